@@ -70,13 +70,25 @@ type app struct {
 	buildCount int64
 	skipBuild  bool
 
+	// fullBuildRequested indicates that all the widgets must be rebuilt at the next build,
+	// including clean rebuild boundaries.
+	fullBuildRequested bool
+
+	// measureCacheGeneration is incremented to invalidate all the measure caches.
+	measureCacheGeneration int64
+
+	// prevChildren is a buffer to detect changes of a widget's children at a build.
+	prevChildren []Widget
+
 	// hitWidgets are widgets and their z values at the cursor position.
 	// hitWidgets are ordered by descending z values.
 	//
 	// Z values are fixed values just after a tree construction, so they are not changed during buildWidgets.
-	hitWidgets []widgetAndZ
+	hitWidgets     []widgetAndZ
+	prevHitWidgets []widgetAndZ
+	tmpHitWidgets  []widgetAndZ
 
-	invalidatedRegions image.Rectangle
+	invalidatedRegions dirtyRegions
 
 	invalidatedRegionsForDebug []invalidatedRegionsForDebugItem
 
@@ -90,8 +102,31 @@ type app struct {
 
 	focusedWidgetState *widgetState
 
+	pointer pointerState
+
+	// pointerCapturingWidget is the widget capturing the pointer. See Context.SetPointerCapture.
+	pointerCapturingWidget Widget
+
 	offscreen   *ebiten.Image
 	debugScreen *ebiten.Image
+
+	screenColorReads []screenColorRead
+
+	timers timers
+
+	// widgetsToMount are the widgets added to the tree at the builds, in the tree order.
+	widgetsToMount []Widget
+
+	// widgetsToUnmount are the widgets removed from their parents at the builds.
+	widgetsToUnmount []Widget
+}
+
+// screenColorRead is a request to read a color on the rendered screen.
+type screenColorRead struct {
+	point image.Point
+	f     func(clr color.Color)
+	clr   color.Color
+	done  bool
 }
 
 type RunOptions struct {
@@ -224,9 +259,28 @@ func (a *app) Update() error {
 		a.focusWidget(a.root.widgetState())
 	}
 
+	a.finishScreenColorReads()
+	a.pointer.updateWithEbiten()
+
+	// Rebuild the tree to reflect the changes by the functions posted from other goroutines.
+	if thePostedFuncs.run() {
+		a.skipBuild = false
+		a.fullBuildRequested = true
+		if theDebugMode.showBuildLogs {
+			slog.Info("rebuilding tree: functions posted")
+		}
+	}
+	if a.timers.tick(a.buildCount) {
+		a.skipBuild = false
+		a.fullBuildRequested = true
+		if theDebugMode.showBuildLogs {
+			slog.Info("rebuilding tree: timers fired")
+		}
+	}
+
 	if s := deviceScaleFactor(); a.deviceScale != s {
 		a.deviceScale = s
-		a.requestRedraw(a.bounds())
+		a.requestRedrawAll()
 	}
 
 	rootState := a.root.widgetState()
@@ -251,6 +305,7 @@ func (a *app) Update() error {
 			slog.Info("pointing input handled", "widget", fmt.Sprintf("%T", r.widget), "aborted", r.aborted)
 		}
 	}
+	a.releasePointerCaptureIfNeeded()
 	if r := a.handleInputWidget(handleInputTypeButton); r.widget != nil {
 		if !r.aborted {
 			inputHandledWidget = r.widget
@@ -265,19 +320,29 @@ func (a *app) Update() error {
 	a.skipBuild = true
 	if dispatchedWidget != nil {
 		a.skipBuild = false
+		a.fullBuildRequested = true
 		if theDebugMode.showBuildLogs {
 			slog.Info("rebuilding tree next time: event dispatched", "widget", fmt.Sprintf("%T", dispatchedWidget))
 		}
-	} else if !a.invalidatedRegions.Empty() {
+	} else if !a.invalidatedRegions.isEmpty() {
 		a.skipBuild = false
 		if theDebugMode.showBuildLogs {
-			slog.Info("rebuilding tree next time: region invalidated", "region", a.invalidatedRegions)
+			slog.Info("rebuilding tree next time: region invalidated", "regions", a.invalidatedRegions.rects)
 		}
 	} else if inputHandledWidget != nil {
 		a.skipBuild = false
 		if theDebugMode.showBuildLogs {
 			slog.Info("rebuilding tree next time: input handled", "widget", fmt.Sprintf("%T", inputHandledWidget))
 		}
+	} else if a.root.widgetState().isRebuildRequested() {
+		a.skipBuild = false
+		if theDebugMode.showBuildLogs {
+			slog.Info("rebuilding tree next time: rebuild requested")
+		}
+	}
+	if inputHandledWidget != nil {
+		// An input handler might change any models.
+		a.fullBuildRequested = true
 	}
 
 	// Call the second buildWidgets to construct the widget tree again to reflect the latest state.
@@ -287,6 +352,7 @@ func (a *app) Update() error {
 	}
 	a.context.inBuild = false
 	a.updateHitWidgets()
+	lifecycleHandlerCalled := a.updateMountedWidgets()
 
 	if !a.cursorShape() {
 		ebiten.SetCursorShape(ebiten.CursorShapeDefault)
@@ -308,7 +374,7 @@ func (a *app) Update() error {
 		a.lastScreenHeight = a.screenHeight
 	}
 	if screenInvalidated {
-		a.requestRedraw(a.bounds())
+		a.requestRedrawAll()
 	} else {
 		// Invalidate regions if a widget's children state is changed.
 		// A widget's bounds might be changed in Update, so do this after updating.
@@ -322,13 +388,25 @@ func (a *app) Update() error {
 	a.skipBuild = true
 	if dispatchedWidget != nil {
 		a.skipBuild = false
+		a.fullBuildRequested = true
 		if theDebugMode.showBuildLogs {
 			slog.Info("rebuilding tree next time: event dispatched", "widget", fmt.Sprintf("%T", dispatchedWidget))
 		}
-	} else if !a.invalidatedRegions.Empty() {
+	} else if lifecycleHandlerCalled {
+		a.skipBuild = false
+		a.fullBuildRequested = true
+		if theDebugMode.showBuildLogs {
+			slog.Info("rebuilding tree next time: widgets mounted or unmounted")
+		}
+	} else if !a.invalidatedRegions.isEmpty() {
+		a.skipBuild = false
+		if theDebugMode.showBuildLogs {
+			slog.Info("rebuilding tree next time: region invalidated", "regions", a.invalidatedRegions.rects)
+		}
+	} else if a.root.widgetState().isRebuildRequested() {
 		a.skipBuild = false
 		if theDebugMode.showBuildLogs {
-			slog.Info("rebuilding tree next time: region invalidated", "region", a.invalidatedRegions)
+			slog.Info("rebuilding tree next time: rebuild requested")
 		}
 	}
 
@@ -342,13 +420,13 @@ func (a *app) Update() error {
 			}
 		}
 
-		if !a.invalidatedRegions.Empty() {
+		for _, region := range a.invalidatedRegions.rects {
 			idx := slices.IndexFunc(a.invalidatedRegionsForDebug, func(i invalidatedRegionsForDebugItem) bool {
-				return i.region.Eq(a.invalidatedRegions)
+				return i.region.Eq(region)
 			})
 			if idx < 0 {
 				a.invalidatedRegionsForDebug = append(a.invalidatedRegionsForDebug, invalidatedRegionsForDebugItem{
-					region: a.invalidatedRegions,
+					region: region,
 					time:   invalidatedRegionForDebugMaxTime(),
 				})
 			} else {
@@ -375,8 +453,38 @@ func (a *app) Draw(screen *ebiten.Image) {
 		screen = a.offscreen
 	}
 	a.drawWidget(screen)
+	a.readScreenColors(screen)
 	a.drawDebugIfNeeded(origScreen)
-	a.invalidatedRegions = image.Rectangle{}
+	a.invalidatedRegions.reset()
+}
+
+// readScreenColors reads the colors requested by Context.ReadScreenColor from the rendered screen.
+func (a *app) readScreenColors(screen *ebiten.Image) {
+	for i := range a.screenColorReads {
+		r := &a.screenColorReads[i]
+		if r.done {
+			continue
+		}
+		r.clr = screen.At(r.point.X, r.point.Y)
+		r.done = true
+	}
+}
+
+// finishScreenColorReads calls the functions of the requests whose colors are already read.
+func (a *app) finishScreenColorReads() {
+	if len(a.screenColorReads) == 0 {
+		return
+	}
+	// A function might request another read.
+	reads := a.screenColorReads
+	a.screenColorReads = nil
+	for _, r := range reads {
+		if !r.done {
+			a.screenColorReads = append(a.screenColorReads, r)
+			continue
+		}
+		r.f(r.clr)
+	}
 }
 
 func (a *app) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -391,7 +499,14 @@ func (a *app) LayoutF(outsideWidth, outsideHeight float64) (float64, float64) {
 }
 
 func (a *app) requestRedraw(region image.Rectangle) {
-	a.invalidatedRegions = a.invalidatedRegions.Union(region)
+	a.invalidatedRegions.add(region)
+}
+
+// requestRedrawAll requests to redraw the entire screen and to rebuild all the widgets.
+// This is used when a state that any widget might read, like the scale or the color mode, is changed.
+func (a *app) requestRedrawAll() {
+	a.requestRedraw(a.bounds())
+	a.fullBuildRequested = true
 }
 
 func (a *app) requestRedrawWidget(widget Widget) {
@@ -417,6 +532,12 @@ func (a *app) buildWidgets() error {
 	}
 
 	a.buildCount++
+	fullBuild := a.fullBuildRequested
+	a.fullBuildRequested = false
+	if fullBuild {
+		// Any models that widgets read might be changed.
+		a.measureCacheGeneration++
+	}
 
 	clear(a.visitedZs)
 	if a.visitedZs == nil {
@@ -425,65 +546,143 @@ func (a *app) buildWidgets() error {
 
 	a.root.widgetState().builtAt = a.buildCount
 
-	_ = traverseWidget(a.root, func(widget Widget) error {
-		clear(widget.widgetState().eventHandlers)
-		return nil
-	})
+	theEventRegistration.buildCount = a.buildCount
+	defer func() {
+		theEventRegistration.owner = nil
+	}()
 
 	var adder ChildAdder
-	if err := traverseWidget(a.root, func(widget Widget) error {
-		widgetState := widget.widgetState()
+	if err := a.buildWidget(a.root, &adder, fullBuild); err != nil {
+		return err
+	}
 
-		if parent := widgetState.parent; parent != nil {
-			widgetState.z = parent.widgetState().z + widget.ZDelta()
-		} else {
-			widgetState.z = 0
-		}
-		widgetState.hasVisibleBoundsCache = false
-		widgetState.visibleBoundsCache = image.Rectangle{}
+	a.zs = slices.Delete(a.zs, 0, len(a.zs))
+	a.zs = slices.AppendSeq(a.zs, maps.Keys(a.visitedZs))
+	slices.Sort(a.zs)
 
-		// Call AddChildren.
-		widgetState.children = slices.Delete(widgetState.children, 0, len(widgetState.children))
-		adder.app = a
-		adder.widget = widget
-		widget.AddChildren(&a.context, &adder)
+	return nil
+}
 
-		// Call Update.
-		if err := widget.Update(&a.context); err != nil {
-			return err
-		}
+func (a *app) prepareWidgetToBuild(widget Widget) {
+	widgetState := widget.widgetState()
+	if parent := widgetState.parent; parent != nil {
+		widgetState.z = parent.widgetState().z + widget.ZDelta()
+	} else {
+		widgetState.z = 0
+	}
+	widgetState.hasVisibleBoundsCache = false
+	widgetState.visibleBoundsCache = image.Rectangle{}
+	a.visitedZs[widgetState.z] = struct{}{}
+}
 
-		// Call Layout.
-		for _, child := range widget.widgetState().children {
-			child.widgetState().bounds = widget.Layout(&a.context, child)
-		}
+// canSkipBuild reports whether widget and its descendants can be kept as they are at the current build.
+func (a *app) canSkipBuild(widget Widget, fullBuild bool) bool {
+	widgetState := widget.widgetState()
+	if fullBuild || !widgetState.rebuildBoundary || widgetState.isRebuildRequested() {
+		return false
+	}
+	// A widget that was not in the tree at the last build might have a stale state.
+	if widgetState.lastBuildCount != a.buildCount-1 {
+		return false
+	}
+	return widgetState.bounds == widgetState.builtBounds && a.context.VisibleBounds(widget) == widgetState.builtVisibleBounds
+}
 
-		a.visitedZs[widgetState.z] = struct{}{}
+func (a *app) buildWidget(widget Widget, adder *ChildAdder, fullBuild bool) error {
+	a.prepareWidgetToBuild(widget)
 
+	widgetState := widget.widgetState()
+	if a.canSkipBuild(widget, fullBuild) {
+		for _, child := range widgetState.children {
+			a.keepWidget(child)
+		}
+		widgetState.lastBuildCount = a.buildCount
+		a.recordMountedWidget(widget)
 		return nil
-	}); err != nil {
+	}
+
+	widgetState.rebuildRequested = false
+	widgetState.descendantRebuildRequested = false
+
+	// Event handlers are registered again by the widget and its ancestors at this build.
+	// Remove only the handlers registered at earlier builds, as the ancestors have already registered handlers.
+	theEventRegistration.owner = widgetState
+	widgetState.removeStaleEventHandlers(a.buildCount)
+
+	// Call AddChildren.
+	a.prevChildren = slices.Delete(a.prevChildren, 0, len(a.prevChildren))
+	a.prevChildren = append(a.prevChildren, widgetState.children...)
+	widgetState.children = slices.Delete(widgetState.children, 0, len(widgetState.children))
+	adder.app = a
+	adder.widget = widget
+	widget.AddChildren(&a.context, adder)
+	if !slices.Equal(a.prevChildren, widgetState.children) {
+		invalidateMeasureCache(widgetState)
+		a.recordRemovedChildren(a.prevChildren, widgetState.children)
+	}
+
+	// Call Update.
+	if err := widget.Update(&a.context); err != nil {
 		return err
 	}
 
-	a.zs = slices.Delete(a.zs, 0, len(a.zs))
-	a.zs = slices.AppendSeq(a.zs, maps.Keys(a.visitedZs))
-	slices.Sort(a.zs)
+	// Call Layout.
+	for _, child := range widgetState.children {
+		child.widgetState().bounds = widget.Layout(&a.context, child)
+	}
 
+	widgetState.lastBuildCount = a.buildCount
+	a.recordMountedWidget(widget)
+	widgetState.builtBounds = widgetState.bounds
+	widgetState.builtVisibleBounds = a.context.VisibleBounds(widget)
+
+	for _, child := range widgetState.children {
+		if err := a.buildWidget(child, adder, fullBuild); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// keepWidget keeps widget and its descendants in the tree without calling AddChildren, Update or Layout.
+func (a *app) keepWidget(widget Widget) {
+	widgetState := widget.widgetState()
+	widgetState.builtAt = a.buildCount
+	widgetState.lastBuildCount = a.buildCount
+	a.recordMountedWidget(widget)
+	a.prepareWidgetToBuild(widget)
+	for _, child := range widgetState.children {
+		a.keepWidget(child)
+	}
+}
+
 func (a *app) updateHitWidgets() {
-	pt := image.Pt(ebiten.CursorPosition())
+	pt := a.pointer.position
 	if a.skipBuild && pt == a.lastCursorPosition {
 		return
 	}
 	a.lastCursorPosition = pt
 
+	a.prevHitWidgets = slices.Delete(a.prevHitWidgets, 0, len(a.prevHitWidgets))
+	a.prevHitWidgets = append(a.prevHitWidgets, a.hitWidgets...)
+
 	a.hitWidgets = slices.Delete(a.hitWidgets, 0, len(a.hitWidgets))
 	a.hitWidgets = a.appendWidgetsAt(a.hitWidgets, pt, a.root, true)
 	slices.SortStableFunc(a.hitWidgets, func(a, b widgetAndZ) int {
 		return b.z - a.z
 	})
+
+	// Rebuild the widgets whose hit states are changed, as they might read the hit states in Update.
+	for _, wz := range a.prevHitWidgets {
+		if !slices.ContainsFunc(a.hitWidgets, func(w widgetAndZ) bool { return w.widget == wz.widget }) {
+			requestRebuild(wz.widget.widgetState())
+		}
+	}
+	for _, wz := range a.hitWidgets {
+		if !slices.ContainsFunc(a.prevHitWidgets, func(w widgetAndZ) bool { return w.widget == wz.widget }) {
+			requestRebuild(wz.widget.widgetState())
+		}
+	}
 }
 
 type handleInputType int
@@ -494,6 +693,11 @@ const (
 )
 
 func (a *app) handleInputWidget(typ handleInputType) HandleInputResult {
+	if typ == handleInputTypePointing {
+		if r, ok := a.handlePointerCapturingWidget(); ok {
+			return r
+		}
+	}
 	for i := len(a.zs) - 1; i >= 0; i-- {
 		z := a.zs[i]
 		if r := a.doHandleInputWidget(typ, a.root, z); r.shouldRaise() {
@@ -615,12 +819,12 @@ func (a *app) resetPrevWidgets(widget Widget) {
 }
 
 func (a *app) drawWidget(screen *ebiten.Image) {
-	if a.invalidatedRegions.Empty() {
-		return
-	}
-	dst := screen.SubImage(a.invalidatedRegions).(*ebiten.Image)
-	for _, z := range a.zs {
-		a.doDrawWidget(dst, a.root, z)
+	// Draw each region separately not to redraw the region between distant regions.
+	for _, region := range a.invalidatedRegions.rects {
+		dst := screen.SubImage(region).(*ebiten.Image)
+		for _, z := range a.zs {
+			a.doDrawWidget(dst, a.root, z)
+		}
 	}
 }
 
@@ -645,7 +849,8 @@ func (a *app) doDrawWidget(dst *ebiten.Image, widget Widget, zToRender int) {
 	if renderCurrent {
 		if useOffscreen {
 			origDst = dst
-			dst = widgetState.ensureOffscreen(dst.Bounds())
+			// Allocate the offscreen for the visible bounds, so that it can be reused for any region.
+			dst = widgetState.ensureOffscreen(vb).SubImage(dst.Bounds()).(*ebiten.Image)
 			dst.Clear()
 		}
 		widget.Draw(&a.context, dst.SubImage(vb).(*ebiten.Image))
@@ -700,6 +905,22 @@ func (a *app) drawDebugIfNeeded(screen *ebiten.Image) {
 }
 
 func (a *app) isWidgetHit(widget Widget) bool {
+	return isWidgetInHitWidgets(a.hitWidgets, widget)
+}
+
+func (a *app) isWidgetHitAt(widget Widget, point image.Point) bool {
+	if point == a.lastCursorPosition {
+		return a.isWidgetHit(widget)
+	}
+	a.tmpHitWidgets = slices.Delete(a.tmpHitWidgets, 0, len(a.tmpHitWidgets))
+	a.tmpHitWidgets = a.appendWidgetsAt(a.tmpHitWidgets, point, a.root, true)
+	slices.SortStableFunc(a.tmpHitWidgets, func(a, b widgetAndZ) int {
+		return b.z - a.z
+	})
+	return isWidgetInHitWidgets(a.tmpHitWidgets, widget)
+}
+
+func isWidgetInHitWidgets(hitWidgets []widgetAndZ, widget Widget) bool {
 	// Now this condition is removed temporarily.
 	// For example, this affects a detection of hovering a child widget in a parent widget.
 	// TODO: Revisit this condition.
@@ -709,7 +930,7 @@ func (a *app) isWidgetHit(widget Widget) bool {
 
 	// hitWidgets are ordered by descending z values.
 	// Always use a fixed set hitWidgets, as the tree might be dynamically changed during buildWidgets.
-	for _, wz := range a.hitWidgets {
+	for _, wz := range hitWidgets {
 		z1 := wz.z
 		z2 := widget.widgetState().z
 		if z1 > z2 {
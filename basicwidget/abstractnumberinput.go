@@ -6,27 +6,49 @@ package basicwidget
 import (
 	"math"
 	"math/big"
-	"strings"
+	"strconv"
 
 	"github.com/guigui-gui/guigui"
 )
 
 const (
-	abstractNumberInputEventValueChanged       = "valueChanged"
-	abstractNumberInputEventValueChangedString = "valueChangedString"
-	abstractNumberInputEventValueChangedBigInt = "valueChangedBigInt"
-	abstractNumberInputEventValueChangedInt64  = "valueChangedInt64"
-	abstractNumberInputEventValueChangedUint64 = "valueChangedUint64"
+	abstractNumberInputEventValueChanged        = "valueChanged"
+	abstractNumberInputEventValueChangedString  = "valueChangedString"
+	abstractNumberInputEventValueChangedBigInt  = "valueChangedBigInt"
+	abstractNumberInputEventValueChangedInt64   = "valueChangedInt64"
+	abstractNumberInputEventValueChangedUint64  = "valueChangedUint64"
+	abstractNumberInputEventValueChangedFloat64 = "valueChangedFloat64"
+	abstractNumberInputEventValueChangedBigRat  = "valueChangedBigRat"
+)
+
+// RoundingMode represents how a value is rounded to the precision.
+type RoundingMode int
+
+const (
+	// RoundingModeHalfAwayFromZero rounds to the nearest, and ties away from zero.
+	RoundingModeHalfAwayFromZero RoundingMode = iota
+
+	// RoundingModeHalfEven rounds to the nearest, and ties to even.
+	RoundingModeHalfEven
+
+	RoundingModeTowardZero
+	RoundingModeAwayFromZero
+	RoundingModeFloor
+	RoundingModeCeiling
 )
 
 type abstractNumberInput struct {
-	value   big.Int
-	min     big.Int
+	value   big.Rat
+	min     big.Rat
 	minSet  bool
-	max     big.Int
+	max     big.Rat
 	maxSet  bool
-	step    big.Int
+	step    big.Rat
 	stepSet bool
+
+	// precision is the number of digits after the decimal point.
+	precision    int
+	roundingMode RoundingMode
 }
 
 func (a *abstractNumberInput) SetOnValueChanged(widget guigui.Widget, f func(value int, committed bool)) {
@@ -49,94 +71,161 @@ func (a *abstractNumberInput) SetOnValueChangedUint64(widget guigui.Widget, f fu
 	guigui.RegisterEventHandler(widget, abstractNumberInputEventValueChangedUint64, f)
 }
 
+func (a *abstractNumberInput) SetOnValueChangedFloat64(widget guigui.Widget, f func(value float64, committed bool)) {
+	guigui.RegisterEventHandler(widget, abstractNumberInputEventValueChangedFloat64, f)
+}
+
+func (a *abstractNumberInput) SetOnValueChangedBigRat(widget guigui.Widget, f func(value *big.Rat, committed bool)) {
+	guigui.RegisterEventHandler(widget, abstractNumberInputEventValueChangedBigRat, f)
+}
+
 func (a *abstractNumberInput) fireValueChangeEvents(widget guigui.Widget, force bool, committed bool) {
 	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChanged, a.Value(), committed)
-	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChangedString, a.value.String(), force)
+	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChangedString, a.ValueString(), force)
 	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChangedBigInt, a.ValueBigInt(), committed)
 	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChangedInt64, a.ValueInt64(), committed)
 	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChangedUint64, a.ValueUint64(), committed)
+	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChangedFloat64, a.ValueFloat64(), committed)
+	guigui.DispatchEventHandler(widget, abstractNumberInputEventValueChangedBigRat, a.ValueBigRat(), committed)
 }
 
-func (a *abstractNumberInput) Value() int {
-	if a.value.Cmp(&maxInt) > 0 {
+// ratToInt returns the integer part of r. The fractional part is truncated.
+func ratToInt(r *big.Rat) *big.Int {
+	if r.IsInt() {
+		return (&big.Int{}).Set(r.Num())
+	}
+	return (&big.Int{}).Quo(r.Num(), r.Denom())
+}
+
+func bigIntToInt(v *big.Int) int {
+	if v.Cmp(&maxInt) > 0 {
 		return math.MaxInt
 	}
-	if a.value.Cmp(&minInt) < 0 {
+	if v.Cmp(&minInt) < 0 {
 		return math.MinInt
 	}
-	if a.value.IsInt64() {
-		return int(a.value.Int64())
+	return int(v.Int64())
+}
+
+func bigIntToInt64(v *big.Int) int64 {
+	if v.Cmp(&maxInt64) > 0 {
+		return math.MaxInt64
+	}
+	if v.Cmp(&minInt64) < 0 {
+		return math.MinInt64
+	}
+	return v.Int64()
+}
+
+func bigIntToUint64(v *big.Int) uint64 {
+	if v.Cmp(&maxUint64) > 0 {
+		return math.MaxUint64
 	}
-	return 0
+	if v.Sign() < 0 {
+		return 0
+	}
+	return v.Uint64()
+}
+
+// Value returns the integer part of the value.
+func (a *abstractNumberInput) Value() int {
+	return bigIntToInt(ratToInt(&a.value))
 }
 
+// ValueString returns the value in the decimal notation with the precision.
 func (a *abstractNumberInput) ValueString() string {
-	return a.value.String()
+	return a.value.FloatString(a.precision)
 }
 
 func (a *abstractNumberInput) ValueBigInt() *big.Int {
-	return (&big.Int{}).Set(&a.value)
+	return ratToInt(&a.value)
 }
 
 func (a *abstractNumberInput) ValueInt64() int64 {
-	if a.value.IsInt64() {
-		return a.value.Int64()
-	}
-	if a.value.Cmp(&maxInt64) > 0 {
-		return math.MaxInt64
-	}
-	if a.value.Cmp(&minInt64) < 0 {
-		return math.MinInt64
-	}
-	return 0
+	return bigIntToInt64(ratToInt(&a.value))
 }
 
 func (a *abstractNumberInput) ValueUint64() uint64 {
-	if a.value.IsUint64() {
-		return a.value.Uint64()
-	}
-	if a.value.Cmp(&maxUint64) > 0 {
-		return math.MaxUint64
-	}
-	if a.value.Cmp(big.NewInt(0)) < 0 {
-		return 0
-	}
-	return 0
+	return bigIntToUint64(ratToInt(&a.value))
+}
+
+func (a *abstractNumberInput) ValueFloat64() float64 {
+	f, _ := a.value.Float64()
+	return f
+}
+
+func (a *abstractNumberInput) ValueBigRat() *big.Rat {
+	return (&big.Rat{}).Set(&a.value)
 }
 
 func (a *abstractNumberInput) SetValue(widget guigui.Widget, value int, committed bool) {
-	a.setValue(widget, (&big.Int{}).SetInt64(int64(value)), false, committed)
+	a.setValue(widget, (&big.Rat{}).SetInt64(int64(value)), false, committed)
 }
 
 func (a *abstractNumberInput) SetValueBigInt(widget guigui.Widget, value *big.Int, committed bool) {
-	a.setValue(widget, value, false, committed)
+	a.setValue(widget, (&big.Rat{}).SetInt(value), false, committed)
 }
 
 func (a *abstractNumberInput) SetValueInt64(widget guigui.Widget, value int64, committed bool) {
-	a.setValue(widget, (&big.Int{}).SetInt64(value), false, committed)
+	a.setValue(widget, (&big.Rat{}).SetInt64(value), false, committed)
 }
 
 func (a *abstractNumberInput) SetValueUint64(widget guigui.Widget, value uint64, committed bool) {
-	a.setValue(widget, (&big.Int{}).SetUint64(value), false, committed)
+	a.setValue(widget, (&big.Rat{}).SetUint64(value), false, committed)
+}
+
+func (a *abstractNumberInput) SetValueFloat64(widget guigui.Widget, value float64, committed bool) {
+	v, ok := float64ToRat(value)
+	if !ok {
+		return
+	}
+	a.setValue(widget, v, false, committed)
+}
+
+func (a *abstractNumberInput) SetValueBigRat(widget guigui.Widget, value *big.Rat, committed bool) {
+	a.setValue(widget, (&big.Rat{}).Set(value), false, committed)
 }
 
 func (a *abstractNumberInput) ForceSetValue(widget guigui.Widget, value int, committed bool) {
-	a.setValue(widget, (&big.Int{}).SetInt64(int64(value)), true, committed)
+	a.setValue(widget, (&big.Rat{}).SetInt64(int64(value)), true, committed)
 }
 
 func (a *abstractNumberInput) ForceSetValueBigInt(widget guigui.Widget, value *big.Int, committed bool) {
-	a.setValue(widget, value, true, committed)
+	a.setValue(widget, (&big.Rat{}).SetInt(value), true, committed)
 }
 
 func (a *abstractNumberInput) ForceSetValueInt64(widget guigui.Widget, value int64, committed bool) {
-	a.setValue(widget, (&big.Int{}).SetInt64(value), true, committed)
+	a.setValue(widget, (&big.Rat{}).SetInt64(value), true, committed)
 }
 
 func (a *abstractNumberInput) ForceSetValueUint64(widget guigui.Widget, value uint64, committed bool) {
-	a.setValue(widget, (&big.Int{}).SetUint64(value), true, committed)
+	a.setValue(widget, (&big.Rat{}).SetUint64(value), true, committed)
+}
+
+func (a *abstractNumberInput) ForceSetValueFloat64(widget guigui.Widget, value float64, committed bool) {
+	v, ok := float64ToRat(value)
+	if !ok {
+		return
+	}
+	a.setValue(widget, v, true, committed)
+}
+
+func (a *abstractNumberInput) ForceSetValueBigRat(widget guigui.Widget, value *big.Rat, committed bool) {
+	a.setValue(widget, (&big.Rat{}).Set(value), true, committed)
+}
+
+// float64ToRat converts a finite float64 value to a big.Rat.
+// The value is rounded to the shortest decimal representation to avoid binary fractions like 0.1000000000000000055511151231257827.
+func float64ToRat(value float64) (*big.Rat, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, false
+	}
+	return (&big.Rat{}).SetString(strconv.FormatFloat(value, 'g', -1, 64))
 }
 
-func (a *abstractNumberInput) setValue(widget guigui.Widget, value *big.Int, force bool, committed bool) {
+// setValue sets the value. value might be modified.
+func (a *abstractNumberInput) setValue(widget guigui.Widget, value *big.Rat, force bool, committed bool) {
+	roundRat(value, a.precision, a.roundingMode)
 	a.clamp(value)
 	if a.value.Cmp(value) == 0 {
 		return
@@ -145,76 +234,182 @@ func (a *abstractNumberInput) setValue(widget guigui.Widget, value *big.Int, for
 	a.fireValueChangeEvents(widget, force, committed)
 }
 
+// Precision returns the number of digits after the decimal point.
+func (a *abstractNumberInput) Precision() int {
+	return a.precision
+}
+
+// SetPrecision sets the number of digits after the decimal point.
+// The value is rounded to the precision by the rounding mode.
+func (a *abstractNumberInput) SetPrecision(widget guigui.Widget, precision int) {
+	precision = max(precision, 0)
+	if a.precision == precision {
+		return
+	}
+	a.precision = precision
+	a.SetValueBigRat(widget, &a.value, true)
+}
+
+func (a *abstractNumberInput) SetRoundingMode(widget guigui.Widget, mode RoundingMode) {
+	if a.roundingMode == mode {
+		return
+	}
+	a.roundingMode = mode
+	a.SetValueBigRat(widget, &a.value, true)
+}
+
+// roundRat rounds x to precision digits after the decimal point by mode.
+func roundRat(x *big.Rat, precision int, mode RoundingMode) {
+	if x.IsInt() && precision >= 0 {
+		return
+	}
+	scale := (&big.Int{}).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	num := (&big.Int{}).Mul(x.Num(), scale)
+	denom := x.Denom()
+
+	// q is truncated toward zero.
+	q, r := (&big.Int{}).QuoRem(num, denom, &big.Int{})
+	if r.Sign() == 0 {
+		return
+	}
+
+	var awayFromZero bool
+	switch mode {
+	case RoundingModeHalfAwayFromZero, RoundingModeHalfEven:
+		// Compare |2r| and denom.
+		c := (&big.Int{}).Mul((&big.Int{}).Abs(r), big.NewInt(2)).Cmp(denom)
+		switch {
+		case c > 0:
+			awayFromZero = true
+		case c == 0:
+			if mode == RoundingModeHalfAwayFromZero {
+				awayFromZero = true
+			} else {
+				awayFromZero = q.Bit(0) == 1
+			}
+		}
+	case RoundingModeTowardZero:
+	case RoundingModeAwayFromZero:
+		awayFromZero = true
+	case RoundingModeFloor:
+		awayFromZero = num.Sign() < 0
+	case RoundingModeCeiling:
+		awayFromZero = num.Sign() > 0
+	}
+	if awayFromZero {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	x.SetFrac(q, scale)
+}
+
 func (a *abstractNumberInput) MinimumValueBigInt() *big.Int {
 	if !a.minSet {
 		return nil
 	}
-	return (&big.Int{}).Set(&a.min)
+	return ratToInt(&a.min)
+}
+
+func (a *abstractNumberInput) MinimumValueBigRat() *big.Rat {
+	if !a.minSet {
+		return nil
+	}
+	return (&big.Rat{}).Set(&a.min)
 }
 
 func (a *abstractNumberInput) SetMinimumValue(widget guigui.Widget, minimum int) {
-	a.min.SetInt64(int64(minimum))
-	a.minSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetMinimumValueBigRat(widget, (&big.Rat{}).SetInt64(int64(minimum)))
 }
 
 func (a *abstractNumberInput) SetMinimumValueBigInt(widget guigui.Widget, minimum *big.Int) {
 	if minimum == nil {
-		a.min = big.Int{}
-		a.minSet = false
+		a.SetMinimumValueBigRat(widget, nil)
 		return
 	}
-	a.min.Set(minimum)
-	a.minSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetMinimumValueBigRat(widget, (&big.Rat{}).SetInt(minimum))
 }
 
 func (a *abstractNumberInput) SetMinimumValueInt64(widget guigui.Widget, minimum int64) {
-	a.min.SetInt64(minimum)
-	a.minSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetMinimumValueBigRat(widget, (&big.Rat{}).SetInt64(minimum))
 }
 
 func (a *abstractNumberInput) SetMinimumValueUint64(widget guigui.Widget, minimum uint64) {
-	a.min.SetUint64(minimum)
+	a.SetMinimumValueBigRat(widget, (&big.Rat{}).SetUint64(minimum))
+}
+
+func (a *abstractNumberInput) SetMinimumValueFloat64(widget guigui.Widget, minimum float64) {
+	v, ok := float64ToRat(minimum)
+	if !ok {
+		return
+	}
+	a.SetMinimumValueBigRat(widget, v)
+}
+
+func (a *abstractNumberInput) SetMinimumValueBigRat(widget guigui.Widget, minimum *big.Rat) {
+	if minimum == nil {
+		a.min = big.Rat{}
+		a.minSet = false
+		return
+	}
+	a.min.Set(minimum)
 	a.minSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetValueBigRat(widget, &a.value, true)
 }
 
 func (a *abstractNumberInput) MaximumValueBigInt() *big.Int {
 	if !a.maxSet {
 		return nil
 	}
-	return (&big.Int{}).Set(&a.max)
+	return ratToInt(&a.max)
+}
+
+func (a *abstractNumberInput) MaximumValueBigRat() *big.Rat {
+	if !a.maxSet {
+		return nil
+	}
+	return (&big.Rat{}).Set(&a.max)
 }
 
 func (a *abstractNumberInput) SetMaximumValue(widget guigui.Widget, maximum int) {
-	a.max.SetInt64(int64(maximum))
-	a.maxSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetMaximumValueBigRat(widget, (&big.Rat{}).SetInt64(int64(maximum)))
 }
 
 func (a *abstractNumberInput) SetMaximumValueBigInt(widget guigui.Widget, maximum *big.Int) {
 	if maximum == nil {
-		a.max = big.Int{}
-		a.maxSet = false
+		a.SetMaximumValueBigRat(widget, nil)
 		return
 	}
-	a.max.Set(maximum)
-	a.maxSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetMaximumValueBigRat(widget, (&big.Rat{}).SetInt(maximum))
 }
 
 func (a *abstractNumberInput) SetMaximumValueInt64(widget guigui.Widget, maximum int64) {
-	a.max.SetInt64(maximum)
-	a.maxSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetMaximumValueBigRat(widget, (&big.Rat{}).SetInt64(maximum))
 }
 
 func (a *abstractNumberInput) SetMaximumValueUint64(widget guigui.Widget, maximum uint64) {
-	a.max.SetUint64(maximum)
+	a.SetMaximumValueBigRat(widget, (&big.Rat{}).SetUint64(maximum))
+}
+
+func (a *abstractNumberInput) SetMaximumValueFloat64(widget guigui.Widget, maximum float64) {
+	v, ok := float64ToRat(maximum)
+	if !ok {
+		return
+	}
+	a.SetMaximumValueBigRat(widget, v)
+}
+
+func (a *abstractNumberInput) SetMaximumValueBigRat(widget guigui.Widget, maximum *big.Rat) {
+	if maximum == nil {
+		a.max = big.Rat{}
+		a.maxSet = false
+		return
+	}
+	a.max.Set(maximum)
 	a.maxSet = true
-	a.SetValueBigInt(widget, (&big.Int{}).Set(&a.value), true)
+	a.SetValueBigRat(widget, &a.value, true)
 }
 
 func (a *abstractNumberInput) SetStep(step int) {
@@ -224,11 +419,11 @@ func (a *abstractNumberInput) SetStep(step int) {
 
 func (a *abstractNumberInput) SetStepBigInt(step *big.Int) {
 	if step == nil {
-		a.step = big.Int{}
+		a.step = big.Rat{}
 		a.stepSet = false
 		return
 	}
-	a.step.Set(step)
+	a.step.SetInt(step)
 	a.stepSet = true
 }
 
@@ -242,7 +437,34 @@ func (a *abstractNumberInput) SetStepUint64(step uint64) {
 	a.stepSet = true
 }
 
-func (a *abstractNumberInput) clamp(value *big.Int) {
+func (a *abstractNumberInput) SetStepFloat64(step float64) {
+	v, ok := float64ToRat(step)
+	if !ok {
+		return
+	}
+	a.step.Set(v)
+	a.stepSet = true
+}
+
+func (a *abstractNumberInput) SetStepBigRat(step *big.Rat) {
+	if step == nil {
+		a.step = big.Rat{}
+		a.stepSet = false
+		return
+	}
+	a.step.Set(step)
+	a.stepSet = true
+}
+
+// actualStep returns the step. The default step is the unit of the precision.
+func (a *abstractNumberInput) actualStep() *big.Rat {
+	if a.stepSet {
+		return (&big.Rat{}).Set(&a.step)
+	}
+	return (&big.Rat{}).SetFrac(big.NewInt(1), (&big.Int{}).Exp(big.NewInt(10), big.NewInt(int64(a.precision)), nil))
+}
+
+func (a *abstractNumberInput) clamp(value *big.Rat) {
 	if a.minSet && value.Cmp(&a.min) < 0 {
 		value.Set(&a.min)
 		return
@@ -254,70 +476,96 @@ func (a *abstractNumberInput) clamp(value *big.Int) {
 }
 
 func (a *abstractNumberInput) Rate() float64 {
+	return a.rateOf(&a.value)
+}
+
+// rateOf returns the rate of value in the range between the minimum and the maximum values.
+func (a *abstractNumberInput) rateOf(value *big.Rat) float64 {
 	if !a.maxSet || !a.minSet {
 		return math.NaN()
 	}
 
-	numer := (&big.Int{}).Sub(&a.value, &a.min)
-	denom := (&big.Int{}).Sub(&a.max, &a.min)
+	numer := (&big.Rat{}).Sub(value, &a.min)
+	denom := (&big.Rat{}).Sub(&a.max, &a.min)
 	if denom.Sign() == 0 {
 		return math.NaN()
 	}
 
-	x, _ := (&big.Rat{}).Quo((&big.Rat{}).SetInt(numer), (&big.Rat{}).SetInt(denom)).Float64()
+	x, _ := (&big.Rat{}).Quo(numer, denom).Float64()
 	return x
 }
 
 func (a *abstractNumberInput) SetRate(rate float64) {
 }
 
-var numberTextReplacer = strings.NewReplacer(
-	"\u2212", "-",
-	"\ufe62", "+",
-	"\ufe63", "-",
-	"\uff0b", "+",
-	"\uff0d", "-",
-	"\uff10", "0",
-	"\uff11", "1",
-	"\uff12", "2",
-	"\uff13", "3",
-	"\uff14", "4",
-	"\uff15", "5",
-	"\uff16", "6",
-	"\uff17", "7",
-	"\uff18", "8",
-	"\uff19", "9",
-)
+// maxDecimalExponent is the maximum absolute value of an exponent accepted by parseDecimal.
+// A huge exponent like "1e1000000" would make a huge rational number.
+const maxDecimalExponent = 1000
 
-func (a *abstractNumberInput) SetString(widget guigui.Widget, text string, force bool, committed bool) {
-	text = strings.TrimSpace(text)
-	text = numberTextReplacer.Replace(text)
+// parseDecimal parses a number in the decimal notation like "-12", "3.25", ".5" or "1e-3".
+// A fraction like "1/3", a prefix like "0x" and an exponent beyond maxDecimalExponent are not accepted.
+func parseDecimal(text string) (*big.Rat, bool) {
+	if !isDecimal(text) {
+		return nil, false
+	}
+	return (&big.Rat{}).SetString(text)
+}
 
-	var v big.Int
-	if _, ok := v.SetString(text, 10); !ok {
-		return
+// isDecimal reports whether text matches [+-]?(digits(.digits?)?|.digits)([eE][+-]?digits)?,
+// and the exponent is within maxDecimalExponent.
+func isDecimal(text string) bool {
+	i := 0
+	if i < len(text) && (text[i] == '+' || text[i] == '-') {
+		i++
 	}
-	a.setValue(widget, &v, force, committed)
+
+	var mantissaDigits int
+	for i < len(text) && '0' <= text[i] && text[i] <= '9' {
+		i++
+		mantissaDigits++
+	}
+	if i < len(text) && text[i] == '.' {
+		i++
+		for i < len(text) && '0' <= text[i] && text[i] <= '9' {
+			i++
+			mantissaDigits++
+		}
+	}
+	if mantissaDigits == 0 {
+		return false
+	}
+
+	if i < len(text) && (text[i] == 'e' || text[i] == 'E') {
+		i++
+		if i < len(text) && (text[i] == '+' || text[i] == '-') {
+			i++
+		}
+		var exp int
+		var expDigits int
+		for i < len(text) && '0' <= text[i] && text[i] <= '9' {
+			exp = exp*10 + int(text[i]-'0')
+			if exp > maxDecimalExponent {
+				return false
+			}
+			i++
+			expDigits++
+		}
+		if expDigits == 0 {
+			return false
+		}
+	}
+
+	return i == len(text)
 }
 
 func (n *abstractNumberInput) Increment(widget guigui.Widget) {
-	var step big.Int
-	if n.stepSet {
-		step.Set(&n.step)
-	} else {
-		step.SetInt64(1)
-	}
-	n.setValue(widget, (&big.Int{}).Add(&n.value, &step), true, true)
+	step := n.actualStep()
+	n.setValue(widget, step.Add(&n.value, step), true, true)
 }
 
 func (n *abstractNumberInput) Decrement(widget guigui.Widget) {
-	var step big.Int
-	if n.stepSet {
-		step.Set(&n.step)
-	} else {
-		step.SetInt64(1)
-	}
-	n.setValue(widget, (&big.Int{}).Sub(&n.value, &step), true, true)
+	step := n.actualStep()
+	n.setValue(widget, step.Sub(&n.value, step), true, true)
 }
 
 func (n *abstractNumberInput) CanIncrement() bool {
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+// ActivityIndicator is a spinner showing that an operation is in progress.
+type ActivityIndicator struct {
+	guigui.DefaultWidget
+
+	stopped bool
+	count   int
+}
+
+// SetAnimating starts or stops the animation. An activity indicator is animating by default.
+// A stopped activity indicator draws nothing.
+func (a *ActivityIndicator) SetAnimating(animating bool) {
+	if a.stopped == !animating {
+		return
+	}
+	a.stopped = !animating
+	guigui.RequestRedraw(a)
+}
+
+func (a *ActivityIndicator) IsAnimating() bool {
+	return !a.stopped
+}
+
+func (a *ActivityIndicator) Tick(context *guigui.Context) error {
+	if a.stopped || !isOnScreen(context, a) {
+		return nil
+	}
+	a.count++
+	guigui.RequestRedraw(a)
+	return nil
+}
+
+func (a *ActivityIndicator) Draw(context *guigui.Context, dst *ebiten.Image) {
+	if a.stopped {
+		return
+	}
+	rate := float64(a.count%ebiten.TPS()) / float64(ebiten.TPS())
+	draw.DrawSpinner(context, dst, context.Bounds(a), activityIndicatorColor(context), rate)
+}
+
+func (a *ActivityIndicator) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	s := defaultIconSize(context)
+	return image.Pt(s, s)
+}
+
+func activityIndicatorColor(context *guigui.Context) color.Color {
+	return draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.6, 0.5)
+}
@@ -7,7 +7,6 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
@@ -70,7 +69,7 @@ func (b *baseButton) HandlePointingInput(context *guigui.Context) guigui.HandleI
 		// IsMouseButtonJustPressed and IsMouseButtonJustReleased can be true at the same time as of Ebitengine v2.9.
 		// Check both.
 		var justPressedOrReleased bool
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if context.IsPointerJustPressed() {
 			if b.keepPressed {
 				return guigui.AbortHandlingInputByWidget(b)
 			}
@@ -82,7 +81,7 @@ func (b *baseButton) HandlePointingInput(context *guigui.Context) guigui.HandleI
 			}
 			justPressedOrReleased = true
 		}
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && b.pressed {
+		if context.IsPointerJustReleased() && b.pressed {
 			if b.keepPressed {
 				return guigui.AbortHandlingInputByWidget(b)
 			}
@@ -99,7 +98,7 @@ func (b *baseButton) HandlePointingInput(context *guigui.Context) guigui.HandleI
 			return guigui.HandleInputByWidget(b)
 		}
 	}
-	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !context.IsPointerPressed() {
 		b.setPressed(false)
 	}
 	return guigui.HandleInputResult{}
@@ -153,7 +152,7 @@ func (b *baseButton) Draw(context *guigui.Context, dst *ebiten.Image) {
 }
 
 func (b *baseButton) canPress(context *guigui.Context) bool {
-	return context.IsEnabled(b) && b.isHovered(context) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !b.keepPressed
+	return context.IsEnabled(b) && b.isHovered(context) && !context.IsPointerPressed() && !b.keepPressed
 }
 
 func (b *baseButton) isHovered(context *guigui.Context) bool {
@@ -161,7 +160,7 @@ func (b *baseButton) isHovered(context *guigui.Context) bool {
 }
 
 func (b *baseButton) isActive(context *guigui.Context) bool {
-	return context.IsEnabled(b) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && b.isHovered(context) && (b.pressed || b.pairedButton != nil && b.pairedButton.pressed)
+	return context.IsEnabled(b) && context.IsPointerPressed() && b.isHovered(context) && (b.pressed || b.pairedButton != nil && b.pairedButton.pressed)
 }
 
 func (b *baseButton) isPressed(context *guigui.Context) bool {
@@ -4,7 +4,6 @@
 package basicwidget
 
 import (
-	"fmt"
 	"image"
 	"image/color"
 	"iter"
@@ -18,8 +17,9 @@ import (
 )
 
 const (
-	baseListEventItemsMoved          = "itemsMoved"
-	baseListEventItemExpanderToggled = "itemExpanderToggled"
+	baseListEventItemsMoved           = "itemsMoved"
+	baseListEventItemExpanderToggled  = "itemExpanderToggled"
+	baseListEventContextMenuRequested = "contextMenuRequested"
 )
 
 type ListStyle int
@@ -64,13 +64,15 @@ type baseList[T comparable] struct {
 	indexToJumpPlus1        int
 	dragSrcIndexPlus1       int
 	dragDstIndexPlus1       int
-	pressStartPlus1         image.Point
 	startPressingIndexPlus1 int
 	headerHeight            int
 	footerHeight            int
 	contentWidthPlus1       int
 	contentHeight           int
 
+	drag      guigui.DragRecognizer
+	longPress guigui.LongPressRecognizer
+
 	itemBoundsForLayoutFromWidget map[guigui.Widget]image.Rectangle
 	itemBoundsForLayoutFromIndex  []image.Rectangle
 }
@@ -91,6 +93,13 @@ func (b *baseList[T]) SetOnItemExpanderToggled(f func(index int, expanded bool))
 	guigui.RegisterEventHandler(b, baseListEventItemExpanderToggled, f)
 }
 
+// SetOnContextMenuRequested sets the function called when a context menu is requested on an item
+// by a right click or a long press.
+// position is the position of the pointer.
+func (b *baseList[T]) SetOnContextMenuRequested(f func(index int, position image.Point)) {
+	guigui.RegisterEventHandler(b, baseListEventContextMenuRequested, f)
+}
+
 func (b *baseList[T]) SetCheckmarkIndex(index int) {
 	if index < 0 {
 		index = -1
@@ -224,19 +233,15 @@ func (b *baseList[T]) Update(context *guigui.Context) error {
 		item, _ := b.abstractList.ItemByIndex(i)
 		itemW := cw - 2*listItemPadding(context)
 		itemW -= item.IndentLevel * listItemIndentSize(context)
-		contentSize := item.Content.Measure(context, guigui.FixedWidthConstraints(itemW))
+		contentSize := context.Measure(item.Content, guigui.FixedWidthConstraints(itemW))
 
 		if b.checkmarkIndexPlus1 == i+1 {
-			colorMode := context.ColorMode()
+			b.checkmark.SetVectorIcon(vectorIconCheck)
 			if i == hoveredItemIndex {
-				colorMode = guigui.ColorModeDark
-			}
-
-			checkImg, err := theResourceImages.Get("check", colorMode)
-			if err != nil {
-				return err
+				b.checkmark.SetIconColor(draw.Color(guigui.ColorModeDark, draw.ColorTypeBase, 0))
+			} else {
+				b.checkmark.SetIconColor(nil)
 			}
-			b.checkmark.SetImage(checkImg)
 
 			imgSize := listItemCheckmarkSize(context)
 			imgP := p
@@ -251,25 +256,19 @@ func (b *baseList[T]) Update(context *guigui.Context) error {
 		}
 
 		if item.IndentLevel > 0 {
-			var img *ebiten.Image
+			var icon *VectorIcon
 			var hasChild bool
 			if nextItem, ok := b.abstractList.ItemByIndex(i + 1); ok {
 				hasChild = nextItem.IndentLevel > item.IndentLevel
 			}
 			if hasChild {
-				var err error
-				var imgName string
 				if item.Collapsed {
-					imgName = "keyboard_arrow_right"
+					icon = vectorIconKeyboardArrowRight
 				} else {
-					imgName = "keyboard_arrow_down"
-				}
-				img, err = theResourceImages.Get(imgName, context.ColorMode())
-				if err != nil {
-					return err
+					icon = vectorIconKeyboardArrowDown
 				}
 			}
-			b.expanderImages[i].SetImage(img)
+			b.expanderImages[i].SetVectorIcon(icon)
 			expanderP := p
 			expanderP.X += (item.IndentLevel - 1) * listItemIndentSize(context)
 			// Adjust the position a bit for better appearance.
@@ -359,7 +358,7 @@ func (b *baseList[T]) hoveredItemIndex(context *guigui.Context) int {
 	if !context.IsWidgetHitAtCursor(b) {
 		return -1
 	}
-	_, y := ebiten.CursorPosition()
+	y := context.PointerPosition().Y
 	_, offsetY := b.scrollOverlay.Offset()
 	y -= RoundedCornerRadius(context) + b.headerHeight
 	y -= context.Bounds(b).Min.Y
@@ -434,7 +433,7 @@ func (b *baseList[T]) ScrollOffset() (float64, float64) {
 }
 
 func (b *baseList[T]) calcDropDstIndex(context *guigui.Context) int {
-	_, y := ebiten.CursorPosition()
+	y := context.PointerPosition().Y
 	for i := range b.visibleItems() {
 		if b := b.itemBounds(context, i); y < (b.Min.Y+b.Max.Y)/2 {
 			return i
@@ -451,10 +450,41 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 		}
 	}
 
+	// Capture the pointer after a drag starts so that the items' content can handle a press.
+	b.drag.CaptureAfterStart = true
+	b.drag.Update(context, b)
+	if b.drag.JustStarted() {
+		// Only the selected movable item can be dragged.
+		if index := b.startPressingIndexPlus1 - 1; index >= 0 && index == b.SelectedItemIndex() {
+			if item, ok := b.abstractList.ItemByIndex(index); ok && item.Movable {
+				b.dragSrcIndexPlus1 = index + 1
+			}
+		}
+		if b.dragSrcIndexPlus1 == 0 {
+			b.drag.Cancel(context, b)
+		}
+	}
+
+	// A long press requests a context menu like a right click, e.g. on touchscreens.
+	b.longPress.Update(context, b)
+	if b.longPress.JustLongPressed() {
+		if index := b.hoveredItemIndex(context); index >= 0 && index < b.abstractList.ItemCount() {
+			if item, _ := b.abstractList.ItemByIndex(index); item.Selectable {
+				b.drag.Cancel(context, b)
+				b.dragSrcIndexPlus1 = 0
+				b.dragDstIndexPlus1 = 0
+				b.startPressingIndexPlus1 = 0
+				guigui.RequestRedraw(b)
+				guigui.DispatchEventHandler(b, baseListEventContextMenuRequested, index, context.PointerPosition())
+				return guigui.HandleInputByWidget(b)
+			}
+		}
+	}
+
 	// Process dragging.
 	if b.dragSrcIndexPlus1 > 0 {
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-			_, y := ebiten.CursorPosition()
+		if b.drag.IsDragging() {
+			y := b.drag.Position().Y
 			p := context.Bounds(b).Min
 			h := context.Bounds(b).Dy() - (b.headerHeight + b.footerHeight)
 			var dy float64
@@ -472,21 +502,22 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 			}
 			return guigui.AbortHandlingInputByWidget(b)
 		}
-		if b.dragDstIndexPlus1 > 0 {
+		// Drop the item only when the drag ends normally. The drag might be discarded, e.g. when another widget handles the input.
+		if b.drag.JustEnded() && b.dragDstIndexPlus1 > 0 {
 			// TODO: Implement multiple items drop.
 			guigui.DispatchEventHandler(b, baseListEventItemsMoved, b.dragSrcIndexPlus1-1, 1, b.dragDstIndexPlus1-1)
-			b.dragDstIndexPlus1 = 0
 		}
 		b.dragSrcIndexPlus1 = 0
+		b.dragDstIndexPlus1 = 0
 		guigui.RequestRedraw(b)
 		return guigui.HandleInputByWidget(b)
 	}
 
 	index := b.hoveredItemIndex(context)
 	if index >= 0 && index < b.abstractList.ItemCount() {
-		c := image.Pt(ebiten.CursorPosition())
+		c := context.PointerPosition()
 
-		left := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+		left := context.IsPointerJustPressed()
 		right := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
 		switch {
 		case (left || right) && context.IsWidgetHitAtCursor(b):
@@ -511,25 +542,19 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 			if b.SelectedItemIndex() != index || !wasFocused || b.style == ListStyleMenu {
 				b.selectItemByIndex(index, true)
 			}
-			b.pressStartPlus1 = c.Add(image.Pt(1, 1))
 			b.startPressingIndexPlus1 = index + 1
 			if left {
 				return guigui.HandleInputByWidget(b)
 			}
+			guigui.DispatchEventHandler(b, baseListEventContextMenuRequested, index, c)
 			// For the right click, give a chance to a parent widget to handle the right click e.g. to open a context menu.
 			// TODO: This behavior seems a little ad-hoc. Consider a better way.
 			return guigui.HandleInputResult{}
 
-		case ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft):
-			item, _ := b.abstractList.ItemByIndex(index)
-			if item.Movable && b.SelectedItemIndex() == index && b.startPressingIndexPlus1-1 == index && (b.pressStartPlus1 != c.Add(image.Pt(1, 1))) {
-				b.dragSrcIndexPlus1 = index + 1
-				return guigui.HandleInputByWidget(b)
-			}
+		case context.IsPointerPressed():
 			return guigui.AbortHandlingInputByWidget(b)
 
-		case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
-			b.pressStartPlus1 = image.Point{}
+		case context.IsPointerJustReleased():
 			b.startPressingIndexPlus1 = 0
 			return guigui.AbortHandlingInputByWidget(b)
 		}
@@ -540,7 +565,6 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 	}
 
 	b.dragSrcIndexPlus1 = 0
-	b.pressStartPlus1 = image.Point{}
 
 	return guigui.HandleInputResult{}
 }
@@ -674,19 +698,15 @@ func (b *baseList[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
 	// Draw a drag indicator.
 	if context.IsEnabled(b) && b.dragSrcIndexPlus1 == 0 {
 		if item, ok := b.abstractList.ItemByIndex(hoveredItemIndex); ok && item.Movable {
-			img, err := theResourceImages.Get("drag_indicator", context.ColorMode())
-			if err != nil {
-				panic(fmt.Sprintf("basicwidget: failed to get drag indicator image: %v", err))
-			}
+			s := 2 * RoundedCornerRadius(context)
+			img := vectorIconDragIndicator.Image(s)
 			op := &ebiten.DrawImageOptions{}
-			s := float64(2*RoundedCornerRadius(context)) / float64(img.Bounds().Dy())
-			op.GeoM.Scale(s, s)
 			bounds := b.itemBounds(context, hoveredItemIndex)
 			p := bounds.Min
 			p.X = context.Bounds(b).Min.X + listItemPadding(context)
-			op.GeoM.Translate(float64(p.X-2*RoundedCornerRadius(context)), float64(p.Y)+(float64(bounds.Dy())-float64(img.Bounds().Dy())*s)/2)
+			op.GeoM.Translate(float64(p.X-s), float64(p.Y+(bounds.Dy()-s)/2))
+			op.ColorScale.ScaleWithColor(draw.Color(context.ColorMode(), draw.ColorTypeBase, 0))
 			op.ColorScale.ScaleAlpha(0.5)
-			op.Filter = ebiten.FilterLinear
 			dst.DrawImage(img, op)
 		}
 	}
@@ -714,7 +734,7 @@ func (b *baseList[T]) Measure(context *guigui.Context, constraints guigui.Constr
 	for i := range b.visibleItems() {
 		item, _ := b.abstractList.ItemByIndex(i)
 		itemW := cw - 2*listItemPadding(context) - item.IndentLevel*listItemIndentSize(context)
-		s := item.Content.Measure(context, guigui.FixedWidthConstraints(itemW))
+		s := context.Measure(item.Content, guigui.FixedWidthConstraints(itemW))
 		size.X = max(size.X, s.X+item.IndentLevel*listItemIndentSize(context))
 		size.Y += s.Y
 	}
@@ -60,6 +60,16 @@ func (b *Button) SetIcon(icon *ebiten.Image) {
 	b.icon.SetImage(icon)
 }
 
+// SetVectorIcon sets a resolution-independent icon instead of an image icon.
+func (b *Button) SetVectorIcon(icon *VectorIcon) {
+	b.icon.SetVectorIcon(icon)
+}
+
+// SetIconName sets the icon registered by RegisterIcon or RegisterVectorIcon.
+func (b *Button) SetIconName(name string) {
+	b.icon.SetIconName(name)
+}
+
 func (b *Button) SetIconAlign(align IconAlign) {
 	if b.iconAlign == align {
 		return
@@ -129,7 +139,7 @@ func (b *Button) Layout(context *guigui.Context, widget guigui.Widget) image.Rec
 		textP := context.Bounds(b).Min
 		if b.icon.HasImage() {
 			textP.X += (s.X - ds.X) / 2
-			switch b.iconAlign {
+			switch b.physicalIconAlign(context) {
 			case IconAlignStart:
 				textP.X += buttonEdgeAndImagePadding(context)
 				textP.X += imgSize.X + buttonTextAndImagePadding(context)
@@ -152,7 +162,7 @@ func (b *Button) Layout(context *guigui.Context, widget guigui.Widget) image.Rec
 		imgP := context.Bounds(b).Min
 		if b.text.Value() != "" {
 			imgP.X += (s.X - ds.X) / 2
-			switch b.iconAlign {
+			switch b.physicalIconAlign(context) {
 			case IconAlignStart:
 				imgP.X += buttonEdgeAndImagePadding(context)
 			case IconAlignEnd:
@@ -228,6 +238,20 @@ func buttonEdgeAndImagePadding(context *guigui.Context) int {
 	return UnitSize(context) / 4
 }
 
+// physicalIconAlign returns the icon align from the left side.
+func (b *Button) physicalIconAlign(context *guigui.Context) IconAlign {
+	if !context.IsRightToLeft() {
+		return b.iconAlign
+	}
+	switch b.iconAlign {
+	case IconAlignStart:
+		return IconAlignEnd
+	case IconAlignEnd:
+		return IconAlignStart
+	}
+	return b.iconAlign
+}
+
 func (b *Button) iconSize(context *guigui.Context) image.Point {
 	s := context.Bounds(b).Size()
 	if b.text.Value() != "" {
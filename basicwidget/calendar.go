@@ -0,0 +1,605 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+const (
+	calendarEventDateSelected = "dateSelected"
+	calendarEventValueChanged = "valueChanged"
+)
+
+// calendarCellCount is the number of the day cells, which is enough for 6 weeks.
+const calendarCellCount = 7 * 6
+
+// Calendar is a month grid to select a date.
+//
+// The dates are in the location set by SetLocation, which is time.Local by default.
+// The month and weekday names and the first day of a week follow the app locale.
+// The names are available in English, German, Spanish, French, Italian, Japanese, Korean, Portuguese, Russian, and Chinese,
+// and the English names are used for the other languages.
+type Calendar struct {
+	guigui.DefaultWidget
+
+	prevButton   Button
+	nextButton   Button
+	title        Text
+	weekdayTexts [7]Text
+	dayTexts     [calendarCellCount]Text
+
+	value            time.Time
+	minDate          time.Time
+	maxDate          time.Time
+	disabledDateFunc func(date time.Time) bool
+	weekStart        time.Weekday
+	weekStartSet     bool
+	location         *time.Location
+
+	// cursor and month are civil dates. See civilDate.
+	cursor time.Time
+	month  time.Time
+
+	keepFocus bool
+
+	prevHoveredCellIndexPlus1 int
+}
+
+// SetOnDateSelected sets the function called when a date is selected by a user.
+// date is midnight of the selected date in the location of the calendar.
+func (c *Calendar) SetOnDateSelected(f func(date time.Time)) {
+	guigui.RegisterEventHandler(c, calendarEventDateSelected, f)
+}
+
+// setOnValueChanged sets the function called when the value is changed by a user.
+// Unlike SetOnDateSelected, f is not called when the same date is selected again.
+func (c *Calendar) setOnValueChanged(f func(value time.Time)) {
+	guigui.RegisterEventHandler(c, calendarEventValueChanged, f)
+}
+
+// Value returns midnight of the selected date in the location of the calendar.
+// Value returns the zero time if no date is selected.
+func (c *Calendar) Value() time.Time {
+	if c.value.IsZero() {
+		return time.Time{}
+	}
+	return c.fromCivilDate(c.selectedDate())
+}
+
+// SetValue selects the date of value in the location of the calendar.
+// The calendar shows the month of the date.
+// If value is the zero time, no date is selected.
+func (c *Calendar) SetValue(value time.Time) {
+	if c.value.Equal(value) {
+		return
+	}
+	c.value = value
+	if !value.IsZero() {
+		c.cursor = c.selectedDate()
+		c.month = firstDayOfMonth(c.cursor)
+	}
+	guigui.RequestRedraw(c)
+}
+
+// SetMinimumDate sets the earliest selectable date. The zero time means no minimum.
+func (c *Calendar) SetMinimumDate(date time.Time) {
+	if c.minDate.Equal(date) {
+		return
+	}
+	c.minDate = date
+	guigui.RequestRedraw(c)
+}
+
+// SetMaximumDate sets the latest selectable date. The zero time means no maximum.
+func (c *Calendar) SetMaximumDate(date time.Time) {
+	if c.maxDate.Equal(date) {
+		return
+	}
+	c.maxDate = date
+	guigui.RequestRedraw(c)
+}
+
+// SetDisabledDateFunc sets the function to report whether a date cannot be selected, e.g. a holiday.
+// f is called with midnight of a date in the location of the calendar.
+//
+// SetDisabledDateFunc doesn't redraw the calendar, so that it can be called at every Update.
+// If the dates f reports change, call guigui.RequestRedraw with the calendar.
+func (c *Calendar) SetDisabledDateFunc(f func(date time.Time) bool) {
+	c.disabledDateFunc = f
+}
+
+// SetWeekStart sets the first day of a week.
+// The default first day follows the region of the app locale.
+func (c *Calendar) SetWeekStart(weekday time.Weekday) {
+	if c.weekStartSet && c.weekStart == weekday {
+		return
+	}
+	c.weekStart = weekday
+	c.weekStartSet = true
+	guigui.RequestRedraw(c)
+}
+
+// SetLocation sets the location to interpret the dates. The default location is time.Local.
+func (c *Calendar) SetLocation(location *time.Location) {
+	if c.location == location {
+		return
+	}
+	c.location = location
+	guigui.RequestRedraw(c)
+}
+
+// ShowMonth shows the month of the year without changing the selected date.
+func (c *Calendar) ShowMonth(year int, month time.Month) {
+	m := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if c.month.Equal(m) {
+		return
+	}
+	c.month = m
+	guigui.RequestRedraw(c)
+}
+
+// resetCursor moves the cursor to the selected date, or today if no date is selected.
+func (c *Calendar) resetCursor() {
+	c.cursor = time.Time{}
+	c.month = time.Time{}
+	guigui.RequestRedraw(c)
+}
+
+// setKeepFocus makes the calendar not take the focus when clicked.
+// This is useful when the calendar is in a popup for a focused widget like a text input.
+func (c *Calendar) setKeepFocus(keepFocus bool) {
+	c.keepFocus = keepFocus
+}
+
+func (c *Calendar) actualLocation() *time.Location {
+	if c.location != nil {
+		return c.location
+	}
+	return time.Local
+}
+
+func (c *Calendar) toCivilDate(t time.Time) time.Time {
+	return civilDate(t.In(c.actualLocation()))
+}
+
+func (c *Calendar) fromCivilDate(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, c.actualLocation())
+}
+
+func (c *Calendar) selectedDate() time.Time {
+	return c.toCivilDate(c.value)
+}
+
+func (c *Calendar) today() time.Time {
+	return c.toCivilDate(time.Now())
+}
+
+func firstDayOfMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (c *Calendar) actualWeekStart(context *guigui.Context) time.Weekday {
+	if c.weekStartSet {
+		return c.weekStart
+	}
+	return firstWeekday(c.title.lang(context))
+}
+
+// cursorDate returns the date focused by the keyboard.
+func (c *Calendar) cursorDate() time.Time {
+	if !c.cursor.IsZero() {
+		return c.cursor
+	}
+	if !c.value.IsZero() {
+		return c.selectedDate()
+	}
+	return c.today()
+}
+
+func (c *Calendar) displayedMonth() time.Time {
+	if !c.month.IsZero() {
+		return c.month
+	}
+	return firstDayOfMonth(c.cursorDate())
+}
+
+func (c *Calendar) cellDate(context *guigui.Context, index int) time.Time {
+	m := c.displayedMonth()
+	return calendarGridStart(m.Year(), m.Month(), c.actualWeekStart(context)).AddDate(0, 0, index)
+}
+
+func (c *Calendar) isInRange(date time.Time) bool {
+	if !c.minDate.IsZero() && date.Before(c.toCivilDate(c.minDate)) {
+		return false
+	}
+	if !c.maxDate.IsZero() && date.After(c.toCivilDate(c.maxDate)) {
+		return false
+	}
+	return true
+}
+
+func (c *Calendar) isSelectable(date time.Time) bool {
+	if !c.isInRange(date) {
+		return false
+	}
+	if c.disabledDateFunc != nil && c.disabledDateFunc(c.fromCivilDate(date)) {
+		return false
+	}
+	return true
+}
+
+func (c *Calendar) clampDate(date time.Time) time.Time {
+	if !c.minDate.IsZero() {
+		if m := c.toCivilDate(c.minDate); date.Before(m) {
+			return m
+		}
+	}
+	if !c.maxDate.IsZero() {
+		if m := c.toCivilDate(c.maxDate); date.After(m) {
+			return m
+		}
+	}
+	return date
+}
+
+func (c *Calendar) moveCursor(date time.Time) {
+	date = c.clampDate(date)
+	c.cursor = date
+	c.month = firstDayOfMonth(date)
+	guigui.RequestRedraw(c)
+}
+
+func (c *Calendar) moveMonth(delta int) {
+	c.month = c.displayedMonth().AddDate(0, delta, 0)
+	guigui.RequestRedraw(c)
+}
+
+func (c *Calendar) canMoveMonth(delta int) bool {
+	m := c.displayedMonth()
+	if delta < 0 {
+		// The last day of the previous month.
+		return c.isInRange(m.AddDate(0, 0, -1))
+	}
+	return c.isInRange(m.AddDate(0, 1, 0))
+}
+
+func (c *Calendar) selectDate(date time.Time) {
+	if !c.isSelectable(date) {
+		return
+	}
+	c.cursor = date
+	c.month = firstDayOfMonth(date)
+	v := c.fromCivilDate(date)
+	changed := !c.value.Equal(v)
+	c.value = v
+	guigui.RequestRedraw(c)
+	guigui.DispatchEventHandler(c, calendarEventDateSelected, v)
+	if changed {
+		guigui.DispatchEventHandler(c, calendarEventValueChanged, v)
+	}
+}
+
+// handleKey moves the cursor or selects the date at the cursor by a key.
+// handleKey returns true if the key is handled.
+func (c *Calendar) handleKey(context *guigui.Context, key ebiten.Key) bool {
+	cursor := c.cursorDate()
+
+	// In a right-to-left calendar, the left arrow key moves to the next day.
+	nextKey, prevKey := ebiten.KeyArrowRight, ebiten.KeyArrowLeft
+	if context.IsRightToLeft() {
+		nextKey, prevKey = prevKey, nextKey
+	}
+
+	switch key {
+	case nextKey:
+		c.moveCursor(cursor.AddDate(0, 0, 1))
+	case prevKey:
+		c.moveCursor(cursor.AddDate(0, 0, -1))
+	case ebiten.KeyArrowDown:
+		c.moveCursor(cursor.AddDate(0, 0, 7))
+	case ebiten.KeyArrowUp:
+		c.moveCursor(cursor.AddDate(0, 0, -7))
+	case ebiten.KeyPageDown, ebiten.KeyPageUp:
+		dir := 1
+		if key == ebiten.KeyPageUp {
+			dir = -1
+		}
+		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+			c.moveCursor(addMonthsClamped(cursor, 12*dir))
+		} else {
+			c.moveCursor(addMonthsClamped(cursor, dir))
+		}
+	case ebiten.KeyHome:
+		offset := (int(cursor.Weekday()) - int(c.actualWeekStart(context)) + 7) % 7
+		c.moveCursor(cursor.AddDate(0, 0, -offset))
+	case ebiten.KeyEnd:
+		offset := (int(c.actualWeekStart(context)) + 6 - int(cursor.Weekday())) % 7
+		c.moveCursor(cursor.AddDate(0, 0, offset))
+	case ebiten.KeyEnter, ebiten.KeyNumpadEnter, ebiten.KeySpace:
+		c.selectDate(cursor)
+	default:
+		return false
+	}
+	return true
+}
+
+// addMonthsClamped adds months to date. The day is clamped to the last day of the month, e.g. Jan 31 + 1 month is Feb 28.
+func addMonthsClamped(date time.Time, months int) time.Time {
+	first := firstDayOfMonth(date).AddDate(0, months, 0)
+	last := first.AddDate(0, 1, -1)
+	return first.AddDate(0, 0, min(date.Day(), last.Day())-1)
+}
+
+func (c *Calendar) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&c.prevButton)
+	adder.AddChild(&c.title)
+	adder.AddChild(&c.nextButton)
+	for i := range c.weekdayTexts {
+		adder.AddChild(&c.weekdayTexts[i])
+	}
+	for i := range c.dayTexts {
+		adder.AddChild(&c.dayTexts[i])
+	}
+}
+
+func (c *Calendar) Update(context *guigui.Context) error {
+	if hovered := c.hoveredCellIndexToDraw(context); c.prevHoveredCellIndexPlus1-1 != hovered {
+		c.prevHoveredCellIndexPlus1 = hovered + 1
+		guigui.RequestRedraw(c)
+	}
+
+	locale := c.title.lang(context)
+	m := c.displayedMonth()
+
+	// The arrows are mirrored in right-to-left as the buttons are.
+	c.prevButton.SetVectorIcon(vectorIconKeyboardArrowLeft)
+	c.prevButton.SetOnDown(func() {
+		c.moveMonth(-1)
+	})
+	context.SetEnabled(&c.prevButton, c.canMoveMonth(-1))
+	c.nextButton.SetVectorIcon(vectorIconKeyboardArrowRight)
+	c.nextButton.SetOnDown(func() {
+		c.moveMonth(1)
+	})
+	context.SetEnabled(&c.nextButton, c.canMoveMonth(1))
+
+	c.title.SetValue(formatMonthYear(locale, m.Year(), m.Month()))
+	c.title.SetBold(true)
+	c.title.SetHorizontalAlign(HorizontalAlignCenter)
+	c.title.SetVerticalAlign(VerticalAlignMiddle)
+	c.title.SetColor(draw.TextColor(context.ColorMode(), context.IsEnabled(c)))
+
+	weekStart := c.actualWeekStart(context)
+	for i := range c.weekdayTexts {
+		t := &c.weekdayTexts[i]
+		t.SetValue(weekdayName(locale, (weekStart+time.Weekday(i))%7))
+		t.SetHorizontalAlign(HorizontalAlignCenter)
+		t.SetVerticalAlign(VerticalAlignMiddle)
+		t.SetColor(IconTintSecondary.color(context.ColorMode()))
+	}
+
+	today := c.today()
+	var selected time.Time
+	if !c.value.IsZero() {
+		selected = c.selectedDate()
+	}
+	for i := range c.dayTexts {
+		date := c.cellDate(context, i)
+		t := &c.dayTexts[i]
+		t.SetValue(strconv.Itoa(date.Day()))
+		t.SetTabular(true)
+		t.SetBold(date.Equal(today))
+		t.SetHorizontalAlign(HorizontalAlignCenter)
+		t.SetVerticalAlign(VerticalAlignMiddle)
+		switch {
+		case date.Equal(selected):
+			t.SetColor(draw.Color2(context.ColorMode(), draw.ColorTypeBase, 1, 1))
+		case !c.isSelectable(date) || !context.IsEnabled(c):
+			t.SetColor(draw.TextColor(context.ColorMode(), false))
+		case date.Month() != m.Month():
+			t.SetColor(IconTintSecondary.color(context.ColorMode()))
+		default:
+			t.SetColor(draw.TextColor(context.ColorMode(), true))
+		}
+	}
+	return nil
+}
+
+func calendarPadding(context *guigui.Context) int {
+	return UnitSize(context) / 4
+}
+
+func calendarCellSize(context *guigui.Context) int {
+	return UnitSize(context) * 5 / 4
+}
+
+func (c *Calendar) contentBounds(context *guigui.Context) image.Rectangle {
+	return context.Bounds(c).Inset(calendarPadding(context))
+}
+
+func (c *Calendar) headerHeight(context *guigui.Context) int {
+	return UnitSize(context)
+}
+
+func (c *Calendar) weekdayRowHeight(context *guigui.Context) int {
+	return UnitSize(context) * 3 / 4
+}
+
+// cellRectangle returns the bounds of the cell at the column and the row in the grid.
+// The row -1 is for the weekday names.
+func (c *Calendar) cellRectangle(context *guigui.Context, col, row int) image.Rectangle {
+	b := c.contentBounds(context)
+	top := b.Min.Y + c.headerHeight(context)
+	w := b.Dx() / 7
+	h := (b.Max.Y - top - c.weekdayRowHeight(context)) / 6
+	x := b.Min.X + (b.Dx()-7*w)/2 + col*w
+	var r image.Rectangle
+	if row < 0 {
+		r = image.Rect(x, top, x+w, top+c.weekdayRowHeight(context))
+	} else {
+		y := top + c.weekdayRowHeight(context) + row*h
+		r = image.Rect(x, y, x+w, y+h)
+	}
+	if context.IsRightToLeft() {
+		r = guigui.MirrorRectangle(r, b)
+	}
+	return r
+}
+
+func (c *Calendar) cellBounds(context *guigui.Context, index int) image.Rectangle {
+	return c.cellRectangle(context, index%7, index/7)
+}
+
+func (c *Calendar) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	b := c.contentBounds(context)
+	u := UnitSize(context)
+	header := b
+	header.Max.Y = header.Min.Y + c.headerHeight(context)
+	switch widget {
+	case &c.prevButton:
+		r := header
+		r.Max.X = r.Min.X + u
+		if context.IsRightToLeft() {
+			r = guigui.MirrorRectangle(r, b)
+		}
+		return r
+	case &c.nextButton:
+		r := header
+		r.Min.X = r.Max.X - u
+		if context.IsRightToLeft() {
+			r = guigui.MirrorRectangle(r, b)
+		}
+		return r
+	case &c.title:
+		r := header
+		r.Min.X += u
+		r.Max.X -= u
+		return r
+	}
+	for i := range c.weekdayTexts {
+		if widget == &c.weekdayTexts[i] {
+			return c.cellRectangle(context, i, -1)
+		}
+	}
+	for i := range c.dayTexts {
+		if widget == &c.dayTexts[i] {
+			return c.cellBounds(context, i)
+		}
+	}
+	return image.Rectangle{}
+}
+
+func (c *Calendar) hoveredCellIndex(context *guigui.Context) int {
+	if !context.IsWidgetHitAtCursor(c) {
+		return -1
+	}
+	pt := context.PointerPosition()
+	for i := range calendarCellCount {
+		if pt.In(c.cellBounds(context, i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hoveredCellIndexToDraw returns the index of the cell drawn as hovered, or -1.
+func (c *Calendar) hoveredCellIndexToDraw(context *guigui.Context) int {
+	if !context.IsEnabled(c) {
+		return -1
+	}
+	index := c.hoveredCellIndex(context)
+	if index < 0 || !c.isSelectable(c.cellDate(context, index)) {
+		return -1
+	}
+	return index
+}
+
+func (c *Calendar) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsPointerJustPressed() {
+		return guigui.HandleInputResult{}
+	}
+	index := c.hoveredCellIndex(context)
+	if index < 0 {
+		return guigui.HandleInputResult{}
+	}
+	if !c.keepFocus {
+		context.SetFocused(c, true)
+	}
+	c.selectDate(c.cellDate(context, index))
+	return guigui.HandleInputByWidget(c)
+}
+
+var calendarNavigationKeys = []ebiten.Key{
+	ebiten.KeyArrowLeft,
+	ebiten.KeyArrowRight,
+	ebiten.KeyArrowUp,
+	ebiten.KeyArrowDown,
+	ebiten.KeyPageUp,
+	ebiten.KeyPageDown,
+	ebiten.KeyHome,
+	ebiten.KeyEnd,
+}
+
+func (c *Calendar) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsFocused(c) {
+		return guigui.HandleInputResult{}
+	}
+	for _, key := range calendarNavigationKeys {
+		if isKeyRepeating(key) && c.handleKey(context, key) {
+			return guigui.HandleInputByWidget(c)
+		}
+	}
+	for _, key := range []ebiten.Key{ebiten.KeyEnter, ebiten.KeyNumpadEnter, ebiten.KeySpace} {
+		if inpututil.IsKeyJustPressed(key) && c.handleKey(context, key) {
+			return guigui.HandleInputByWidget(c)
+		}
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (c *Calendar) Draw(context *guigui.Context, dst *ebiten.Image) {
+	cm := context.ColorMode()
+	radius := RoundedCornerRadius(context)
+	hovered := c.hoveredCellIndexToDraw(context)
+	var selected time.Time
+	if !c.value.IsZero() {
+		selected = c.selectedDate()
+	}
+	cursor := c.cursorDate()
+	showCursor := c.keepFocus || context.IsFocused(c)
+
+	for i := range calendarCellCount {
+		date := c.cellDate(context, i)
+		r := c.cellBounds(context, i).Inset(int(context.Scale()))
+		switch {
+		case date.Equal(selected):
+			draw.DrawRoundedRect(context, dst, r, draw.Color(cm, draw.ColorTypeAccent, 0.5), radius)
+		case i == hovered:
+			draw.DrawRoundedRect(context, dst, r, draw.Color2(cm, draw.ColorTypeBase, 0.9, 0.4), radius)
+		}
+		if showCursor && date.Equal(cursor) {
+			clr := draw.Color(cm, draw.ColorTypeAccent, 0.5)
+			if date.Equal(selected) {
+				clr = draw.Color2(cm, draw.ColorTypeBase, 1, 1)
+			}
+			draw.DrawRoundedRectBorder(context, dst, r, clr, clr, radius, float32(2*context.Scale()), draw.RoundedRectBorderTypeRegular)
+		}
+	}
+}
+
+func (c *Calendar) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	p := calendarPadding(context)
+	s := calendarCellSize(context)
+	return image.Pt(7*s+2*p, c.headerHeight(context)+c.weekdayRowHeight(context)+6*s+2*p)
+}
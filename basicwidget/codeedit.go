@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+)
+
+// lineStart returns the start position of the line including idx.
+func lineStart(text string, idx int) int {
+	return strings.LastIndex(text[:idx], "\n") + 1
+}
+
+// lineEnd returns the end position of the line including idx, excluding the line break.
+func lineEnd(text string, idx int) int {
+	if i := strings.Index(text[idx:], "\n"); i >= 0 {
+		return idx + i
+	}
+	return len(text)
+}
+
+// autoIndent returns the indentation for a new line inserted at idx.
+// The indentation of the current line is kept, and one more level is added after an opening bracket.
+func autoIndent(text string, idx int) string {
+	start := lineStart(text, idx)
+	line := text[start:idx]
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	if trimmed := strings.TrimRight(line, " \t"); trimmed != "" {
+		switch trimmed[len(trimmed)-1] {
+		case '(', '[', '{':
+			indent += "\t"
+		}
+	}
+	return indent
+}
+
+// selectedLinesRange returns the range of the lines touched by the selection [start, end).
+// A line whose head is the end of a non-empty selection is not included.
+func selectedLinesRange(text string, start, end int) (int, int) {
+	if end > start && end > 0 && text[end-1] == '\n' {
+		end--
+	}
+	return lineStart(text, start), lineEnd(text, end)
+}
+
+// indentLines adds indent to the head of each line touched by the selection [start, end).
+// indentLines returns the new text and the new selection.
+func indentLines(text string, start, end int, indent string) (string, int, int) {
+	ls, le := selectedLinesRange(text, start, end)
+	var b strings.Builder
+	b.WriteString(text[:ls])
+	newStart, newEnd := start, end
+	pos := ls
+	for {
+		b.WriteString(indent)
+		if pos < start {
+			newStart += len(indent)
+		}
+		if pos < end {
+			newEnd += len(indent)
+		}
+		i := strings.Index(text[pos:le], "\n")
+		if i < 0 {
+			break
+		}
+		b.WriteString(text[pos : pos+i+1])
+		pos += i + 1
+	}
+	b.WriteString(text[pos:])
+	return b.String(), newStart, newEnd
+}
+
+// unindentLines removes one level of indentation from the head of each line touched by the selection [start, end).
+// One level is a tab or at most maxSpaces spaces.
+// unindentLines returns the new text and the new selection.
+func unindentLines(text string, start, end int, maxSpaces int) (string, int, int) {
+	ls, le := selectedLinesRange(text, start, end)
+	var b strings.Builder
+	b.WriteString(text[:ls])
+	newStart, newEnd := start, end
+	pos := ls
+	for {
+		var n int
+		if strings.HasPrefix(text[pos:], "\t") {
+			n = 1
+		} else {
+			for n < maxSpaces && pos+n < len(text) && text[pos+n] == ' ' {
+				n++
+			}
+		}
+		newStart -= max(0, min(start, pos+n)-pos)
+		newEnd -= max(0, min(end, pos+n)-pos)
+
+		i := strings.Index(text[pos:le], "\n")
+		if i < 0 {
+			b.WriteString(text[pos+n:])
+			break
+		}
+		b.WriteString(text[pos+n : pos+i+1])
+		pos += i + 1
+	}
+	return b.String(), newStart, newEnd
+}
+
+// matchingBracket returns the position of the bracket matching the bracket at idx.
+// Brackets in strings or comments are not distinguished.
+func matchingBracket(text string, idx int) (int, bool) {
+	if idx < 0 || idx >= len(text) {
+		return 0, false
+	}
+	const openings = "([{"
+	const closings = ")]}"
+	c := text[idx]
+	if i := strings.IndexByte(openings, c); i >= 0 {
+		o, cl := openings[i], closings[i]
+		var depth int
+		for j := idx + 1; j < len(text); j++ {
+			switch text[j] {
+			case o:
+				depth++
+			case cl:
+				if depth == 0 {
+					return j, true
+				}
+				depth--
+			}
+		}
+		return 0, false
+	}
+	if i := strings.IndexByte(closings, c); i >= 0 {
+		o, cl := openings[i], closings[i]
+		var depth int
+		for j := idx - 1; j >= 0; j-- {
+			switch text[j] {
+			case cl:
+				depth++
+			case o:
+				if depth == 0 {
+					return j, true
+				}
+				depth--
+			}
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+// bracketPairAtCursor returns the positions of the brackets next to the cursor and its pair.
+// The bracket before the cursor is prior to the one after the cursor.
+func bracketPairAtCursor(text string, cursor int) (int, int, bool) {
+	if cursor > 0 {
+		if m, ok := matchingBracket(text, cursor-1); ok {
+			return cursor - 1, m, true
+		}
+	}
+	if m, ok := matchingBracket(text, cursor); ok {
+		return cursor, m, true
+	}
+	return 0, 0, false
+}
+
+func (t *Text) setCodeEditing(codeEditing bool) {
+	if t.codeEditing == codeEditing {
+		return
+	}
+	t.codeEditing = codeEditing
+	guigui.RequestRedraw(t)
+}
+
+// handleCodeEditingKeys handles the keys for code editing: auto-indentation and indentation of lines.
+func (t *Text) handleCodeEditingKeys(context *guigui.Context) bool {
+	start, end := t.field.Selection()
+	str := t.field.Text()
+	switch {
+	case t.multiline && inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		ins := "\n" + autoIndent(str, start)
+		t.setTextAndSelection(str[:start]+ins+str[end:], start+len(ins), start+len(ins), -1)
+		return true
+	case isKeyRepeating(ebiten.KeyTab) && ebiten.IsKeyPressed(ebiten.KeyShift):
+		// Count the spaces of a tab width as one level.
+		spaces := max(1, int(t.actualTabWidth(context)/text.Advance(" ", t.face(context, false))))
+		newText, s, e := unindentLines(str, start, end, spaces)
+		t.setTextAndSelection(newText, s, e, -1)
+		return true
+	case isKeyRepeating(ebiten.KeyTab):
+		if start == end || !strings.Contains(str[start:end], "\n") {
+			t.setTextAndSelection(str[:start]+"\t"+str[end:], start+1, start+1, -1)
+			return true
+		}
+		newText, s, e := indentLines(str, start, end, "\t")
+		t.setTextAndSelection(newText, s, e, -1)
+		return true
+	}
+	return false
+}
+
+func (t *Text) appendBracketHighlights(context *guigui.Context, highlights []textutil.Highlight) []textutil.Highlight {
+	start, end := t.field.Selection()
+	if start != end {
+		return highlights
+	}
+	b0, b1, ok := bracketPairAtCursor(t.field.Text(), start)
+	if !ok {
+		return highlights
+	}
+	clr := draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.85, 0.35)
+	highlights = append(highlights,
+		textutil.Highlight{Start: b0, End: b0 + 1, Color: clr},
+		textutil.Highlight{Start: b1, End: b1 + 1, Color: clr})
+	slices.SortStableFunc(highlights, func(a, b textutil.Highlight) int {
+		return a.Start - b.Start
+	})
+	return highlights
+}
+
+// logicalLineTopsCacheKey is the key of the cached tops of the logical lines.
+type logicalLineTopsCacheKey struct {
+	text       string
+	size       image.Point
+	face       text.Face
+	lineHeight float64
+	rtl        bool
+}
+
+// logicalLineTops returns the tops of the logical lines relative to the text bounds.
+//
+// The tops are cached until the text or the layout changes,
+// as computing them requires wrapping and measuring all the lines.
+// The returned slice must not be modified.
+func (t *Text) logicalLineTops(context *guigui.Context) []float64 {
+	key := logicalLineTopsCacheKey{
+		text:       t.textToDraw(context, false),
+		size:       t.actualTextBounds(context).Size(),
+		face:       t.face(context, false),
+		lineHeight: t.lineHeight(context),
+		rtl:        context.IsRightToLeft(),
+	}
+	if t.cachedLogicalLineTopsValid && t.cachedLogicalLineTopsKey == key {
+		return t.cachedLogicalLineTops
+	}
+
+	op := &textutil.Options{
+		AutoWrap:         t.autoWrap,
+		Face:             key.face,
+		LineHeight:       key.lineHeight,
+		HorizontalAlign:  textutil.HorizontalAlign(t.hAlign),
+		RightToLeft:      key.rtl,
+		VerticalAlign:    textutil.VerticalAlign(t.vAlign),
+		TabWidth:         t.actualTabWidth(context),
+		KeepTailingSpace: t.keepTailingSpace,
+		Spans:            t.textutilSpans(context, false),
+	}
+	t.cachedLogicalLineTops = t.cachedLogicalLineTops[:0]
+	for _, y := range textutil.LogicalLineTops(key.size, key.text, op) {
+		t.cachedLogicalLineTops = append(t.cachedLogicalLineTops, y)
+	}
+	t.cachedLogicalLineTopsKey = key
+	t.cachedLogicalLineTopsValid = true
+	return t.cachedLogicalLineTops
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestAutoIndent(t *testing.T) {
+	testCases := []struct {
+		text string
+		idx  int
+		want string
+	}{
+		{text: "foo", idx: 3, want: ""},
+		{text: "\tfoo", idx: 4, want: "\t"},
+		{text: "\tfoo {", idx: 6, want: "\t\t"},
+		{text: "  x\n\t\tbar", idx: 9, want: "\t\t"},
+		{text: "  foo(", idx: 6, want: "  \t"},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.AutoIndent(tc.text, tc.idx); got != tc.want {
+			t.Errorf("AutoIndent(%q, %d): got: %q, want: %q", tc.text, tc.idx, got, tc.want)
+		}
+	}
+}
+
+func TestIndentLines(t *testing.T) {
+	testCases := []struct {
+		text      string
+		start     int
+		end       int
+		wantText  string
+		wantStart int
+		wantEnd   int
+	}{
+		{text: "a\nb\nc", start: 0, end: 3, wantText: "\ta\n\tb\nc", wantStart: 0, wantEnd: 5},
+		{text: "a\nb\nc", start: 0, end: 4, wantText: "\ta\n\tb\nc", wantStart: 0, wantEnd: 6},
+		{text: "a\nb\nc", start: 1, end: 5, wantText: "\ta\n\tb\n\tc", wantStart: 2, wantEnd: 8},
+	}
+	for _, tc := range testCases {
+		got, start, end := basicwidget.IndentLines(tc.text, tc.start, tc.end, "\t")
+		if got != tc.wantText || start != tc.wantStart || end != tc.wantEnd {
+			t.Errorf("IndentLines(%q, %d, %d): got: %q, %d, %d, want: %q, %d, %d", tc.text, tc.start, tc.end, got, start, end, tc.wantText, tc.wantStart, tc.wantEnd)
+		}
+	}
+}
+
+func TestUnindentLines(t *testing.T) {
+	testCases := []struct {
+		text      string
+		start     int
+		end       int
+		wantText  string
+		wantStart int
+		wantEnd   int
+	}{
+		{text: "\ta\n    b\n  c", start: 0, end: 11, wantText: "a\nb\nc", wantStart: 0, wantEnd: 4},
+		{text: "\ta\n    b", start: 6, end: 6, wantText: "\ta\nb", wantStart: 3, wantEnd: 3},
+		{text: "a\n\tb", start: 0, end: 2, wantText: "a\n\tb", wantStart: 0, wantEnd: 2},
+	}
+	for _, tc := range testCases {
+		got, start, end := basicwidget.UnindentLines(tc.text, tc.start, tc.end, 4)
+		if got != tc.wantText || start != tc.wantStart || end != tc.wantEnd {
+			t.Errorf("UnindentLines(%q, %d, %d): got: %q, %d, %d, want: %q, %d, %d", tc.text, tc.start, tc.end, got, start, end, tc.wantText, tc.wantStart, tc.wantEnd)
+		}
+	}
+}
+
+func TestMatchingBracket(t *testing.T) {
+	testCases := []struct {
+		text string
+		idx  int
+		want int
+		ok   bool
+	}{
+		{text: "f(a[1], {b})", idx: 1, want: 11, ok: true},
+		{text: "f(a[1], {b})", idx: 11, want: 1, ok: true},
+		{text: "f(a[1], {b})", idx: 3, want: 5, ok: true},
+		{text: "f(a[1], {b})", idx: 0, ok: false},
+		{text: "(()", idx: 0, ok: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.MatchingBracket(tc.text, tc.idx)
+		if ok != tc.ok || (ok && got != tc.want) {
+			t.Errorf("MatchingBracket(%q, %d): got: %d, %t, want: %d, %t", tc.text, tc.idx, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/oklab"
+)
+
+// formatHexColor formats clr like "#RRGGBB", or "#RRGGBBAA" if clr is not opaque.
+func formatHexColor(clr color.NRGBA) string {
+	if clr.A == 0xff {
+		return fmt.Sprintf("#%02X%02X%02X", clr.R, clr.G, clr.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", clr.R, clr.G, clr.B, clr.A)
+}
+
+// parseHexColor parses a color like "#RGB", "#RGBA", "#RRGGBB" or "#RRGGBBAA". The leading '#' is optional.
+func parseHexColor(str string) (color.NRGBA, bool) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "#")
+	switch len(str) {
+	case 3, 4:
+		// Expand each digit like "F" to "FF".
+		var b strings.Builder
+		for _, r := range str {
+			b.WriteRune(r)
+			b.WriteRune(r)
+		}
+		str = b.String()
+	case 6, 8:
+	default:
+		return color.NRGBA{}, false
+	}
+	if len(str) == 6 {
+		str += "ff"
+	}
+	v, err := strconv.ParseUint(str, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	return color.NRGBA{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}, true
+}
+
+// isOklabInSRGBGamut reports whether the Oklab color is in the sRGB gamut.
+func isOklabInSRGBGamut(l, a, b float64) bool {
+	// https://bottosson.github.io/posts/oklab/#converting-from-linear-srgb-to-oklab
+	l_ := l + 0.3963377774*a + 0.2158037573*b
+	m_ := l - 0.1055613458*a - 0.0638541728*b
+	s_ := l - 0.0894841775*a - 1.2914855480*b
+
+	ll := l_ * l_ * l_
+	m := m_ * m_ * m_
+	s := s_ * s_ * s_
+
+	const eps = 1e-4
+	for _, v := range [...]float64{
+		+4.0767416621*ll - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*ll + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*ll - 0.7034186147*m + 1.7076147010*s,
+	} {
+		if v < -eps || v > 1+eps {
+			return false
+		}
+	}
+	return true
+}
+
+// maxOklchChroma returns the maximum chroma in the sRGB gamut for the lightness and the hue in radians.
+func maxOklchChroma(lightness, hue float64) float64 {
+	if lightness <= 0 || lightness >= 1 {
+		return 0
+	}
+	sin, cos := math.Sincos(hue)
+	// The chroma of any sRGB color is less than 0.4.
+	lo, hi := 0.0, 0.4
+	for range 20 {
+		mid := (lo + hi) / 2
+		if isOklabInSRGBGamut(lightness, mid*cos, mid*sin) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// colorPickerValue is a color in the OKLCh color space, with the chroma relative to the sRGB gamut.
+type colorPickerValue struct {
+	// hue is in degrees in [0, 360).
+	hue float64
+
+	// saturation is the rate of the chroma to the maximum chroma in the sRGB gamut, usually in [0, 1].
+	saturation float64
+
+	lightness float64
+	alpha     float64
+}
+
+func (v colorPickerValue) oklch() oklab.Oklch {
+	h := v.hue * math.Pi / 180
+	return oklab.Oklch{
+		L:     v.lightness,
+		C:     v.saturation * maxOklchChroma(v.lightness, h),
+		H:     h,
+		Alpha: v.alpha,
+	}
+}
+
+func (v colorPickerValue) nrgba() color.NRGBA {
+	return color.NRGBAModel.Convert(v.oklch()).(color.NRGBA)
+}
+
+// newColorPickerValue converts clr. The hue and the saturation of the previous value are kept where they are undefined, e.g. for gray.
+func newColorPickerValue(clr color.Color, prev colorPickerValue) colorPickerValue {
+	v := prev
+	_, _, _, a := clr.RGBA()
+	if a == 0 {
+		v.alpha = 0
+		return v
+	}
+	c := oklab.OklchModel.Convert(clr).(oklab.Oklch)
+	v.lightness = min(max(c.L, 0), 1)
+	v.alpha = c.Alpha
+	if math.IsNaN(c.H) || c.C < 1e-4 {
+		v.saturation = 0
+		return v
+	}
+	v.hue = math.Mod(c.H*180/math.Pi+360, 360)
+	// The saturation can be slightly more than 1 around the cusp of the gamut, e.g. for pure blue,
+	// as the gamut is not convex in OKLCh.
+	if m := maxOklchChroma(v.lightness, c.H); m > 0 {
+		v.saturation = c.C / m
+	} else {
+		v.saturation = 0
+	}
+	return v
+}
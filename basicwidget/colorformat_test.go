@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestFormatHexColor(t *testing.T) {
+	testCases := []struct {
+		clr  color.NRGBA
+		want string
+	}{
+		{clr: color.NRGBA{A: 0xff}, want: "#000000"},
+		{clr: color.NRGBA{R: 0x12, G: 0xab, B: 0xef, A: 0xff}, want: "#12ABEF"},
+		{clr: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0x80}, want: "#FF800080"},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.FormatHexColor(tc.clr); got != tc.want {
+			t.Errorf("FormatHexColor(%v): got: %q, want: %q", tc.clr, got, tc.want)
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	testCases := []struct {
+		str  string
+		want color.NRGBA
+		ok   bool
+	}{
+		{str: "#12ABEF", want: color.NRGBA{R: 0x12, G: 0xab, B: 0xef, A: 0xff}, ok: true},
+		{str: "12abef", want: color.NRGBA{R: 0x12, G: 0xab, B: 0xef, A: 0xff}, ok: true},
+		{str: "#FF800080", want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0x80}, ok: true},
+		{str: "#f80", want: color.NRGBA{R: 0xff, G: 0x88, B: 0x00, A: 0xff}, ok: true},
+		{str: " #f808 ", want: color.NRGBA{R: 0xff, G: 0x88, B: 0x00, A: 0x88}, ok: true},
+		{str: "", ok: false},
+		{str: "#12345", ok: false},
+		{str: "#GGGGGG", ok: false},
+		{str: "+1234567", ok: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.ParseHexColor(tc.str)
+		if ok != tc.ok || (ok && got != tc.want) {
+			t.Errorf("ParseHexColor(%q): got: %v, %t, want: %v, %t", tc.str, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestMaxOklchChroma(t *testing.T) {
+	// The chroma of sRGB red in OKLCh is about 0.2577 at the lightness 0.6280 and the hue 29.23 degrees.
+	got := basicwidget.MaxOklchChroma(0.6280, 29.23*math.Pi/180)
+	if math.Abs(got-0.2577) > 0.002 {
+		t.Errorf("MaxOklchChroma for red: got: %f, want: about 0.2577", got)
+	}
+	for _, l := range []float64{0, 1} {
+		if got := basicwidget.MaxOklchChroma(l, 0); got != 0 {
+			t.Errorf("MaxOklchChroma(%f, 0): got: %f, want: 0", l, got)
+		}
+	}
+}
+
+func TestColorPickerValueRoundTrip(t *testing.T) {
+	for _, clr := range []color.NRGBA{
+		{A: 0xff},
+		{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+		{R: 0xff, A: 0xff},
+		{G: 0xff, A: 0xff},
+		{B: 0xff, A: 0xff},
+		{R: 0x12, G: 0xab, B: 0xef, A: 0x80},
+		{R: 0xff, G: 0xcc, B: 0x00, A: 0xff},
+	} {
+		got := basicwidget.RoundTripColorPickerValue(clr)
+		for i, pair := range [][2]uint8{{got.R, clr.R}, {got.G, clr.G}, {got.B, clr.B}, {got.A, clr.A}} {
+			if d := int(pair[0]) - int(pair[1]); d < -1 || d > 1 {
+				t.Errorf("round trip of %v: got: %v (channel %d)", clr, got, i)
+			}
+		}
+	}
+}
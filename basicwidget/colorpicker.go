@@ -0,0 +1,811 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/hajimehoshi/oklab"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+const (
+	colorPickerEventValueChanged = "valueChanged"
+)
+
+type colorChannel int
+
+const (
+	colorChannelRed colorChannel = iota
+	colorChannelGreen
+	colorChannelBlue
+	colorChannelAlpha
+	colorChannelLightness
+	colorChannelChroma
+	colorChannelHue
+
+	colorChannelCount
+)
+
+func (c colorChannel) label() string {
+	return [...]string{"R", "G", "B", "A", "L", "C", "H"}[c]
+}
+
+// maxRecentColors is the maximum number of the recent colors shared by color pickers.
+const maxRecentColors = 10
+
+// theRecentColors are the colors committed recently in any color picker, from the newest.
+var theRecentColors []color.NRGBA
+
+func addRecentColor(clr color.NRGBA) {
+	theRecentColors = slices.DeleteFunc(theRecentColors, func(c color.NRGBA) bool {
+		return c == clr
+	})
+	theRecentColors = slices.Insert(theRecentColors, 0, clr)
+	if len(theRecentColors) > maxRecentColors {
+		theRecentColors = theRecentColors[:maxRecentColors]
+	}
+}
+
+var theDefaultSwatchColors []color.NRGBA
+
+func defaultSwatchColors() []color.NRGBA {
+	if theDefaultSwatchColors == nil {
+		theDefaultSwatchColors = []color.NRGBA{
+			{A: 0xff},
+			newColorPickerValue(oklab.Oklab{L: 0.6, Alpha: 1}, colorPickerValue{}).nrgba(),
+			{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		}
+		// Red, orange, yellow, green, cyan, blue and purple.
+		for _, hue := range []float64{29, 55, 100, 145, 195, 264, 310} {
+			v := colorPickerValue{
+				hue:        hue,
+				saturation: 1,
+				lightness:  0.7,
+				alpha:      1,
+			}
+			theDefaultSwatchColors = append(theDefaultSwatchColors, v.nrgba())
+		}
+	}
+	return theDefaultSwatchColors
+}
+
+// ColorPicker is a widget to pick a color.
+//
+// The color is edited in the OKLCh color space, where the lightness and the hue are perceptually uniform.
+// The saturation area shows the chroma relative to the maximum chroma in the sRGB gamut.
+type ColorPicker struct {
+	guigui.DefaultWidget
+
+	area             colorPickerArea
+	hueStrip         colorPickerStrip
+	alphaStrip       colorPickerStrip
+	preview          colorPickerPreview
+	eyedropperButton Button
+	hexInput         TextInput
+	channelTexts     [colorChannelCount]Text
+	channelInputs    [colorChannelCount]NumberInput
+	recentSwatches   colorPickerSwatches
+	presetSwatches   colorPickerSwatches
+	eyedropper       colorPickerEyedropper
+
+	value        colorPickerValue
+	valueSet     bool
+	swatchColors []color.NRGBA
+	picking      bool
+
+	channelItems [2][]guigui.LinearLayoutItem
+	rowItems     []guigui.LinearLayoutItem
+}
+
+// SetOnValueChanged sets the function called when the color is changed by a user.
+// committed is false while a user is dragging or typing.
+func (c *ColorPicker) SetOnValueChanged(f func(clr color.Color, committed bool)) {
+	guigui.RegisterEventHandler(c, colorPickerEventValueChanged, f)
+}
+
+func (c *ColorPicker) actualValue() colorPickerValue {
+	if !c.valueSet {
+		return colorPickerValue{
+			alpha: 1,
+		}
+	}
+	return c.value
+}
+
+// Value returns the color. The default color is opaque black.
+func (c *ColorPicker) Value() color.NRGBA {
+	return c.actualValue().nrgba()
+}
+
+func (c *ColorPicker) SetValue(clr color.Color) {
+	if c.valueSet && color.NRGBAModel.Convert(clr) == c.Value() {
+		return
+	}
+	c.value = newColorPickerValue(clr, c.actualValue())
+	c.valueSet = true
+	guigui.RequestRedraw(c)
+}
+
+// SetSwatchColors sets the preset colors. The default colors are black, gray, white and some vivid colors.
+func (c *ColorPicker) SetSwatchColors(colors []color.Color) {
+	if slices.EqualFunc(c.swatchColors, colors, func(c0 color.NRGBA, c1 color.Color) bool {
+		return c0 == color.NRGBAModel.Convert(c1).(color.NRGBA)
+	}) {
+		return
+	}
+	c.swatchColors = adjustSliceSize(c.swatchColors, len(colors))
+	for i, clr := range colors {
+		c.swatchColors[i] = color.NRGBAModel.Convert(clr).(color.NRGBA)
+	}
+	guigui.RequestRedraw(c)
+}
+
+func (c *ColorPicker) actualSwatchColors() []color.NRGBA {
+	if c.swatchColors != nil {
+		return c.swatchColors
+	}
+	return defaultSwatchColors()
+}
+
+// setValueByUser sets the value and dispatches the event.
+// A committed color is added to the recent colors.
+func (c *ColorPicker) setValueByUser(value colorPickerValue, committed bool) {
+	if c.valueSet && c.value == value && !committed {
+		return
+	}
+	c.value = value
+	c.valueSet = true
+	guigui.RequestRedraw(c)
+	clr := value.nrgba()
+	if committed {
+		addRecentColor(clr)
+	}
+	guigui.DispatchEventHandler(c, colorPickerEventValueChanged, color.Color(clr), committed)
+}
+
+func (c *ColorPicker) channelValue(channel colorChannel) float64 {
+	v := c.actualValue()
+	clr := v.nrgba()
+	switch channel {
+	case colorChannelRed:
+		return float64(clr.R)
+	case colorChannelGreen:
+		return float64(clr.G)
+	case colorChannelBlue:
+		return float64(clr.B)
+	case colorChannelAlpha:
+		return math.Round(v.alpha * 100)
+	case colorChannelLightness:
+		return v.lightness * 100
+	case colorChannelChroma:
+		return v.oklch().C
+	case colorChannelHue:
+		return math.Round(v.hue)
+	}
+	return 0
+}
+
+func (c *ColorPicker) setChannelValue(channel colorChannel, value float64, committed bool) {
+	v := c.actualValue()
+	switch channel {
+	case colorChannelRed, colorChannelGreen, colorChannelBlue:
+		clr := v.nrgba()
+		x := uint8(min(max(math.Round(value), 0), 255))
+		switch channel {
+		case colorChannelRed:
+			clr.R = x
+		case colorChannelGreen:
+			clr.G = x
+		case colorChannelBlue:
+			clr.B = x
+		}
+		// Keep the exact alpha as the 8-bit alpha might be rounded.
+		alpha := v.alpha
+		clr.A = 0xff
+		v = newColorPickerValue(clr, v)
+		v.alpha = alpha
+	case colorChannelAlpha:
+		v.alpha = min(max(value/100, 0), 1)
+	case colorChannelLightness:
+		v.lightness = min(max(value/100, 0), 1)
+		v.saturation = min(v.saturation, 1)
+	case colorChannelChroma:
+		if m := maxOklchChroma(v.lightness, v.hue*math.Pi/180); m > 0 {
+			v.saturation = min(max(value/m, 0), 1)
+		}
+	case colorChannelHue:
+		v.hue = math.Mod(math.Mod(value, 360)+360, 360)
+		v.saturation = min(v.saturation, 1)
+	}
+	c.setValueByUser(v, committed)
+}
+
+func (c *ColorPicker) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&c.area)
+	adder.AddChild(&c.hueStrip)
+	adder.AddChild(&c.alphaStrip)
+	adder.AddChild(&c.preview)
+	adder.AddChild(&c.eyedropperButton)
+	adder.AddChild(&c.hexInput)
+	for i := range colorChannelCount {
+		adder.AddChild(&c.channelTexts[i])
+		adder.AddChild(&c.channelInputs[i])
+	}
+	if len(theRecentColors) > 0 {
+		adder.AddChild(&c.recentSwatches)
+	}
+	adder.AddChild(&c.presetSwatches)
+	if c.picking {
+		adder.AddChild(&c.eyedropper)
+	}
+}
+
+func (c *ColorPicker) Update(context *guigui.Context) error {
+	c.area.picker = c
+	c.hueStrip.picker = c
+	c.hueStrip.channel = colorChannelHue
+	c.alphaStrip.picker = c
+	c.alphaStrip.channel = colorChannelAlpha
+	c.preview.picker = c
+	c.eyedropper.picker = c
+
+	c.eyedropperButton.SetVectorIcon(vectorIconColorize)
+	c.eyedropperButton.SetOnDown(func() {
+		c.picking = true
+		guigui.RequestRedraw(c)
+	})
+
+	clr := c.Value()
+	c.hexInput.SetValue(formatHexColor(clr))
+	c.hexInput.SetTabular(true)
+	c.hexInput.SetOnValueChanged(func(text string, committed bool) {
+		if !committed {
+			return
+		}
+		if clr, ok := parseHexColor(text); ok {
+			c.setValueByUser(newColorPickerValue(clr, c.actualValue()), true)
+		}
+		c.hexInput.ForceSetValue(formatHexColor(c.Value()))
+	})
+
+	for i := range colorChannelCount {
+		c.channelTexts[i].SetValue(i.label())
+		c.channelTexts[i].SetHorizontalAlign(HorizontalAlignCenter)
+		c.channelTexts[i].SetVerticalAlign(VerticalAlignMiddle)
+
+		input := &c.channelInputs[i]
+		switch i {
+		case colorChannelRed, colorChannelGreen, colorChannelBlue:
+			input.SetMinimumValue(0)
+			input.SetMaximumValue(255)
+		case colorChannelAlpha:
+			input.SetMinimumValue(0)
+			input.SetMaximumValue(100)
+			input.SetNumberFormat(NumberFormat{
+				Suffix: "%",
+			})
+		case colorChannelLightness:
+			input.SetMinimumValue(0)
+			input.SetMaximumValue(100)
+			input.SetPrecision(1)
+		case colorChannelChroma:
+			input.SetMinimumValue(0)
+			input.SetMaximumValueFloat64(0.4)
+			input.SetPrecision(3)
+			input.SetStepFloat64(0.01)
+		case colorChannelHue:
+			input.SetMinimumValue(0)
+			input.SetMaximumValue(360)
+		}
+		input.SetValueFloat64(c.channelValue(i))
+		input.SetOnValueChangedFloat64(func(value float64, committed bool) {
+			c.setChannelValue(i, value, committed)
+		})
+	}
+
+	c.recentSwatches.colors = theRecentColors
+	c.recentSwatches.onSelected = func(clr color.NRGBA) {
+		c.setValueByUser(newColorPickerValue(clr, c.actualValue()), true)
+	}
+	c.presetSwatches.colors = c.actualSwatchColors()
+	c.presetSwatches.onSelected = c.recentSwatches.onSelected
+
+	return nil
+}
+
+func (c *ColorPicker) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	u := UnitSize(context)
+	gap := u / 4
+
+	for row := range c.channelItems {
+		c.channelItems[row] = c.channelItems[row][:0]
+	}
+	for i := range colorChannelCount {
+		row := 0
+		if i >= colorChannelLightness {
+			row = 1
+		}
+		c.channelItems[row] = append(c.channelItems[row],
+			guigui.LinearLayoutItem{Widget: &c.channelTexts[i], Size: guigui.FixedSize(u / 2)},
+			guigui.LinearLayoutItem{Widget: &c.channelInputs[i], Size: guigui.FlexibleSize(1)},
+		)
+	}
+	// Align the second row with the first row.
+	c.channelItems[1] = append(c.channelItems[1], guigui.LinearLayoutItem{Size: guigui.FixedSize(u / 2)}, guigui.LinearLayoutItem{Size: guigui.FlexibleSize(1)})
+
+	c.rowItems = append(c.rowItems[:0],
+		guigui.LinearLayoutItem{Widget: &c.area, Size: guigui.FlexibleSize(1)},
+		guigui.LinearLayoutItem{Widget: &c.hueStrip, Size: guigui.FixedSize(u / 2)},
+		guigui.LinearLayoutItem{Widget: &c.alphaStrip, Size: guigui.FixedSize(u / 2)},
+		guigui.LinearLayoutItem{
+			Size: guigui.FixedSize(u),
+			Layout: guigui.LinearLayout{
+				Direction: guigui.LayoutDirectionHorizontal,
+				Items: []guigui.LinearLayoutItem{
+					{Widget: &c.preview, Size: guigui.FixedSize(2 * u)},
+					{Widget: &c.eyedropperButton, Size: guigui.FixedSize(u)},
+					{Widget: &c.hexInput, Size: guigui.FlexibleSize(1)},
+				},
+				Gap: gap,
+			},
+		},
+	)
+	for row := range c.channelItems {
+		c.rowItems = append(c.rowItems, guigui.LinearLayoutItem{
+			Size: guigui.FixedSize(u),
+			Layout: guigui.LinearLayout{
+				Direction: guigui.LayoutDirectionHorizontal,
+				Items:     c.channelItems[row],
+				Gap:       gap / 2,
+			},
+		})
+	}
+	if len(theRecentColors) > 0 {
+		c.rowItems = append(c.rowItems, guigui.LinearLayoutItem{Widget: &c.recentSwatches, Size: guigui.FixedSize(c.recentSwatches.height(context))})
+	}
+	c.rowItems = append(c.rowItems, guigui.LinearLayoutItem{Widget: &c.presetSwatches, Size: guigui.FixedSize(c.presetSwatches.height(context))})
+
+	if widget == &c.eyedropper {
+		return context.AppBounds()
+	}
+	return (guigui.LinearLayout{
+		Direction: guigui.LayoutDirectionVertical,
+		Items:     c.rowItems,
+		Gap:       gap,
+		Padding: guigui.Padding{
+			Start:  gap,
+			Top:    gap,
+			End:    gap,
+			Bottom: gap,
+		},
+	}).WidgetBounds(context, context.Bounds(c), widget)
+}
+
+func (c *ColorPicker) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if c.picking && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		c.picking = false
+		guigui.RequestRedraw(c)
+		return guigui.HandleInputByWidget(c)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (c *ColorPicker) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	u := UnitSize(context)
+	gap := u / 4
+	w := 12 * u
+	// The area, the strips, the hex row, the channel rows and the swatches.
+	h := 5*u + 2*(u/2) + 3*u + c.presetSwatches.height(context) + 6*gap + 2*gap
+	if len(theRecentColors) > 0 {
+		h += c.recentSwatches.height(context) + gap
+	}
+	return image.Pt(w, h)
+}
+
+func colorPickerThumbRadius(context *guigui.Context) float32 {
+	return float32(UnitSize(context)) / 4
+}
+
+// drawColorPickerThumb draws a ring to show the current position on a color area or a strip.
+func drawColorPickerThumb(context *guigui.Context, dst *ebiten.Image, x, y float32, clr color.Color) {
+	r := colorPickerThumbRadius(context)
+	s := float32(context.Scale())
+	vector.FillCircle(dst, x, y, r, clr, true)
+	vector.StrokeCircle(dst, x, y, r, 2*s, color.White, true)
+	vector.StrokeCircle(dst, x, y, r+1.5*s, 1*s, color.NRGBA{A: 0x80}, true)
+}
+
+// colorPickerImage is an image generated on CPU.
+type colorPickerImage struct {
+	image  *ebiten.Image
+	pixels []byte
+}
+
+// ensure returns the image of the size and pixels to write.
+func (c *colorPickerImage) ensure(width, height int) []byte {
+	if c.image != nil && (c.image.Bounds().Dx() != width || c.image.Bounds().Dy() != height) {
+		c.image.Deallocate()
+		c.image = nil
+	}
+	if c.image == nil {
+		c.image = ebiten.NewImage(width, height)
+	}
+	c.pixels = adjustSliceSize(c.pixels, 4*width*height)
+	return c.pixels
+}
+
+func (c *colorPickerImage) flush() {
+	c.image.WritePixels(c.pixels)
+}
+
+func setPremultipliedPixel(pix []byte, index int, clr color.Color) {
+	r, g, b, a := clr.RGBA()
+	pix[4*index] = byte(r >> 8)
+	pix[4*index+1] = byte(g >> 8)
+	pix[4*index+2] = byte(b >> 8)
+	pix[4*index+3] = byte(a >> 8)
+}
+
+func drawColorPickerImage(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, img *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(bounds.Dx())/float64(img.Bounds().Dx()), float64(bounds.Dy())/float64(img.Bounds().Dy()))
+	op.GeoM.Translate(float64(bounds.Min.X), float64(bounds.Min.Y))
+	draw.DrawInRoundedCornerRect(context, dst, bounds, RoundedCornerRadius(context)/2, img, op)
+}
+
+func drawColorPickerBorder(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle) {
+	borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset, false)
+	draw.DrawRoundedRectBorder(context, dst, bounds, borderClr1, borderClr2, RoundedCornerRadius(context)/2, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+}
+
+type checkerboardKey struct {
+	width    int
+	height   int
+	cellSize int
+}
+
+var theCheckerboardImages map[checkerboardKey]*ebiten.Image
+
+// drawCheckerboard draws a checkerboard pattern to show transparency.
+func drawCheckerboard(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle) {
+	key := checkerboardKey{
+		width:    bounds.Dx(),
+		height:   bounds.Dy(),
+		cellSize: max(UnitSize(context)/4, 1),
+	}
+	img, ok := theCheckerboardImages[key]
+	if !ok {
+		img = ebiten.NewImage(max(key.width, 1), max(key.height, 1))
+		img.Fill(color.White)
+		for y := 0; y < key.height; y += key.cellSize {
+			for x := (y / key.cellSize % 2) * key.cellSize; x < key.width; x += 2 * key.cellSize {
+				vector.FillRect(img, float32(x), float32(y), float32(key.cellSize), float32(key.cellSize), color.Gray{Y: 0xcc}, false)
+			}
+		}
+		if theCheckerboardImages == nil {
+			theCheckerboardImages = map[checkerboardKey]*ebiten.Image{}
+		}
+		theCheckerboardImages[key] = img
+	}
+	drawColorPickerImage(context, dst, bounds, img)
+}
+
+// draggableColorPickerPart is the common part of the widgets changing the color by dragging.
+type draggableColorPickerPart struct {
+	dragging bool
+}
+
+// handleDragging calls setValue with the cursor position while dragging.
+func (d *draggableColorPickerPart) handleDragging(context *guigui.Context, widget guigui.Widget, picker *ColorPicker, setValue func(pt image.Point, committed bool)) guigui.HandleInputResult {
+	if !context.IsEnabled(widget) {
+		d.dragging = false
+		return guigui.HandleInputResult{}
+	}
+	pt := context.PointerPosition()
+	if context.IsWidgetHitAtCursor(widget) && context.IsPointerJustPressed() {
+		d.dragging = true
+		setValue(pt, false)
+		return guigui.HandleInputByWidget(widget)
+	}
+	if !d.dragging {
+		return guigui.HandleInputResult{}
+	}
+	if !context.IsPointerPressed() {
+		d.dragging = false
+		picker.setValueByUser(picker.actualValue(), true)
+		return guigui.HandleInputResult{}
+	}
+	setValue(pt, false)
+	return guigui.HandleInputByWidget(widget)
+}
+
+// colorPickerArea is the area to pick the saturation and the lightness.
+type colorPickerArea struct {
+	guigui.DefaultWidget
+
+	picker *ColorPicker
+	draggableColorPickerPart
+
+	image    colorPickerImage
+	imageHue float64
+}
+
+func (c *colorPickerArea) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	return c.handleDragging(context, c, c.picker, func(pt image.Point, committed bool) {
+		b := context.Bounds(c)
+		v := c.picker.actualValue()
+		v.saturation = min(max(float64(pt.X-b.Min.X)/float64(max(b.Dx()-1, 1)), 0), 1)
+		v.lightness = 1 - min(max(float64(pt.Y-b.Min.Y)/float64(max(b.Dy()-1, 1)), 0), 1)
+		c.picker.setValueByUser(v, committed)
+	})
+}
+
+func (c *colorPickerArea) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	return ebiten.CursorShapeCrosshair, true
+}
+
+func (c *colorPickerArea) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(c)
+	if b.Empty() {
+		return
+	}
+	v := c.picker.actualValue()
+
+	w, h := b.Dx(), b.Dy()
+	if c.image.image == nil || c.image.image.Bounds().Size() != b.Size() || c.imageHue != v.hue {
+		pix := c.image.ensure(w, h)
+		hue := v.hue * math.Pi / 180
+		for y := range h {
+			l := 1 - float64(y)/float64(max(h-1, 1))
+			maxC := maxOklchChroma(l, hue)
+			for x := range w {
+				clr := oklab.Oklch{
+					L:     l,
+					C:     maxC * float64(x) / float64(max(w-1, 1)),
+					H:     hue,
+					Alpha: 1,
+				}
+				setPremultipliedPixel(pix, y*w+x, clr)
+			}
+		}
+		c.image.flush()
+		c.imageHue = v.hue
+	}
+	drawColorPickerImage(context, dst, b, c.image.image)
+	drawColorPickerBorder(context, dst, b)
+
+	x := float32(b.Min.X) + float32(min(v.saturation, 1))*float32(w-1)
+	y := float32(b.Min.Y) + float32(1-v.lightness)*float32(h-1)
+	opaque := v
+	opaque.alpha = 1
+	drawColorPickerThumb(context, dst, x, y, opaque.nrgba())
+}
+
+// colorPickerStrip is a strip to pick the hue or the alpha.
+type colorPickerStrip struct {
+	guigui.DefaultWidget
+
+	picker  *ColorPicker
+	channel colorChannel
+	draggableColorPickerPart
+
+	image colorPickerImage
+
+	// imageColor is the opaque color of the alpha strip image.
+	imageColor color.NRGBA
+}
+
+func (c *colorPickerStrip) rate() float64 {
+	v := c.picker.actualValue()
+	if c.channel == colorChannelHue {
+		return v.hue / 360
+	}
+	return v.alpha
+}
+
+func (c *colorPickerStrip) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	return c.handleDragging(context, c, c.picker, func(pt image.Point, committed bool) {
+		b := context.Bounds(c)
+		rate := min(max(float64(pt.X-b.Min.X)/float64(max(b.Dx()-1, 1)), 0), 1)
+		if context.IsRightToLeft() {
+			rate = 1 - rate
+		}
+		v := c.picker.actualValue()
+		if c.channel == colorChannelHue {
+			v.hue = min(rate*360, 359.9)
+			v.saturation = min(v.saturation, 1)
+		} else {
+			v.alpha = rate
+		}
+		c.picker.setValueByUser(v, committed)
+	})
+}
+
+func (c *colorPickerStrip) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(c)
+	if b.Empty() {
+		return
+	}
+	v := c.picker.actualValue()
+	opaque := v
+	opaque.alpha = 1
+
+	w := b.Dx()
+	switch c.channel {
+	case colorChannelHue:
+		if c.image.image == nil || c.image.image.Bounds().Dx() != w {
+			pix := c.image.ensure(w, 1)
+			for x := range w {
+				hv := colorPickerValue{
+					hue:        360 * float64(x) / float64(max(w-1, 1)),
+					saturation: 1,
+					lightness:  0.7,
+					alpha:      1,
+				}
+				setPremultipliedPixel(pix, x, hv.nrgba())
+			}
+			c.image.flush()
+		}
+	case colorChannelAlpha:
+		drawCheckerboard(context, dst, b)
+		if clr := opaque.nrgba(); c.image.image == nil || c.image.image.Bounds().Dx() != w || c.imageColor != clr {
+			pix := c.image.ensure(w, 1)
+			for x := range w {
+				a := clr
+				a.A = uint8(255 * x / max(w-1, 1))
+				setPremultipliedPixel(pix, x, a)
+			}
+			c.image.flush()
+			c.imageColor = clr
+		}
+	}
+	op := &ebiten.DrawImageOptions{}
+	if context.IsRightToLeft() {
+		op.GeoM.Scale(-1, 1)
+		op.GeoM.Translate(float64(w), 0)
+	}
+	op.GeoM.Scale(1, float64(b.Dy()))
+	op.GeoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+	draw.DrawInRoundedCornerRect(context, dst, b, RoundedCornerRadius(context)/2, c.image.image, op)
+	drawColorPickerBorder(context, dst, b)
+
+	rate := c.rate()
+	if context.IsRightToLeft() {
+		rate = 1 - rate
+	}
+	x := float32(b.Min.X) + float32(rate)*float32(w-1)
+	y := float32(b.Min.Y+b.Max.Y) / 2
+	thumbClr := opaque.nrgba()
+	if c.channel == colorChannelHue {
+		hv := colorPickerValue{
+			hue:        v.hue,
+			saturation: 1,
+			lightness:  0.7,
+			alpha:      1,
+		}
+		thumbClr = hv.nrgba()
+	}
+	drawColorPickerThumb(context, dst, x, y, thumbClr)
+}
+
+// colorPickerPreview shows the current color.
+type colorPickerPreview struct {
+	guigui.DefaultWidget
+
+	picker *ColorPicker
+}
+
+func (c *colorPickerPreview) Draw(context *guigui.Context, dst *ebiten.Image) {
+	drawColorPickerSwatch(context, dst, context.Bounds(c), c.picker.Value())
+}
+
+// drawColorPickerSwatch draws clr in bounds, over a checkerboard if clr is translucent.
+func drawColorPickerSwatch(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, clr color.NRGBA) {
+	if clr.A < 0xff {
+		drawCheckerboard(context, dst, bounds)
+	}
+	draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context)/2)
+	drawColorPickerBorder(context, dst, bounds)
+}
+
+// colorPickerSwatches is a row of colors to pick.
+type colorPickerSwatches struct {
+	guigui.DefaultWidget
+
+	colors     []color.NRGBA
+	onSelected func(clr color.NRGBA)
+}
+
+func colorPickerSwatchesPerRow() int {
+	return maxRecentColors
+}
+
+func (c *colorPickerSwatches) rowCount() int {
+	n := colorPickerSwatchesPerRow()
+	return max((len(c.colors)+n-1)/n, 1)
+}
+
+func (c *colorPickerSwatches) height(context *guigui.Context) int {
+	u := UnitSize(context)
+	return c.rowCount()*(u*3/4) + (c.rowCount()-1)*(u/8)
+}
+
+func (c *colorPickerSwatches) swatchBounds(context *guigui.Context, index int) image.Rectangle {
+	b := context.Bounds(c)
+	u := UnitSize(context)
+	n := colorPickerSwatchesPerRow()
+	gap := u / 8
+	w := (b.Dx() - (n-1)*gap) / n
+	h := u * 3 / 4
+	x := b.Min.X + (index%n)*(w+gap)
+	y := b.Min.Y + (index/n)*(h+gap)
+	r := image.Rect(x, y, x+w, y+h)
+	if context.IsRightToLeft() {
+		r = guigui.MirrorRectangle(r, b)
+	}
+	return r
+}
+
+func (c *colorPickerSwatches) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsWidgetHitAtCursor(c) || !context.IsPointerJustPressed() {
+		return guigui.HandleInputResult{}
+	}
+	pt := context.PointerPosition()
+	for i, clr := range c.colors {
+		if pt.In(c.swatchBounds(context, i)) {
+			if c.onSelected != nil {
+				c.onSelected(clr)
+			}
+			return guigui.HandleInputByWidget(c)
+		}
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (c *colorPickerSwatches) Draw(context *guigui.Context, dst *ebiten.Image) {
+	for i, clr := range c.colors {
+		drawColorPickerSwatch(context, dst, c.swatchBounds(context, i), clr)
+	}
+}
+
+// colorPickerEyedropper covers the app to pick a color on the screen.
+type colorPickerEyedropper struct {
+	guigui.DefaultWidget
+
+	picker *ColorPicker
+}
+
+func (c *colorPickerEyedropper) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsPointerJustPressed() {
+		// Block the other widgets while picking a color.
+		return guigui.HandleInputByWidget(c)
+	}
+	picker := c.picker
+	picker.picking = false
+	guigui.RequestRedraw(picker)
+	context.ReadScreenColor(context.PointerPosition(), func(clr color.Color) {
+		// Keep the alpha as the screen is opaque.
+		v := newColorPickerValue(clr, picker.actualValue())
+		v.alpha = picker.actualValue().alpha
+		picker.setValueByUser(v, true)
+	})
+	return guigui.HandleInputByWidget(c)
+}
+
+func (c *colorPickerEyedropper) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	return ebiten.CursorShapeCrosshair, true
+}
+
+func (c *colorPickerEyedropper) ZDelta() int {
+	return 1
+}
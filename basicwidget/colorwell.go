@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+)
+
+const (
+	colorWellEventValueChanged = "valueChanged"
+)
+
+// ColorWell is a compact button showing a color, which opens a ColorPicker in a popup.
+type ColorWell struct {
+	guigui.DefaultWidget
+
+	button Button
+	swatch colorWellSwatch
+	popup  Popup
+	picker ColorPicker
+}
+
+// SetOnValueChanged sets the function called when the color is changed by a user.
+// committed is false while a user is dragging or typing in the color picker.
+func (c *ColorWell) SetOnValueChanged(f func(clr color.Color, committed bool)) {
+	guigui.RegisterEventHandler(c, colorWellEventValueChanged, f)
+}
+
+// Value returns the color. The default color is opaque black.
+func (c *ColorWell) Value() color.NRGBA {
+	return c.picker.Value()
+}
+
+func (c *ColorWell) SetValue(clr color.Color) {
+	c.picker.SetValue(clr)
+}
+
+// SetSwatchColors sets the preset colors of the color picker.
+func (c *ColorWell) SetSwatchColors(colors []color.Color) {
+	c.picker.SetSwatchColors(colors)
+}
+
+func (c *ColorWell) IsOpen() bool {
+	return c.popup.IsOpen()
+}
+
+func (c *ColorWell) SetOpen(open bool) {
+	c.popup.SetOpen(open)
+}
+
+func (c *ColorWell) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&c.button)
+	adder.AddChild(&c.popup)
+}
+
+func (c *ColorWell) Update(context *guigui.Context) error {
+	c.swatch.well = c
+	c.button.SetContent(&c.swatch)
+	c.button.SetOnDown(func() {
+		c.popup.SetOpen(true)
+	})
+	c.button.setKeepPressed(c.popup.IsOpen())
+
+	c.picker.SetOnValueChanged(func(clr color.Color, committed bool) {
+		guigui.DispatchEventHandler(c, colorWellEventValueChanged, clr, committed)
+	})
+
+	c.popup.SetContent(&c.picker)
+	c.popup.SetCloseByClickingOutside(true)
+	return nil
+}
+
+func (c *ColorWell) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	switch widget {
+	case &c.button:
+		return context.Bounds(c)
+	case &c.popup:
+		return popupBoundsForAnchor(context, context.Bounds(c), context.Measure(&c.picker, guigui.Constraints{}))
+	}
+	return image.Rectangle{}
+}
+
+func (c *ColorWell) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	return image.Pt(2*UnitSize(context), defaultButtonSize(context).Y)
+}
+
+// colorWellSwatch is the content of a color well button.
+type colorWellSwatch struct {
+	guigui.DefaultWidget
+
+	well *ColorWell
+}
+
+func (c *colorWellSwatch) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(c)
+	padding := UnitSize(context) / 4
+	b = b.Inset(padding)
+	if b.Empty() {
+		return
+	}
+	drawColorPickerSwatch(context, dst, b, c.well.Value())
+}
@@ -0,0 +1,522 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"slices"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+const (
+	comboBoxEventItemSelected = "itemSelected"
+	comboBoxEventValueChanged = "valueChanged"
+)
+
+// TextRange represents a range of a string in bytes.
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// ComboBoxMatcher reports whether text matches query.
+// matches are the ranges in text to be highlighted.
+type ComboBoxMatcher func(text, query string) (matches []TextRange, ok bool)
+
+// ComboBoxSuggestionProvider provides suggestions for query.
+// setItems can be called from any goroutine, and can be called after the provider returns.
+// Results for an old query are ignored.
+type ComboBoxSuggestionProvider[T comparable] func(query string, setItems func(items []ComboBoxItem[T]))
+
+type ComboBoxItem[T comparable] struct {
+	Text     string
+	Disabled bool
+	Value    T
+}
+
+func equalFoldRune(r1, r2 rune) bool {
+	if r1 == r2 {
+		return true
+	}
+	for r := unicode.SimpleFold(r1); r != r1; r = unicode.SimpleFold(r) {
+		if r == r2 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrefixFold reports whether text has query as a prefix ignoring cases,
+// and returns the length of the matched prefix in text in bytes.
+func hasPrefixFold(text, query string) (int, bool) {
+	var n int
+	for _, q := range query {
+		if n >= len(text) {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(text[n:])
+		if !equalFoldRune(r, q) {
+			return 0, false
+		}
+		n += size
+	}
+	return n, true
+}
+
+// ComboBoxPrefixMatcher matches text starting with query, ignoring cases.
+func ComboBoxPrefixMatcher(text, query string) ([]TextRange, bool) {
+	if query == "" {
+		return nil, true
+	}
+	n, ok := hasPrefixFold(text, query)
+	if !ok {
+		return nil, false
+	}
+	return []TextRange{{Start: 0, End: n}}, true
+}
+
+// ComboBoxSubstringMatcher matches text containing query, ignoring cases.
+func ComboBoxSubstringMatcher(text, query string) ([]TextRange, bool) {
+	if query == "" {
+		return nil, true
+	}
+	for i := range text {
+		if n, ok := hasPrefixFold(text[i:], query); ok {
+			return []TextRange{{Start: i, End: i + n}}, true
+		}
+	}
+	return nil, false
+}
+
+// ComboBoxFuzzyMatcher matches text containing all the runes of query in the same order, ignoring cases.
+func ComboBoxFuzzyMatcher(text, query string) ([]TextRange, bool) {
+	if query == "" {
+		return nil, true
+	}
+	var matches []TextRange
+	var i int
+	for _, q := range query {
+		var found bool
+		for i < len(text) {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			if !equalFoldRune(r, q) {
+				i += size
+				continue
+			}
+			if len(matches) > 0 && matches[len(matches)-1].End == i {
+				matches[len(matches)-1].End = i + size
+			} else {
+				matches = append(matches, TextRange{Start: i, End: i + size})
+			}
+			i += size
+			found = true
+			break
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return matches, true
+}
+
+type ComboBox[T comparable] struct {
+	guigui.DefaultWidget
+
+	textInput    TextInput
+	popup        Popup
+	list         guigui.WidgetWithSize[*List[T]]
+	itemContents []comboBoxItemContent
+
+	items    []ComboBoxItem[T]
+	matcher  ComboBoxMatcher
+	provider ComboBoxSuggestionProvider[T]
+
+	query           string
+	filteredQuery   string
+	filtered        bool
+	filteredItems   []ComboBoxItem[T]
+	filteredMatches [][]TextRange
+	listItems       []ListItem[T]
+	selectingByKey  bool
+
+	providerM        sync.Mutex
+	providerQueryID  int
+	providerItems    []ComboBoxItem[T]
+	providerItemsSet bool
+}
+
+func (c *ComboBox[T]) SetOnItemSelected(f func(item ComboBoxItem[T])) {
+	guigui.RegisterEventHandler(c, comboBoxEventItemSelected, f)
+}
+
+func (c *ComboBox[T]) SetOnValueChanged(f func(text string, committed bool)) {
+	guigui.RegisterEventHandler(c, comboBoxEventValueChanged, f)
+}
+
+func (c *ComboBox[T]) SetItems(items []ComboBoxItem[T]) {
+	if slices.Equal(c.items, items) {
+		return
+	}
+	c.items = adjustSliceSize(c.items, len(items))
+	copy(c.items, items)
+	if c.provider == nil {
+		c.filter(c.items)
+	}
+}
+
+func (c *ComboBox[T]) SetItemsByStrings(strs []string) {
+	items := make([]ComboBoxItem[T], len(strs))
+	for i, str := range strs {
+		items[i].Text = str
+	}
+	c.SetItems(items)
+}
+
+// SetMatcher sets the matcher to filter items.
+// The default matcher is ComboBoxPrefixMatcher.
+//
+// SetMatcher filters the items again, as functions cannot be compared.
+// Set a matcher once, e.g. at the first Update, instead of at every Update.
+func (c *ComboBox[T]) SetMatcher(matcher ComboBoxMatcher) {
+	c.matcher = matcher
+	c.filter(c.candidateItems())
+}
+
+// SetSuggestionProvider sets the provider of items.
+// If a provider is set, the items set by SetItems are ignored.
+func (c *ComboBox[T]) SetSuggestionProvider(provider ComboBoxSuggestionProvider[T]) {
+	if c.provider == nil && provider == nil {
+		return
+	}
+	c.provider = provider
+	if c.provider == nil {
+		c.filter(c.items)
+	}
+}
+
+func (c *ComboBox[T]) Value() string {
+	return c.textInput.Value()
+}
+
+func (c *ComboBox[T]) SetValue(text string) {
+	if c.query == text && c.textInput.Value() == text {
+		return
+	}
+	c.query = text
+	c.textInput.SetValue(text)
+	c.updateSuggestions()
+}
+
+func (c *ComboBox[T]) IsOpen() bool {
+	return c.popup.IsOpen()
+}
+
+func (c *ComboBox[T]) SetOpen(open bool) {
+	c.popup.SetOpen(open)
+}
+
+func (c *ComboBox[T]) candidateItems() []ComboBoxItem[T] {
+	if c.provider != nil {
+		c.providerM.Lock()
+		defer c.providerM.Unlock()
+		return c.providerItems
+	}
+	return c.items
+}
+
+func (c *ComboBox[T]) actualMatcher() ComboBoxMatcher {
+	if c.matcher != nil {
+		return c.matcher
+	}
+	return ComboBoxPrefixMatcher
+}
+
+func (c *ComboBox[T]) updateSuggestions() {
+	if c.provider == nil {
+		// The items are filtered again by SetItems when they change.
+		if c.filtered && c.filteredQuery == c.query {
+			return
+		}
+		c.filter(c.items)
+		return
+	}
+
+	c.providerM.Lock()
+	c.providerQueryID++
+	id := c.providerQueryID
+	c.providerItemsSet = false
+	c.providerM.Unlock()
+
+	c.provider(c.query, func(items []ComboBoxItem[T]) {
+		c.providerM.Lock()
+		defer c.providerM.Unlock()
+		if c.providerQueryID != id {
+			return
+		}
+		c.providerItems = slices.Clone(items)
+		c.providerItemsSet = true
+	})
+}
+
+func (c *ComboBox[T]) filter(items []ComboBoxItem[T]) {
+	matcher := c.actualMatcher()
+	c.filteredQuery = c.query
+	c.filtered = true
+
+	c.filteredItems = c.filteredItems[:0]
+	c.filteredMatches = c.filteredMatches[:0]
+	for _, item := range items {
+		matches, ok := matcher(item.Text, c.query)
+		if !ok {
+			// Items from a provider are already filtered. Show them without highlighting.
+			if c.provider == nil {
+				continue
+			}
+			matches = nil
+		}
+		c.filteredItems = append(c.filteredItems, item)
+		c.filteredMatches = append(c.filteredMatches, matches)
+	}
+
+	c.itemContents = adjustSliceSize(c.itemContents, len(c.filteredItems))
+	c.listItems = adjustSliceSize(c.listItems, len(c.filteredItems))
+	for i, item := range c.filteredItems {
+		c.itemContents[i].setText(item.Text, c.filteredMatches[i])
+		c.listItems[i] = ListItem[T]{
+			Content:  &c.itemContents[i],
+			Disabled: item.Disabled,
+			Value:    item.Value,
+		}
+	}
+	list := c.list.Widget()
+	list.SetItems(c.listItems)
+	c.selectByKey(-1)
+	guigui.RequestRedraw(c)
+}
+
+func (c *ComboBox[T]) selectByKey(index int) {
+	c.selectingByKey = true
+	defer func() {
+		c.selectingByKey = false
+	}()
+	list := c.list.Widget()
+	list.SelectItemByIndex(index)
+	if index >= 0 {
+		list.JumpToItemIndex(index)
+	}
+}
+
+func (c *ComboBox[T]) moveSelection(delta int) {
+	list := c.list.Widget()
+	index := list.SelectedItemIndex()
+	for {
+		index += delta
+		if index < 0 {
+			c.selectByKey(-1)
+			return
+		}
+		if index >= len(c.filteredItems) {
+			return
+		}
+		if !c.filteredItems[index].Disabled {
+			break
+		}
+	}
+	c.selectByKey(index)
+}
+
+func (c *ComboBox[T]) chooseItem(index int) {
+	if index < 0 || index >= len(c.filteredItems) {
+		return
+	}
+	item := c.filteredItems[index]
+	c.query = item.Text
+	c.textInput.text.setTextAndSelection(item.Text, len(item.Text), len(item.Text), -1)
+	c.popup.SetOpen(false)
+	guigui.DispatchEventHandler(c, comboBoxEventItemSelected, item)
+	c.textInput.CommitWithCurrentInputValue()
+}
+
+func (c *ComboBox[T]) openIfNeeded(context *guigui.Context) {
+	if !c.textInput.isFocused(context) {
+		return
+	}
+	if len(c.filteredItems) == 0 {
+		c.popup.SetOpen(false)
+		return
+	}
+	c.popup.SetOpen(true)
+}
+
+func (c *ComboBox[T]) handleKey(context *guigui.Context, key ebiten.Key) bool {
+	if !c.popup.IsOpen() {
+		switch key {
+		case ebiten.KeyArrowDown:
+			c.updateSuggestions()
+			c.openIfNeeded(context)
+			return true
+		}
+		return false
+	}
+
+	switch key {
+	case ebiten.KeyArrowDown:
+		c.moveSelection(1)
+		return true
+	case ebiten.KeyArrowUp:
+		c.moveSelection(-1)
+		return true
+	case ebiten.KeyEnter, ebiten.KeyNumpadEnter:
+		index := c.list.Widget().SelectedItemIndex()
+		if index < 0 {
+			c.popup.SetOpen(false)
+			return false
+		}
+		c.chooseItem(index)
+		return true
+	case ebiten.KeyEscape:
+		c.popup.SetOpen(false)
+		return true
+	}
+	return false
+}
+
+func (c *ComboBox[T]) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&c.textInput)
+	adder.AddChild(&c.popup)
+}
+
+func (c *ComboBox[T]) Update(context *guigui.Context) error {
+	c.textInput.SetOnValueChanged(func(text string, committed bool) {
+		if !committed && text != c.query {
+			c.query = text
+			c.updateSuggestions()
+			c.openIfNeeded(context)
+		}
+		guigui.DispatchEventHandler(c, comboBoxEventValueChanged, text, committed)
+	})
+	c.textInput.SetOnKeyJustPressed(func(key ebiten.Key) bool {
+		return c.handleKey(context, key)
+	})
+
+	list := c.list.Widget()
+	list.SetOnItemSelected(func(index int) {
+		if c.selectingByKey {
+			return
+		}
+		c.chooseItem(index)
+		context.SetFocused(&c.textInput, true)
+	})
+	c.list.SetFixedSize(c.popupBounds(context).Size())
+
+	c.popup.SetContent(&c.list)
+	c.popup.SetCloseByClickingOutside(true)
+	c.popup.setKeepFocus(true)
+
+	return nil
+}
+
+func (c *ComboBox[T]) Tick(context *guigui.Context) error {
+	if c.provider != nil {
+		c.providerM.Lock()
+		items := c.providerItems
+		set := c.providerItemsSet
+		c.providerItemsSet = false
+		c.providerM.Unlock()
+		if set {
+			c.filter(items)
+			c.openIfNeeded(context)
+		}
+	}
+
+	// Close the popup when the focus moves to another widget e.g. by the tab key.
+	if c.popup.IsOpen() && !c.textInput.isFocused(context) && !context.IsFocusedOrHasFocusedChild(&c.popup) {
+		c.popup.SetOpen(false)
+	}
+	return nil
+}
+
+func (c *ComboBox[T]) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	switch widget {
+	case &c.textInput:
+		return context.Bounds(c)
+	case &c.popup:
+		return c.popupBounds(context)
+	}
+	return image.Rectangle{}
+}
+
+func (c *ComboBox[T]) popupBounds(context *guigui.Context) image.Rectangle {
+	b := context.Bounds(c)
+	s := context.Measure(c.list.Widget(), guigui.Constraints{})
+	s.X = max(s.X, b.Dx())
+	s.Y = min(s.Y, 8*UnitSize(context))
+	return popupBoundsForAnchor(context, b, s)
+}
+
+func (c *ComboBox[T]) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	return c.textInput.Measure(context, constraints)
+}
+
+type comboBoxItemContent struct {
+	guigui.DefaultWidget
+
+	text    Text
+	matches []TextRange
+}
+
+func (c *comboBoxItemContent) setText(text string, matches []TextRange) {
+	if c.text.Value() == text && slices.Equal(c.matches, matches) {
+		return
+	}
+	c.text.SetValue(text)
+	c.matches = adjustSliceSize(c.matches, len(matches))
+	copy(c.matches, matches)
+	guigui.RequestRedraw(c)
+}
+
+func (c *comboBoxItemContent) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&c.text)
+}
+
+func (c *comboBoxItemContent) Update(context *guigui.Context) error {
+	c.text.SetVerticalAlign(VerticalAlignMiddle)
+	c.text.SetColor(draw.TextColor(context.ColorMode(), context.IsEnabled(c)))
+	return nil
+}
+
+func (c *comboBoxItemContent) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	switch widget {
+	case &c.text:
+		return context.Bounds(c)
+	}
+	return image.Rectangle{}
+}
+
+func (c *comboBoxItemContent) Draw(context *guigui.Context, dst *ebiten.Image) {
+	clr := draw.ScaleAlpha(draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5), 0.3)
+	for _, m := range c.matches {
+		start, ok := c.text.textPosition(context, m.Start, false)
+		if !ok {
+			continue
+		}
+		end, ok := c.text.textPosition(context, m.End, false)
+		if !ok {
+			continue
+		}
+		r := image.Rect(int(start.X), int(start.Top), int(end.X), int(end.Bottom))
+		draw.DrawRoundedRect(context, dst, r, clr, RoundedCornerRadius(context)/2)
+	}
+}
+
+func (c *comboBoxItemContent) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	return c.text.Measure(context, constraints)
+}
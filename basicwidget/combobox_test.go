@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestComboBoxMatchers(t *testing.T) {
+	testCases := []struct {
+		name    string
+		matcher basicwidget.ComboBoxMatcher
+		text    string
+		query   string
+		matches []basicwidget.TextRange
+		ok      bool
+	}{
+		{
+			name:    "prefix",
+			matcher: basicwidget.ComboBoxPrefixMatcher,
+			text:    "Apple",
+			query:   "ap",
+			matches: []basicwidget.TextRange{{Start: 0, End: 2}},
+			ok:      true,
+		},
+		{
+			name:    "prefix",
+			matcher: basicwidget.ComboBoxPrefixMatcher,
+			text:    "Pineapple",
+			query:   "ap",
+			ok:      false,
+		},
+		{
+			name:    "prefix",
+			matcher: basicwidget.ComboBoxPrefixMatcher,
+			text:    "Apple",
+			query:   "",
+			ok:      true,
+		},
+		{
+			name:    "prefix",
+			matcher: basicwidget.ComboBoxPrefixMatcher,
+			text:    "ap",
+			query:   "apple",
+			ok:      false,
+		},
+		{
+			name:    "substring",
+			matcher: basicwidget.ComboBoxSubstringMatcher,
+			text:    "Pineapple",
+			query:   "APP",
+			matches: []basicwidget.TextRange{{Start: 4, End: 7}},
+			ok:      true,
+		},
+		{
+			name:    "substring",
+			matcher: basicwidget.ComboBoxSubstringMatcher,
+			text:    "Ünïcödé",
+			query:   "ÏCÖ",
+			matches: []basicwidget.TextRange{{Start: 3, End: 8}},
+			ok:      true,
+		},
+		{
+			name:    "fuzzy",
+			matcher: basicwidget.ComboBoxFuzzyMatcher,
+			text:    "basicwidget/combobox.go",
+			query:   "bwcb",
+			matches: []basicwidget.TextRange{{Start: 0, End: 1}, {Start: 5, End: 6}, {Start: 12, End: 13}, {Start: 15, End: 16}},
+			ok:      true,
+		},
+		{
+			name:    "fuzzy",
+			matcher: basicwidget.ComboBoxFuzzyMatcher,
+			text:    "combobox",
+			query:   "comb",
+			matches: []basicwidget.TextRange{{Start: 0, End: 4}},
+			ok:      true,
+		},
+		{
+			name:    "fuzzy",
+			matcher: basicwidget.ComboBoxFuzzyMatcher,
+			text:    "combobox",
+			query:   "bc",
+			ok:      false,
+		},
+	}
+	for _, tc := range testCases {
+		matches, ok := tc.matcher(tc.text, tc.query)
+		if ok != tc.ok {
+			t.Errorf("%s: matcher(%q, %q): ok: got: %t, want: %t", tc.name, tc.text, tc.query, ok, tc.ok)
+			continue
+		}
+		if !slices.Equal(matches, tc.matches) {
+			t.Errorf("%s: matcher(%q, %q): matches: got: %v, want: %v", tc.name, tc.text, tc.query, matches, tc.matches)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+const (
+	datePickerEventValueChanged = "valueChanged"
+)
+
+// DatePicker is a text input for a date with a popup calendar.
+//
+// A user can type a date in the format of the app locale or in the ISO 8601 format like "2025-01-31".
+// While the calendar is open, the arrow keys move the cursor in the calendar and the enter key selects the date.
+type DatePicker struct {
+	guigui.DefaultWidget
+
+	textInput TextInput
+	button    Button
+	popup     Popup
+	calendar  Calendar
+
+	layout string
+}
+
+// SetOnValueChanged sets the function called when the date is changed by a user.
+// value is midnight of the date in the location of the date picker, or the zero time if the date is cleared.
+func (d *DatePicker) SetOnValueChanged(f func(value time.Time)) {
+	guigui.RegisterEventHandler(d, datePickerEventValueChanged, f)
+}
+
+// Value returns midnight of the date in the location of the date picker.
+// Value returns the zero time if no date is set.
+func (d *DatePicker) Value() time.Time {
+	return d.calendar.Value()
+}
+
+// SetValue sets the date of value in the location of the date picker.
+// If value is the zero time, the date is cleared.
+func (d *DatePicker) SetValue(value time.Time) {
+	d.calendar.SetValue(value)
+}
+
+// SetMinimumDate sets the earliest date. The zero time means no minimum.
+func (d *DatePicker) SetMinimumDate(date time.Time) {
+	d.calendar.SetMinimumDate(date)
+}
+
+// SetMaximumDate sets the latest date. The zero time means no maximum.
+func (d *DatePicker) SetMaximumDate(date time.Time) {
+	d.calendar.SetMaximumDate(date)
+}
+
+// SetDisabledDateFunc sets the function to report whether a date cannot be selected.
+// See also Calendar.SetDisabledDateFunc.
+func (d *DatePicker) SetDisabledDateFunc(f func(date time.Time) bool) {
+	d.calendar.SetDisabledDateFunc(f)
+}
+
+// SetWeekStart sets the first day of a week in the calendar.
+// The default first day follows the region of the app locale.
+func (d *DatePicker) SetWeekStart(weekday time.Weekday) {
+	d.calendar.SetWeekStart(weekday)
+}
+
+// SetLocation sets the location to interpret the dates. The default location is time.Local.
+func (d *DatePicker) SetLocation(location *time.Location) {
+	d.calendar.SetLocation(location)
+}
+
+// SetLayout sets the layout to format a date as time.Time.Format does, e.g. "2006-01-02".
+// The default layout follows the app locale.
+func (d *DatePicker) SetLayout(layout string) {
+	if d.layout == layout {
+		return
+	}
+	d.layout = layout
+	guigui.RequestRedraw(d)
+}
+
+func (d *DatePicker) IsOpen() bool {
+	return d.popup.IsOpen()
+}
+
+func (d *DatePicker) SetOpen(open bool) {
+	if open && !d.popup.IsOpen() {
+		d.calendar.resetCursor()
+	}
+	d.popup.SetOpen(open)
+}
+
+func (d *DatePicker) actualLayout(context *guigui.Context) string {
+	if d.layout != "" {
+		return d.layout
+	}
+	return dateLayout(d.textInput.text.lang(context))
+}
+
+func (d *DatePicker) valueText(context *guigui.Context) string {
+	v := d.calendar.Value()
+	if v.IsZero() {
+		return ""
+	}
+	return v.Format(d.actualLayout(context))
+}
+
+// setValueByUser sets the value and dispatches the event if the value is changed.
+func (d *DatePicker) setValueByUser(value time.Time) {
+	old := d.calendar.Value()
+	d.calendar.SetValue(value)
+	if d.calendar.Value().Equal(old) {
+		return
+	}
+	guigui.DispatchEventHandler(d, datePickerEventValueChanged, d.calendar.Value())
+}
+
+// commitText sets the value by the text typed by a user.
+// If the text is not a valid date, the text is reverted.
+func (d *DatePicker) commitText(context *guigui.Context, text string) {
+	if text == "" {
+		d.setValueByUser(time.Time{})
+		return
+	}
+	if v, ok := parseDate(text, d.actualLayout(context), d.calendar.actualLocation()); ok && d.calendar.isSelectable(civilDate(v)) {
+		d.setValueByUser(v)
+	}
+	d.textInput.ForceSetValue(d.valueText(context))
+}
+
+func (d *DatePicker) handleKey(context *guigui.Context, key ebiten.Key) bool {
+	if !d.popup.IsOpen() {
+		if key == ebiten.KeyArrowDown {
+			d.SetOpen(true)
+			return true
+		}
+		return false
+	}
+
+	switch key {
+	case ebiten.KeyEscape:
+		d.popup.SetOpen(false)
+		return true
+	case ebiten.KeySpace:
+		// A space is a character in the text input.
+		return false
+	}
+	return d.calendar.handleKey(context, key)
+}
+
+func (d *DatePicker) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&d.textInput)
+	adder.AddChild(&d.button)
+	adder.AddChild(&d.popup)
+}
+
+func (d *DatePicker) Update(context *guigui.Context) error {
+	d.textInput.SetValue(d.valueText(context))
+	d.textInput.SetTabular(true)
+	d.textInput.setPaddingEnd(UnitSize(context))
+	d.textInput.SetOnValueChanged(func(text string, committed bool) {
+		if committed {
+			d.commitText(context, text)
+		}
+	})
+	d.textInput.SetOnKeyJustPressed(func(key ebiten.Key) bool {
+		return d.handleKey(context, key)
+	})
+
+	d.button.SetVectorIcon(vectorIconCalendarToday)
+	d.button.setSharpenCorners(draw.SharpenCorners{
+		UpperStart: true,
+		LowerStart: true,
+	})
+	d.button.SetOnDown(func() {
+		d.SetOpen(true)
+		context.SetFocused(&d.textInput, true)
+	})
+	context.SetEnabled(&d.button, d.textInput.IsEditable())
+
+	d.calendar.setKeepFocus(true)
+	d.calendar.SetOnDateSelected(func(date time.Time) {
+		d.popup.SetOpen(false)
+		context.SetFocused(&d.textInput, true)
+		d.textInput.ForceSetValue(d.valueText(context))
+	})
+	d.calendar.setOnValueChanged(func(value time.Time) {
+		guigui.DispatchEventHandler(d, datePickerEventValueChanged, value)
+	})
+
+	d.popup.SetContent(&d.calendar)
+	d.popup.SetCloseByClickingOutside(true)
+	d.popup.setKeepFocus(true)
+
+	return nil
+}
+
+func (d *DatePicker) Tick(context *guigui.Context) error {
+	// Close the popup when the focus moves to another widget e.g. by the tab key.
+	if d.popup.IsOpen() && !d.textInput.isFocused(context) && !context.IsFocusedOrHasFocusedChild(&d.popup) {
+		d.popup.SetOpen(false)
+	}
+	return nil
+}
+
+func (d *DatePicker) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	switch widget {
+	case &d.textInput:
+		return context.Bounds(d)
+	case &d.button:
+		b := context.Bounds(d)
+		r := b
+		r.Min.X = r.Max.X - UnitSize(context)
+		if context.IsRightToLeft() {
+			r = guigui.MirrorRectangle(r, b)
+		}
+		return r
+	case &d.popup:
+		return popupBoundsForAnchor(context, context.Bounds(d), context.Measure(&d.calendar, guigui.Constraints{}))
+	}
+	return image.Rectangle{}
+}
+
+func (d *DatePicker) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	return d.textInput.Measure(context, constraints)
+}
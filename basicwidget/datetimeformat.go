@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// calendarNames represents the names to show dates in a locale.
+type calendarNames struct {
+	months []string
+
+	// weekdays are the short names of the weekdays from Sunday.
+	weekdays []string
+
+	// monthYear is the format of a month and a year, taking the month name and the year as arguments.
+	monthYear string
+}
+
+// theCalendarNames is based on the standalone names in CLDR.
+//
+// golang.org/x/text doesn't provide the names of months and weekdays, so only these languages are supported.
+// The English names are used for the other languages.
+var theCalendarNames = map[string]*calendarNames{
+	"en": {
+		months:    []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		weekdays:  []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		monthYear: "%[1]s %[2]d",
+	},
+	"de": {
+		months:    []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		weekdays:  []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		monthYear: "%[1]s %[2]d",
+	},
+	"es": {
+		months:    []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		weekdays:  []string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		monthYear: "%[1]s de %[2]d",
+	},
+	"fr": {
+		months:    []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		weekdays:  []string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		monthYear: "%[1]s %[2]d",
+	},
+	"it": {
+		months:    []string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		weekdays:  []string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		monthYear: "%[1]s %[2]d",
+	},
+	"ja": {
+		months:    []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:  []string{"日", "月", "火", "水", "木", "金", "土"},
+		monthYear: "%[2]d年%[1]s",
+	},
+	"ko": {
+		months:    []string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		weekdays:  []string{"일", "월", "화", "수", "목", "금", "토"},
+		monthYear: "%[2]d년 %[1]s",
+	},
+	"pt": {
+		months:    []string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		weekdays:  []string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		monthYear: "%[1]s de %[2]d",
+	},
+	"ru": {
+		months:    []string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		weekdays:  []string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		monthYear: "%[1]s %[2]d г.",
+	},
+	"zh": {
+		months:    []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:  []string{"日", "一", "二", "三", "四", "五", "六"},
+		monthYear: "%[2]d年%[1]s",
+	},
+}
+
+var (
+	theCalendarNamesMatcher language.Matcher
+	theCalendarNamesTags    []language.Tag
+)
+
+func calendarNamesForLocale(locale language.Tag) *calendarNames {
+	if theCalendarNamesMatcher == nil {
+		// English is the fallback, and must be the first.
+		theCalendarNamesTags = []language.Tag{language.English}
+		for _, name := range []string{"de", "es", "fr", "it", "ja", "ko", "pt", "ru", "zh"} {
+			theCalendarNamesTags = append(theCalendarNamesTags, language.Make(name))
+		}
+		theCalendarNamesMatcher = language.NewMatcher(theCalendarNamesTags)
+	}
+	_, index, _ := theCalendarNamesMatcher.Match(locale)
+	base, _ := theCalendarNamesTags[index].Base()
+	return theCalendarNames[base.String()]
+}
+
+// formatMonthYear formats the month and the year like "January 2025" in a locale.
+func formatMonthYear(locale language.Tag, year int, month time.Month) string {
+	names := calendarNamesForLocale(locale)
+	return fmt.Sprintf(names.monthYear, names.months[month-1], year)
+}
+
+// weekdayName returns the short name of a weekday like "Mon" in a locale.
+func weekdayName(locale language.Tag, weekday time.Weekday) string {
+	return calendarNamesForLocale(locale).weekdays[weekday]
+}
+
+// firstWeekdayRegions are the regions whose weeks don't start on Monday, based on CLDR.
+var firstWeekdayRegions = map[time.Weekday]string{
+	time.Saturday: "AE AF BH DJ DZ EG IQ IR JO KW LY OM QA SD SY",
+	time.Sunday:   "AG AS BD BR BS BT BW BZ CA CN CO DM DO ET GT GU HK HN ID IL IN JM JP KE KH KR LA MH MM MO MT MX MZ NI NP PA PE PH PK PR PT PY SA SG SV TH TT TW UM US VE VI WS YE ZA ZW",
+	time.Friday:   "MV",
+}
+
+// firstWeekday returns the first day of a week in a locale.
+func firstWeekday(locale language.Tag) time.Weekday {
+	region, _ := locale.Region()
+	r := region.String()
+	for weekday, regions := range firstWeekdayRegions {
+		for _, rr := range strings.Fields(regions) {
+			if rr == r {
+				return weekday
+			}
+		}
+	}
+	return time.Monday
+}
+
+// dateLayout returns the layout to format a date by time.Time.Format in a locale.
+func dateLayout(locale language.Tag) string {
+	base, _ := locale.Base()
+	region, _ := locale.Region()
+	switch base.String() {
+	case "en":
+		switch region.String() {
+		case "US":
+			return "1/2/2006"
+		case "CA":
+			return "2006-01-02"
+		}
+		return "02/01/2006"
+	case "ja":
+		return "2006/01/02"
+	case "zh":
+		return "2006/1/2"
+	case "ko":
+		return "2006. 1. 2."
+	case "de", "ru":
+		return "02.01.2006"
+	case "es", "fr", "it", "pt":
+		return "02/01/2006"
+	}
+	return "2006-01-02"
+}
+
+// timeLayout returns the layout to format a time of a day by time.Time.Format in a locale.
+func timeLayout(locale language.Tag) string {
+	base, _ := locale.Base()
+	region, _ := locale.Region()
+	if base.String() == "en" {
+		switch region.String() {
+		case "US", "CA", "AU", "NZ", "IN", "PH":
+			return "3:04 PM"
+		}
+	}
+	return "15:04"
+}
+
+// parseDate parses str as a date in layout or in the ISO 8601 format.
+func parseDate(str string, layout string, location *time.Location) (time.Time, bool) {
+	str = strings.TrimSpace(str)
+	for _, l := range []string{layout, time.DateOnly} {
+		if t, err := time.ParseInLocation(l, str, location); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseTime parses str as a time of a day in layout, or in the 24-hour or 12-hour format.
+// The returned value is the duration from midnight.
+func parseTime(str string, layout string) (time.Duration, bool) {
+	str = strings.TrimSpace(str)
+	for _, l := range []string{layout, "15:04", "15:04:05", "3:04 PM", "3:04PM", "3:04 pm", "3:04pm"} {
+		if t, err := time.Parse(l, str); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, true
+		}
+	}
+	return 0, false
+}
+
+// civilDate returns the date of t in its location as midnight in UTC.
+// Calendars use civil dates so that the calculation is not affected by daylight saving time.
+func civilDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// calendarGridStart returns the date of the first cell in the grid of the month, which starts on weekStart.
+func calendarGridStart(year int, month time.Month, weekStart time.Weekday) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(first.Weekday()) - int(weekStart) + 7) % 7
+	return first.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestFirstWeekday(t *testing.T) {
+	testCases := []struct {
+		locale string
+		want   time.Weekday
+	}{
+		{locale: "en-US", want: time.Sunday},
+		{locale: "en-GB", want: time.Monday},
+		{locale: "en", want: time.Sunday},
+		{locale: "de", want: time.Monday},
+		{locale: "ja", want: time.Sunday},
+		{locale: "ar-EG", want: time.Saturday},
+		{locale: "dv-MV", want: time.Friday},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.FirstWeekday(language.MustParse(tc.locale)); got != tc.want {
+			t.Errorf("FirstWeekday(%s): got: %v, want: %v", tc.locale, got, tc.want)
+		}
+	}
+}
+
+func TestDateLayout(t *testing.T) {
+	date := time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "en-US", want: "3/7/2025"},
+		{locale: "en-GB", want: "07/03/2025"},
+		{locale: "de", want: "07.03.2025"},
+		{locale: "ja", want: "2025/03/07"},
+		{locale: "ko", want: "2025. 3. 7."},
+		{locale: "sv", want: "2025-03-07"},
+	}
+	for _, tc := range testCases {
+		if got := date.Format(basicwidget.DateLayout(language.MustParse(tc.locale))); got != tc.want {
+			t.Errorf("date in %s: got: %q, want: %q", tc.locale, got, tc.want)
+		}
+	}
+}
+
+func TestFormatMonthYear(t *testing.T) {
+	testCases := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "en", want: "March 2025"},
+		{locale: "en-GB", want: "March 2025"},
+		{locale: "es", want: "marzo de 2025"},
+		{locale: "ja", want: "2025年3月"},
+		{locale: "pt-BR", want: "março de 2025"},
+		{locale: "nl", want: "March 2025"},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.FormatMonthYear(language.MustParse(tc.locale), 2025, time.March); got != tc.want {
+			t.Errorf("FormatMonthYear in %s: got: %q, want: %q", tc.locale, got, tc.want)
+		}
+	}
+}
+
+func TestCalendarGridStart(t *testing.T) {
+	// March 1, 2025 is Saturday.
+	testCases := []struct {
+		weekStart time.Weekday
+		want      time.Time
+	}{
+		{weekStart: time.Sunday, want: time.Date(2025, time.February, 23, 0, 0, 0, 0, time.UTC)},
+		{weekStart: time.Monday, want: time.Date(2025, time.February, 24, 0, 0, 0, 0, time.UTC)},
+		{weekStart: time.Saturday, want: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.CalendarGridStart(2025, time.March, tc.weekStart); !got.Equal(tc.want) {
+			t.Errorf("CalendarGridStart(2025, March, %v): got: %v, want: %v", tc.weekStart, got, tc.want)
+		}
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	testCases := []struct {
+		date   time.Time
+		months int
+		want   time.Time
+	}{
+		{date: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC), months: 1, want: time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		{date: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), months: 12, want: time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		{date: time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), months: -3, want: time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.AddMonthsClamped(tc.date, tc.months); !got.Equal(tc.want) {
+			t.Errorf("AddMonthsClamped(%v, %d): got: %v, want: %v", tc.date, tc.months, got, tc.want)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	testCases := []struct {
+		str    string
+		layout string
+		want   time.Duration
+		ok     bool
+	}{
+		{str: "13:45", layout: "15:04", want: 13*time.Hour + 45*time.Minute, ok: true},
+		{str: "1:45 PM", layout: "3:04 PM", want: 13*time.Hour + 45*time.Minute, ok: true},
+		{str: "13:45", layout: "3:04 PM", want: 13*time.Hour + 45*time.Minute, ok: true},
+		{str: "25:00", layout: "15:04", ok: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.ParseTime(tc.str, tc.layout)
+		if ok != tc.ok || got != tc.want {
+			t.Errorf("ParseTime(%q, %q): got: %v, %t, want: %v, %t", tc.str, tc.layout, got, ok, tc.want, tc.ok)
+		}
+	}
+}
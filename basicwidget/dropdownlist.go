@@ -191,11 +191,7 @@ func (d *dropdownListButtonContent) AddChildren(context *guigui.Context, adder *
 }
 
 func (d *dropdownListButtonContent) Update(context *guigui.Context) error {
-	img, err := theResourceImages.Get("unfold_more", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	d.image.SetImage(img)
+	d.image.SetVectorIcon(vectorIconUnfoldMore)
 	return nil
 }
 
@@ -3,6 +3,161 @@
 
 package basicwidget
 
+import (
+	"image"
+	"image/color"
+	"math/big"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
 func ReplaceNewLinesWithSpace(text string, start, end, shiftIndex int) (string, int, int, int) {
 	return replaceNewLinesWithSpace(text, start, end, shiftIndex)
 }
+
+func FindTextMatches(str, query string, options TextFindOptions) ([]TextRange, error) {
+	re, err := newTextFindRegexp(query, options)
+	if err != nil {
+		return nil, err
+	}
+	return appendTextFindMatches(nil, str, re, options.WholeWord), nil
+}
+
+func AutoIndent(text string, idx int) string {
+	return autoIndent(text, idx)
+}
+
+func IndentLines(text string, start, end int, indent string) (string, int, int) {
+	return indentLines(text, start, end, indent)
+}
+
+func UnindentLines(text string, start, end int, maxSpaces int) (string, int, int) {
+	return unindentLines(text, start, end, maxSpaces)
+}
+
+func MatchingBracket(text string, idx int) (int, bool) {
+	return matchingBracket(text, idx)
+}
+
+func RoundDecimal(str string, precision int, mode RoundingMode) string {
+	x, ok := parseDecimal(str)
+	if !ok {
+		return ""
+	}
+	roundRat(x, precision, mode)
+	return x.FloatString(precision)
+}
+
+func ParseDecimal(str string) (string, bool) {
+	x, ok := parseDecimal(str)
+	if !ok {
+		return "", false
+	}
+	return x.RatString(), true
+}
+
+func DownscaledImageSize(size image.Point, maxSize image.Point) image.Point {
+	return downscaledImageSize(size, maxSize)
+}
+
+// LRUCacheKeys adds keys to an LRU cache with capacity, looks up getKeys, and returns the remaining keys from the most recently used.
+func LRUCacheKeys(capacity int, keys []string, getKeys []string) []string {
+	c := lruCache[string, int]{
+		capacity: capacity,
+	}
+	for i, k := range keys {
+		c.add(k, i)
+	}
+	for _, k := range getKeys {
+		c.get(k)
+	}
+	var remaining []string
+	for e := c.entries.Front(); e != nil; e = e.Next() {
+		remaining = append(remaining, e.Value.(*lruCacheEntry[string, int]).key)
+	}
+	return remaining
+}
+
+func FirstWeekday(locale language.Tag) time.Weekday {
+	return firstWeekday(locale)
+}
+
+func DateLayout(locale language.Tag) string {
+	return dateLayout(locale)
+}
+
+func FormatMonthYear(locale language.Tag, year int, month time.Month) string {
+	return formatMonthYear(locale, year, month)
+}
+
+func CalendarGridStart(year int, month time.Month, weekStart time.Weekday) time.Time {
+	return calendarGridStart(year, month, weekStart)
+}
+
+func AddMonthsClamped(date time.Time, months int) time.Time {
+	return addMonthsClamped(date, months)
+}
+
+func ParseTime(str string, layout string) (time.Duration, bool) {
+	return parseTime(str, layout)
+}
+
+func FormatHexColor(clr color.NRGBA) string {
+	return formatHexColor(clr)
+}
+
+func ParseHexColor(str string) (color.NRGBA, bool) {
+	return parseHexColor(str)
+}
+
+func MaxOklchChroma(lightness, hue float64) float64 {
+	return maxOklchChroma(lightness, hue)
+}
+
+// RoundTripColorPickerValue converts clr to the color picker's representation and back.
+func RoundTripColorPickerValue(clr color.NRGBA) color.NRGBA {
+	return newColorPickerValue(clr, colorPickerValue{}).nrgba()
+}
+
+func newTestSlider(ticks []SliderTick, min, max, step float64) *Slider {
+	var s Slider
+	s.SetMinimumValueFloat64(min)
+	s.SetMaximumValueFloat64(max)
+	if step > 0 {
+		s.SetStepFloat64(step)
+	}
+	s.SetTicks(ticks)
+	return &s
+}
+
+func SliderNearestTickValue(ticks []SliderTick, min, max float64, value float64) (float64, bool) {
+	v, ok := newTestSlider(ticks, min, max, 0).nearestTickValue(big.NewRat(int64(value*1000), 1000))
+	if !ok {
+		return 0, false
+	}
+	f, _ := v.Float64()
+	return f, true
+}
+
+func SliderNextTickValue(ticks []SliderTick, min, max float64, value float64, dir int) (float64, bool) {
+	v, ok := newTestSlider(ticks, min, max, 0).nextTickValue(big.NewRat(int64(value*1000), 1000), dir)
+	if !ok {
+		return 0, false
+	}
+	f, _ := v.Float64()
+	return f, true
+}
+
+// SliderNextValue returns the value moved by an arrow key, or by a page key if page is true.
+func SliderNextValue(ticks []SliderTick, snapToTicks bool, min, max, step float64, value float64, dir int, page bool) float64 {
+	s := newTestSlider(ticks, min, max, step)
+	s.SetSnapToTicks(snapToTicks)
+	f, _ := s.nextValue(big.NewRat(int64(value*1000), 1000), dir, page).Float64()
+	return f
+}
+
+// SetSliderUpperThumbValue sets the value of the upper thumb as if a user moves it.
+func SetSliderUpperThumbValue(slider *Slider, value int) {
+	slider.setThumbValue(sliderThumbUpper, big.NewRat(int64(value), 1))
+}
@@ -28,6 +28,10 @@ type UnicodeRange struct {
 type FaceSourceEntry struct {
 	FaceSource    *text.GoTextFaceSource
 	UnicodeRanges []UnicodeRange
+
+	// Monospace indicates that the face source is for monospace texts like code.
+	// A monospace entry is used only for monospace texts, prior to the other entries.
+	Monospace bool
 }
 
 var (
@@ -57,34 +61,51 @@ func init() {
 var (
 	tagLiga = text.MustParseTag("liga")
 	tagTnum = text.MustParseTag("tnum")
+	tagItal = text.MustParseTag("ital")
+	tagSlnt = text.MustParseTag("slnt")
 )
 
-func fontFace(size float64, weight text.Weight, liga bool, tnum bool, lang language.Tag) text.Face {
-	key := faceCacheKey{
-		size:   size,
-		weight: weight,
-		liga:   liga,
-		tnum:   tnum,
-		lang:   lang,
-	}
+func fontFace(key faceCacheKey) text.Face {
 	if f, ok := theFaceCache[key]; ok {
 		return f
 	}
 
+	entries := theFaceSourceEntries
+	if key.monospace {
+		// Put the monospace entries first. The other entries are fallbacks.
+		entries = slices.Clone(entries)
+		slices.SortStableFunc(entries, func(a, b FaceSourceEntry) int {
+			switch {
+			case a.Monospace && !b.Monospace:
+				return -1
+			case !a.Monospace && b.Monospace:
+				return 1
+			}
+			return 0
+		})
+	}
+
 	var fs []text.Face
-	for _, entry := range theFaceSourceEntries {
+	for _, entry := range entries {
+		if entry.Monospace && !key.monospace {
+			continue
+		}
 		gtf := &text.GoTextFace{
 			Source:   entry.FaceSource,
-			Size:     size,
-			Language: lang,
+			Size:     key.size,
+			Language: key.lang,
+		}
+		gtf.SetVariation(text.MustParseTag("wght"), float32(key.weight))
+		if key.italic {
+			gtf.SetVariation(tagItal, 1)
+			gtf.SetVariation(tagSlnt, -10)
 		}
-		gtf.SetVariation(text.MustParseTag("wght"), float32(weight))
-		if liga {
+		if key.liga {
 			gtf.SetFeature(tagLiga, 1)
 		} else {
 			gtf.SetFeature(tagLiga, 0)
 		}
-		if tnum {
+		if key.tnum || key.monospace {
 			gtf.SetFeature(tagTnum, 1)
 		} else {
 			gtf.SetFeature(tagTnum, 0)
@@ -130,6 +151,9 @@ func areFaceSourceEntriesEqual(a, b []FaceSourceEntry) bool {
 		if !slices.Equal(a[i].UnicodeRanges, b[i].UnicodeRanges) {
 			return false
 		}
+		if a[i].Monospace != b[i].Monospace {
+			return false
+		}
 	}
 	return true
 }
@@ -146,9 +170,11 @@ func SetFaceSources(entries []FaceSourceEntry) {
 }
 
 type faceCacheKey struct {
-	size   float64
-	weight text.Weight
-	liga   bool
-	tnum   bool
-	lang   language.Tag
+	size      float64
+	weight    text.Weight
+	liga      bool
+	tnum      bool
+	italic    bool
+	monospace bool
+	lang      language.Tag
 }
@@ -85,10 +85,10 @@ func (f *Form) appendItemBounds(itemBounds []image.Rectangle, contentBounds map[
 		var primaryS image.Point
 		var secondaryS image.Point
 		if item.PrimaryWidget != nil {
-			primaryS = item.PrimaryWidget.Measure(context, guigui.Constraints{})
+			primaryS = context.Measure(item.PrimaryWidget, guigui.Constraints{})
 		}
 		if item.SecondaryWidget != nil {
-			secondaryS = item.SecondaryWidget.Measure(context, guigui.Constraints{})
+			secondaryS = context.Measure(item.SecondaryWidget, guigui.Constraints{})
 		}
 		newLine := item.PrimaryWidget != nil && primaryS.X+secondaryS.X+2*paddingS.X > bounds.Dx()
 		var baseH int
@@ -112,10 +112,14 @@ func (f *Form) appendItemBounds(itemBounds []image.Rectangle, contentBounds map[
 			pY := min((baseH-primaryS.Y)/2, maxPaddingY)
 			bounds.Min.Y += pY
 			bounds.Max.Y += pY
-			contentBounds[item.PrimaryWidget] = image.Rectangle{
+			r := image.Rectangle{
 				Min: bounds.Min,
 				Max: bounds.Min.Add(primaryS),
 			}
+			if context.IsRightToLeft() {
+				r = guigui.MirrorRectangle(r, b)
+			}
+			contentBounds[item.PrimaryWidget] = r
 		}
 		if item.SecondaryWidget != nil {
 			bounds := b
@@ -129,10 +133,14 @@ func (f *Form) appendItemBounds(itemBounds []image.Rectangle, contentBounds map[
 				bounds.Min.Y += pY
 				bounds.Max.Y += pY
 			}
-			contentBounds[item.SecondaryWidget] = image.Rectangle{
+			r := image.Rectangle{
 				Min: bounds.Min,
 				Max: bounds.Min.Add(secondaryS),
 			}
+			if context.IsRightToLeft() {
+				r = guigui.MirrorRectangle(r, b)
+			}
+			contentBounds[item.SecondaryWidget] = r
 		}
 
 		y += baseH
@@ -178,10 +186,10 @@ func (f *Form) measureWithoutConstraints(context *guigui.Context) image.Point {
 		var primaryS image.Point
 		var secondaryS image.Point
 		if item.PrimaryWidget != nil {
-			primaryS = item.PrimaryWidget.Measure(context, guigui.Constraints{})
+			primaryS = context.Measure(item.PrimaryWidget, guigui.Constraints{})
 		}
 		if item.SecondaryWidget != nil {
-			secondaryS = item.SecondaryWidget.Measure(context, guigui.Constraints{})
+			secondaryS = context.Measure(item.SecondaryWidget, guigui.Constraints{})
 		}
 
 		s.X = max(s.X, primaryS.X+secondaryS.X+2*paddingS.X+gapX)
@@ -193,21 +201,19 @@ func (f *Form) measureWithoutConstraints(context *guigui.Context) image.Point {
 
 func (f *Form) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
 	s := f.measureWithoutConstraints(context)
-	w, ok := constraints.FixedWidth()
-	if !ok {
-		return s
-	}
-	if s.X <= w {
-		return image.Pt(w, s.Y)
+	w, ok := constraints.MaxWidth()
+	if !ok || s.X <= w {
+		return constraints.Constrain(s)
 	}
 
+	// The items don't fit in the width. Lay out the items with the width.
 	f.cachedItemBoundsForMeasure = slices.Delete(f.cachedItemBoundsForMeasure, 0, len(f.cachedItemBoundsForMeasure))
 	clear(f.cachedContentBoundsForMeasure)
 	f.cachedItemBoundsForMeasure, f.cachedContentBoundsForMeasure = f.appendItemBounds(f.cachedItemBoundsForMeasure, f.cachedContentBoundsForMeasure, context, w)
 	if len(f.cachedItemBoundsForMeasure) == 0 {
-		return image.Pt(w, 0)
+		return constraints.Constrain(image.Pt(w, 0))
 	}
-	return f.cachedItemBoundsForMeasure[len(f.cachedItemBoundsForMeasure)-1].Max.Sub(f.cachedItemBoundsForMeasure[0].Min)
+	return constraints.Constrain(f.cachedItemBoundsForMeasure[len(f.cachedItemBoundsForMeasure)-1].Max.Sub(f.cachedItemBoundsForMeasure[0].Min))
 }
 
 func minFormItemHeight(context *guigui.Context) int {
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+// IconTint represents a color to tint a registered icon.
+// The actual color follows the color mode.
+type IconTint int
+
+const (
+	// IconTintText tints an icon with the text color.
+	IconTintText IconTint = iota
+
+	// IconTintSecondary tints an icon with a dimmer color than the text color.
+	IconTintSecondary
+
+	// IconTintAccent tints an icon with the accent color.
+	IconTintAccent
+
+	// IconTintActive tints an icon with the color for an active item like a selected list item.
+	IconTintActive
+
+	// IconTintNone draws an icon in the original colors.
+	IconTintNone
+)
+
+func (i IconTint) color(colorMode guigui.ColorMode) color.Color {
+	switch i {
+	case IconTintText:
+		return draw.Color(colorMode, draw.ColorTypeBase, 0)
+	case IconTintSecondary:
+		return draw.Color2(colorMode, draw.ColorTypeBase, 0.5, 0.6)
+	case IconTintAccent:
+		return draw.Color(colorMode, draw.ColorTypeAccent, 0.5)
+	case IconTintActive:
+		return draw.Color2(colorMode, draw.ColorTypeBase, 1, 1)
+	}
+	return nil
+}
+
+type registeredIcon struct {
+	vectorIcon *VectorIcon
+
+	// images are the images at different resolutions, sorted by the size in ascending order.
+	images []iconImage
+}
+
+type iconImage struct {
+	original *ebiten.Image
+	mask     *ebiten.Image
+}
+
+func (i *iconImage) size() int {
+	return max(i.original.Bounds().Dx(), i.original.Bounds().Dy())
+}
+
+// image returns the image of the icon best for size in pixels.
+// If tinted is true, the image is white to be tinted.
+// The returned image might not be size x size.
+func (r *registeredIcon) image(size int, tinted bool) *ebiten.Image {
+	if r.vectorIcon != nil {
+		return r.vectorIcon.Image(size)
+	}
+	// Choose the smallest image not smaller than size, or the largest image.
+	idx := len(r.images) - 1
+	for i, img := range r.images {
+		if img.size() >= size {
+			idx = i
+			break
+		}
+	}
+	if tinted {
+		return r.images[idx].mask
+	}
+	return r.images[idx].original
+}
+
+type tintedIconKey struct {
+	name      string
+	colorMode guigui.ColorMode
+	tint      IconTint
+	size      int
+}
+
+var (
+	theIconRegistry    map[string]*registeredIcon
+	theTintedIconCache map[tintedIconKey]*ebiten.Image
+)
+
+func init() {
+	RegisterVectorIcon("check", vectorIconCheck)
+	RegisterVectorIcon("keyboard_arrow_down", vectorIconKeyboardArrowDown)
+	RegisterVectorIcon("keyboard_arrow_up", vectorIconKeyboardArrowUp)
+	RegisterVectorIcon("keyboard_arrow_right", vectorIconKeyboardArrowRight)
+	RegisterVectorIcon("keyboard_arrow_left", vectorIconKeyboardArrowLeft)
+	RegisterVectorIcon("calendar_today", vectorIconCalendarToday)
+	RegisterVectorIcon("colorize", vectorIconColorize)
+	RegisterVectorIcon("unfold_more", vectorIconUnfoldMore)
+	RegisterVectorIcon("drag_indicator", vectorIconDragIndicator)
+}
+
+func registerIcon(name string, icon *registeredIcon) {
+	if theIconRegistry == nil {
+		theIconRegistry = map[string]*registeredIcon{}
+	}
+	theIconRegistry[name] = icon
+	for key := range theTintedIconCache {
+		if key.name == name {
+			delete(theTintedIconCache, key)
+		}
+	}
+}
+
+// RegisterIcon registers an icon named name with the image files at paths in fsys.
+// The files are the same icon at different resolutions for high-DPI displays, e.g. "check_24.png" and "check_48.png".
+// The image best for the size to draw is chosen.
+// A registered icon replaces the icon with the same name, including the built-in icons.
+//
+// A tinted icon is drawn with the alpha channel of the image.
+func RegisterIcon(name string, fsys fs.FS, paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("basicwidget: no image files for the icon %q", name)
+	}
+	icon := &registeredIcon{}
+	for _, path := range paths {
+		img, err := decodeIconImage(fsys, path)
+		if err != nil {
+			return fmt.Errorf("basicwidget: failed to load the icon %q: %w", name, err)
+		}
+		icon.images = append(icon.images, iconImage{
+			original: ebiten.NewImageFromImage(img),
+			mask:     ebiten.NewImageFromImage(createMonochromeImage(color.White, img)),
+		})
+	}
+	slices.SortFunc(icon.images, func(a, b iconImage) int {
+		return a.size() - b.size()
+	})
+	registerIcon(name, icon)
+	return nil
+}
+
+func decodeIconImage(fsys fs.FS, path string) (image.Image, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// RegisterVectorIcon registers a vector icon named name.
+// A registered icon replaces the icon with the same name, including the built-in icons.
+func RegisterVectorIcon(name string, icon *VectorIcon) {
+	registerIcon(name, &registeredIcon{
+		vectorIcon: icon,
+	})
+}
+
+// IconNames returns the names of the registered icons in the sorted order.
+func IconNames() []string {
+	names := make([]string, 0, len(theIconRegistry))
+	for name := range theIconRegistry {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, strings.Compare)
+	return names
+}
+
+// IconImage returns the image of the registered icon named name, whose width and height are size in pixels.
+// The image is tinted with tint for colorMode.
+// IconImage returns false if the icon is not registered.
+func IconImage(name string, colorMode guigui.ColorMode, tint IconTint, size int) (*ebiten.Image, bool) {
+	icon, ok := theIconRegistry[name]
+	if !ok {
+		return nil, false
+	}
+	key := tintedIconKey{
+		name:      name,
+		colorMode: colorMode,
+		tint:      tint,
+		size:      size,
+	}
+	if img, ok := theTintedIconCache[key]; ok {
+		return img, true
+	}
+
+	src := icon.image(size, tint != IconTintNone)
+	img := ebiten.NewImage(max(size, 1), max(size, 1))
+	op := &ebiten.DrawImageOptions{}
+	s := float64(size) / float64(max(src.Bounds().Dx(), src.Bounds().Dy()))
+	op.GeoM.Scale(s, s)
+	op.GeoM.Translate((float64(size)-float64(src.Bounds().Dx())*s)/2, (float64(size)-float64(src.Bounds().Dy())*s)/2)
+	if clr := tint.color(colorMode); clr != nil {
+		op.ColorScale.ScaleWithColor(clr)
+	}
+	op.Filter = ebiten.FilterLinear
+	img.DrawImage(src, op)
+
+	if theTintedIconCache == nil {
+		theTintedIconCache = map[tintedIconKey]*ebiten.Image{}
+	}
+	theTintedIconCache[key] = img
+	return img, true
+}
@@ -4,46 +4,425 @@
 package basicwidget
 
 import (
+	"image"
+	"image/color"
+
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
 
 	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+// ImageFit represents how an image is fit into the bounds of an Image widget.
+type ImageFit int
+
+const (
+	// ImageFitContain scales an image to fit into the bounds, keeping the aspect ratio.
+	ImageFitContain ImageFit = iota
+
+	// ImageFitCover scales an image to cover the bounds, keeping the aspect ratio.
+	// The parts out of the bounds are clipped.
+	ImageFitCover
+
+	// ImageFitFill stretches an image to the bounds.
+	ImageFitFill
+
+	// ImageFitNone draws an image at the natural size at the center.
+	// The natural size is the image size multiplied by the context scale.
+	ImageFitNone
+
+	// ImageFitTile repeats an image at the natural size from the upper-left corner.
+	ImageFitTile
+
+	// ImageFitNinePatch stretches the edges and the center of an image, keeping the corners at the natural size.
+	// The corners are specified by SetNinePatchInsets.
+	ImageFitNinePatch
+)
+
+// NinePatchInsets represents the sizes of the corners of a nine-patch image in the image's pixels.
+type NinePatchInsets struct {
+	Left   int
+	Top    int
+	Right  int
+	Bottom int
+}
+
+const (
+	imageEventLoadError = "loadError"
 )
 
 type Image struct {
 	guigui.DefaultWidget
 
-	image *ebiten.Image
+	image           *ebiten.Image
+	vectorIcon      *VectorIcon
+	iconName        string
+	iconColor       color.Color
+	iconTint        IconTint
+	fit             ImageFit
+	ninePatchInsets NinePatchInsets
+
+	source       *ImageSource
+	load         *imageLoad
+	placeholder  *ebiten.Image
+	loadingCount int
+}
+
+// SetOnLoadError sets the handler called when loading an image source fails.
+func (i *Image) SetOnLoadError(f func(err error)) {
+	guigui.RegisterEventHandler(i, imageEventLoadError, f)
 }
 
 func (i *Image) Draw(context *guigui.Context, dst *ebiten.Image) {
-	if i.image == nil {
+	b := context.Bounds(i)
+	if b.Empty() {
 		return
 	}
 
-	b := context.Bounds(i)
-	imgScale := min(float64(b.Dx())/float64(i.image.Bounds().Dx()), float64(b.Dy())/float64(i.image.Bounds().Dy()))
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Scale(imgScale, imgScale)
-	op.GeoM.Translate(float64(b.Min.X), float64(b.Min.Y))
-	op.GeoM.Translate((float64(b.Dx())-float64(i.image.Bounds().Dx())*imgScale)/2,
-		(float64(b.Dy())-float64(i.image.Bounds().Dy())*imgScale)/2)
-	if !context.IsEnabled(i) {
-		// TODO: Reduce the saturation?
-		op.ColorScale.ScaleAlpha(0.25)
-	}
-	// TODO: Use a better filter.
+	if i.vectorIcon != nil || i.iconName != "" {
+		i.drawIcon(context, dst, b)
+		return
+	}
+
+	img := i.image
+	if img == nil && i.source != nil {
+		if i.placeholder != nil {
+			img = i.placeholder
+		} else if i.load != nil {
+			rate := float64(i.loadingCount%ebiten.TPS()) / float64(ebiten.TPS())
+			s := min(b.Dx(), b.Dy(), UnitSize(context))
+			sb := image.Rect(0, 0, s, s).Add(b.Min).Add(image.Pt((b.Dx()-s)/2, (b.Dy()-s)/2))
+			draw.DrawSpinner(context, dst, sb, activityIndicatorColor(context), rate)
+			return
+		}
+	}
+	if img == nil {
+		return
+	}
+
+	imgW, imgH := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+	if imgW == 0 || imgH == 0 {
+		return
+	}
+
+	var geoM ebiten.GeoM
+	switch i.fit {
+	case ImageFitContain, ImageFitCover:
+		var imgScale float64
+		if i.fit == ImageFitContain {
+			imgScale = min(float64(b.Dx())/imgW, float64(b.Dy())/imgH)
+		} else {
+			imgScale = max(float64(b.Dx())/imgW, float64(b.Dy())/imgH)
+			dst = dst.SubImage(b).(*ebiten.Image)
+		}
+		geoM.Scale(imgScale, imgScale)
+		geoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+		geoM.Translate((float64(b.Dx())-imgW*imgScale)/2, (float64(b.Dy())-imgH*imgScale)/2)
+		i.drawImage(context, dst, img, geoM, nil)
+	case ImageFitFill:
+		geoM.Scale(float64(b.Dx())/imgW, float64(b.Dy())/imgH)
+		geoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+		i.drawImage(context, dst, img, geoM, nil)
+	case ImageFitNone:
+		s := context.Scale()
+		geoM.Scale(s, s)
+		geoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+		geoM.Translate((float64(b.Dx())-imgW*s)/2, (float64(b.Dy())-imgH*s)/2)
+		i.drawImage(context, dst.SubImage(b).(*ebiten.Image), img, geoM, nil)
+	case ImageFitTile:
+		s := context.Scale()
+		w, h := imgW*s, imgH*s
+		if w < 1 || h < 1 {
+			return
+		}
+		dst := dst.SubImage(b).(*ebiten.Image)
+		for y := float64(b.Min.Y); y < float64(b.Max.Y); y += h {
+			for x := float64(b.Min.X); x < float64(b.Max.X); x += w {
+				geoM.Reset()
+				geoM.Scale(s, s)
+				geoM.Translate(x, y)
+				i.drawImage(context, dst, img, geoM, nil)
+			}
+		}
+	case ImageFitNinePatch:
+		i.drawNinePatch(context, dst, b, img)
+	}
+}
+
+func (i *Image) drawIcon(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle) {
+	s := min(bounds.Dx(), bounds.Dy())
+	clr := i.iconColor
+	if clr == nil {
+		clr = i.iconTint.color(context.ColorMode())
+	}
+
+	var img *ebiten.Image
+	var mirrored bool
+	if i.vectorIcon != nil {
+		img = i.vectorIcon.Image(s)
+		mirrored = i.vectorIcon.mirroredInRightToLeft
+	} else if icon, ok := theIconRegistry[i.iconName]; ok {
+		img = icon.image(s, clr != nil)
+		mirrored = icon.vectorIcon != nil && icon.vectorIcon.mirroredInRightToLeft
+	}
+	if img == nil {
+		return
+	}
+
+	imgW, imgH := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+	imgScale := float64(s) / max(imgW, imgH)
+	var geoM ebiten.GeoM
+	if mirrored && context.IsRightToLeft() {
+		geoM.Scale(-1, 1)
+		geoM.Translate(imgW, 0)
+	}
+	geoM.Scale(imgScale, imgScale)
+	geoM.Translate(float64(bounds.Min.X)+(float64(bounds.Dx())-imgW*imgScale)/2, float64(bounds.Min.Y)+(float64(bounds.Dy())-imgH*imgScale)/2)
+	i.drawImage(context, dst, img, geoM, clr)
+}
+
+func (i *Image) drawNinePatch(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, img *ebiten.Image) {
+	ib := img.Bounds()
+	in := i.ninePatchInsets
+
+	// The corners are shrunk when the bounds are too small.
+	s := context.Scale()
+	if w := in.Left + in.Right; w > 0 {
+		s = min(s, float64(bounds.Dx())/float64(w))
+	}
+	if h := in.Top + in.Bottom; h > 0 {
+		s = min(s, float64(bounds.Dy())/float64(h))
+	}
+
+	srcXs := [...]int{ib.Min.X, ib.Min.X + in.Left, ib.Max.X - in.Right, ib.Max.X}
+	srcYs := [...]int{ib.Min.Y, ib.Min.Y + in.Top, ib.Max.Y - in.Bottom, ib.Max.Y}
+	dstXs := [...]float64{float64(bounds.Min.X), float64(bounds.Min.X) + float64(in.Left)*s, float64(bounds.Max.X) - float64(in.Right)*s, float64(bounds.Max.X)}
+	dstYs := [...]float64{float64(bounds.Min.Y), float64(bounds.Min.Y) + float64(in.Top)*s, float64(bounds.Max.Y) - float64(in.Bottom)*s, float64(bounds.Max.Y)}
+	for j := range 3 {
+		for k := range 3 {
+			sw, sh := srcXs[k+1]-srcXs[k], srcYs[j+1]-srcYs[j]
+			dw, dh := dstXs[k+1]-dstXs[k], dstYs[j+1]-dstYs[j]
+			if sw <= 0 || sh <= 0 || dw <= 0 || dh <= 0 {
+				continue
+			}
+			src := img.SubImage(image.Rect(srcXs[k], srcYs[j], srcXs[k+1], srcYs[j+1])).(*ebiten.Image)
+			var geoM ebiten.GeoM
+			geoM.Scale(dw/float64(sw), dh/float64(sh))
+			geoM.Translate(dstXs[k], dstYs[j])
+			i.drawImage(context, dst, src, geoM, nil)
+		}
+	}
+}
+
+// drawImage draws src with geoM. If clr is not nil, src is tinted with clr.
+// A disabled image is desaturated and translucent.
+func (i *Image) drawImage(context *guigui.Context, dst, src *ebiten.Image, geoM ebiten.GeoM, clr color.Color) {
+	// FilterLinear uses mipmaps when the image is shrunk, which keeps the quality.
+	if context.IsEnabled(i) {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM = geoM
+		if clr != nil {
+			op.ColorScale.ScaleWithColor(clr)
+		}
+		op.Filter = ebiten.FilterLinear
+		dst.DrawImage(src, op)
+		return
+	}
+
+	var cm colorm.ColorM
+	if clr != nil {
+		cm.ScaleWithColor(clr)
+	}
+	cm.ChangeHSV(0, 0, 1)
+	cm.Scale(1, 1, 1, 0.5)
+	op := &colorm.DrawImageOptions{}
+	op.GeoM = geoM
 	op.Filter = ebiten.FilterLinear
-	dst.DrawImage(i.image, op)
+	colorm.DrawImage(dst, src, cm, op)
 }
 
 func (i *Image) HasImage() bool {
-	return i.image != nil
+	return i.image != nil || i.vectorIcon != nil || i.iconName != "" || i.source != nil
+}
+
+// resetImage clears the image and the icon set by any setter.
+func (i *Image) resetImage() {
+	i.image = nil
+	i.vectorIcon = nil
+	i.iconName = ""
+	i.source = nil
+	i.load = nil
 }
 
 func (i *Image) SetImage(image *ebiten.Image) {
-	if i.image == image {
+	if i.image == image && i.vectorIcon == nil && i.iconName == "" && i.source == nil {
 		return
 	}
+	i.resetImage()
 	i.image = image
 	guigui.RequestRedraw(i)
 }
+
+// SetImageSource sets an image loaded asynchronously.
+// While the image is being loaded, the placeholder or a spinner is shown.
+// The image is not loaded again if source has the same key as the current source.
+func (i *Image) SetImageSource(source *ImageSource) {
+	if i.source == source {
+		return
+	}
+	if i.source.isSameCachedImage(source) {
+		i.source = source
+		return
+	}
+	i.resetImage()
+	i.source = source
+	if source != nil {
+		i.load = loadImage(source)
+		i.resolveLoad()
+	}
+	guigui.RequestRedraw(i)
+}
+
+// SetPlaceholder sets an image shown while an image source is being loaded or failed to be loaded.
+func (i *Image) SetPlaceholder(placeholder *ebiten.Image) {
+	if i.placeholder == placeholder {
+		return
+	}
+	i.placeholder = placeholder
+	if i.source != nil && i.image == nil {
+		guigui.RequestRedraw(i)
+	}
+}
+
+// IsLoading reports whether an image source is being loaded.
+func (i *Image) IsLoading() bool {
+	return i.load != nil
+}
+
+// resolveLoad takes the loaded image if the image source has been loaded, and reports whether it has.
+func (i *Image) resolveLoad() bool {
+	img, done, err := i.load.result()
+	if !done {
+		return false
+	}
+	if err != nil {
+		forgetImageLoad(i.source, i.load)
+		i.load = nil
+		guigui.RequestRedraw(i)
+		guigui.DispatchEventHandler(i, imageEventLoadError, err)
+		return true
+	}
+	i.image = img
+	i.load = nil
+	guigui.RequestRedraw(i)
+	return true
+}
+
+func (i *Image) Tick(context *guigui.Context) error {
+	if i.load == nil {
+		return nil
+	}
+	if i.resolveLoad() {
+		return nil
+	}
+	if i.placeholder != nil || !isOnScreen(context, i) {
+		return nil
+	}
+	i.loadingCount++
+	guigui.RequestRedraw(i)
+	return nil
+}
+
+// SetVectorIcon sets a resolution-independent icon instead of an image.
+// The icon is rendered at the size of the bounds so that it is crisp at any scale.
+func (i *Image) SetVectorIcon(icon *VectorIcon) {
+	if i.vectorIcon == icon && i.image == nil && i.iconName == "" && i.source == nil {
+		return
+	}
+	i.resetImage()
+	i.vectorIcon = icon
+	guigui.RequestRedraw(i)
+}
+
+// SetIconName sets the icon registered by RegisterIcon or RegisterVectorIcon.
+// The icon is tinted following the color mode.
+func (i *Image) SetIconName(name string) {
+	if i.iconName == name && i.image == nil && i.vectorIcon == nil && i.source == nil {
+		return
+	}
+	i.resetImage()
+	i.iconName = name
+	guigui.RequestRedraw(i)
+}
+
+// SetIconTint sets the tint of an icon. The default tint is IconTintText.
+// The tint is ignored if an icon color is set by SetIconColor.
+func (i *Image) SetIconTint(tint IconTint) {
+	if i.iconTint == tint {
+		return
+	}
+	i.iconTint = tint
+	guigui.RequestRedraw(i)
+}
+
+// SetIconColor sets the color of an icon.
+// If clr is nil, the color of the icon tint is used.
+func (i *Image) SetIconColor(clr color.Color) {
+	if draw.EqualColor(i.iconColor, clr) {
+		return
+	}
+	i.iconColor = clr
+	guigui.RequestRedraw(i)
+}
+
+func (i *Image) SetFit(fit ImageFit) {
+	if i.fit == fit {
+		return
+	}
+	i.fit = fit
+	guigui.RequestRedraw(i)
+}
+
+// SetNinePatchInsets sets the corners of a nine-patch image for ImageFitNinePatch.
+func (i *Image) SetNinePatchInsets(insets NinePatchInsets) {
+	if i.ninePatchInsets == insets {
+		return
+	}
+	i.ninePatchInsets = insets
+	guigui.RequestRedraw(i)
+}
+
+// CreateMonochromeImage creates an image in the text color of colorMode with the alpha channel of img.
+//
+// Deprecated: Use RegisterIcon and SetIconName instead, which follow the color mode automatically.
+func CreateMonochromeImage(colorMode guigui.ColorMode, img image.Image) image.Image {
+	return createMonochromeImage(draw.Color(colorMode, draw.ColorTypeBase, 0), img)
+}
+
+// createMonochromeImage creates an image in clr with the alpha channel of img.
+func createMonochromeImage(clr color.Color, img image.Image) image.Image {
+	r, g, b, _ := clr.RGBA()
+
+	bounds := img.Bounds()
+	pix := make([]byte, 4*bounds.Dx()*bounds.Dy())
+	for j := range bounds.Dy() {
+		for i := range bounds.Dx() {
+			_, _, _, a := img.At(i, j).RGBA()
+			if a == 0 {
+				continue
+			}
+
+			pix[4*(j*bounds.Dx()+i)] = byte((r * a / 0xFFFF) >> 8)
+			pix[4*(j*bounds.Dx()+i)+1] = byte((g * a / 0xFFFF) >> 8)
+			pix[4*(j*bounds.Dx()+i)+2] = byte((b * a / 0xFFFF) >> 8)
+			pix[4*(j*bounds.Dx()+i)+3] = uint8(a >> 8)
+		}
+	}
+
+	return &image.RGBA{
+		Pix:    pix,
+		Stride: 4 * bounds.Dx(),
+		Rect:   bounds,
+	}
+}
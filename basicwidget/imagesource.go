@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"container/list"
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"reflect"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	xdraw "golang.org/x/image/draw"
+)
+
+// ImageSource represents an image loaded asynchronously by Image.SetImageSource.
+type ImageSource struct {
+	// Key identifies the image in the cache shared by Image widgets.
+	// Key must be comparable. If Key is nil, the image is not cached.
+	Key any
+
+	// MaxSize is the maximum size of the image in pixels.
+	// A larger image is downscaled on the worker goroutine, keeping the aspect ratio.
+	// If MaxSize is zero, the image is not downscaled.
+	MaxSize image.Point
+
+	// Load loads an image. Load is called on a worker goroutine.
+	Load func() (image.Image, error)
+}
+
+// NewImageSourceFromReader creates an image source decoding r.
+// PNG and JPEG are supported in addition to the formats registered by image.RegisterFormat.
+func NewImageSourceFromReader(r io.Reader) *ImageSource {
+	return &ImageSource{
+		Load: func() (image.Image, error) {
+			img, _, err := image.Decode(r)
+			return img, err
+		},
+	}
+}
+
+type imageSourceFSKey struct {
+	fsys fs.FS
+	name string
+}
+
+// NewImageSourceFromFS creates an image source decoding the file name in fsys.
+// The image is cached if fsys is comparable.
+func NewImageSourceFromFS(fsys fs.FS, name string) *ImageSource {
+	s := &ImageSource{
+		Load: func() (image.Image, error) {
+			f, err := fsys.Open(name)
+			if err != nil {
+				return nil, err
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			img, _, err := image.Decode(f)
+			return img, err
+		},
+	}
+	if reflect.ValueOf(fsys).Comparable() {
+		s.Key = imageSourceFSKey{
+			fsys: fsys,
+			name: name,
+		}
+	}
+	return s
+}
+
+func (s *ImageSource) isSameCachedImage(other *ImageSource) bool {
+	if s == nil || other == nil || s.Key == nil {
+		return false
+	}
+	return s.Key == other.Key && s.MaxSize == other.MaxSize
+}
+
+// downscaledImageSize returns the size to fit size into maxSize, keeping the aspect ratio.
+// The size is not changed if size already fits into maxSize or maxSize is zero.
+func downscaledImageSize(size image.Point, maxSize image.Point) image.Point {
+	if maxSize.X <= 0 && maxSize.Y <= 0 {
+		return size
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return size
+	}
+	w, h := size.X, size.Y
+	if maxSize.X > 0 && w > maxSize.X {
+		h = max(h*maxSize.X/w, 1)
+		w = maxSize.X
+	}
+	if maxSize.Y > 0 && h > maxSize.Y {
+		w = max(w*maxSize.Y/h, 1)
+		h = maxSize.Y
+	}
+	return image.Pt(w, h)
+}
+
+func downscaleImage(img image.Image, maxSize image.Point) image.Image {
+	size := downscaledImageSize(img.Bounds().Size(), maxSize)
+	if size == img.Bounds().Size() {
+		return img
+	}
+	dst := image.NewRGBA(image.Rectangle{Max: size})
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	return dst
+}
+
+// imageLoad is a loading image, which might be shared by multiple Image widgets.
+type imageLoad struct {
+	m    sync.Mutex
+	done bool
+	img  image.Image
+	err  error
+
+	// image is created from img on the UI thread.
+	image *ebiten.Image
+}
+
+func newImageLoad(source *ImageSource) *imageLoad {
+	l := &imageLoad{}
+	go func() {
+		img, err := source.Load()
+		if err == nil && img == nil {
+			err = errors.New("basicwidget: ImageSource.Load returned no image")
+		}
+		if err == nil {
+			img = downscaleImage(img, source.MaxSize)
+		}
+		l.m.Lock()
+		defer l.m.Unlock()
+		l.done = true
+		l.img = img
+		l.err = err
+	}()
+	return l
+}
+
+// result returns the loaded image. done is false while the image is being loaded.
+// result must be called on the UI thread.
+func (l *imageLoad) result() (img *ebiten.Image, done bool, err error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if !l.done {
+		return nil, false, nil
+	}
+	if l.err != nil {
+		return nil, true, l.err
+	}
+	if l.image == nil {
+		l.image = ebiten.NewImageFromImage(l.img)
+		l.img = nil
+	}
+	return l.image, true, nil
+}
+
+type imageCacheKey struct {
+	key     any
+	maxSize image.Point
+}
+
+// lruCache is a cache evicting the least recently used entries beyond the capacity.
+type lruCache[K comparable, V any] struct {
+	capacity int
+	elements map[K]*list.Element
+	entries  list.List
+}
+
+type lruCacheEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func (c *lruCache[K, V]) get(key K) (V, bool) {
+	e, ok := c.elements[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.entries.MoveToFront(e)
+	return e.Value.(*lruCacheEntry[K, V]).value, true
+}
+
+func (c *lruCache[K, V]) add(key K, value V) {
+	if e, ok := c.elements[key]; ok {
+		e.Value.(*lruCacheEntry[K, V]).value = value
+		c.entries.MoveToFront(e)
+		return
+	}
+	if c.elements == nil {
+		c.elements = map[K]*list.Element{}
+	}
+	c.elements[key] = c.entries.PushFront(&lruCacheEntry[K, V]{
+		key:   key,
+		value: value,
+	})
+	c.evict()
+}
+
+func (c *lruCache[K, V]) remove(key K) {
+	e, ok := c.elements[key]
+	if !ok {
+		return
+	}
+	c.entries.Remove(e)
+	delete(c.elements, key)
+}
+
+func (c *lruCache[K, V]) setCapacity(capacity int) {
+	c.capacity = capacity
+	c.evict()
+}
+
+func (c *lruCache[K, V]) evict() {
+	for c.entries.Len() > max(c.capacity, 0) {
+		e := c.entries.Back()
+		c.entries.Remove(e)
+		delete(c.elements, e.Value.(*lruCacheEntry[K, V]).key)
+	}
+}
+
+const defaultImageCacheCapacity = 64
+
+var theImageCache = lruCache[imageCacheKey, *imageLoad]{
+	capacity: defaultImageCacheCapacity,
+}
+
+// SetImageCacheCapacity sets the maximum number of images cached for ImageSource.
+// The default capacity is 64.
+func SetImageCacheCapacity(capacity int) {
+	theImageCache.setCapacity(capacity)
+}
+
+// loadImage starts loading an image, or returns the loading or loaded image in the cache.
+func loadImage(source *ImageSource) *imageLoad {
+	if source.Key == nil {
+		return newImageLoad(source)
+	}
+	key := imageCacheKey{
+		key:     source.Key,
+		maxSize: source.MaxSize,
+	}
+	if l, ok := theImageCache.get(key); ok {
+		return l
+	}
+	l := newImageLoad(source)
+	theImageCache.add(key, l)
+	return l
+}
+
+// forgetImageLoad removes a failed load from the cache so that the image can be loaded again.
+func forgetImageLoad(source *ImageSource, load *imageLoad) {
+	if source.Key == nil {
+		return
+	}
+	key := imageCacheKey{
+		key:     source.Key,
+		maxSize: source.MaxSize,
+	}
+	if l, ok := theImageCache.get(key); ok && l == load {
+		theImageCache.remove(key)
+	}
+}
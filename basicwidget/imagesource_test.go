@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestDownscaledImageSize(t *testing.T) {
+	testCases := []struct {
+		size    image.Point
+		maxSize image.Point
+		want    image.Point
+	}{
+		{size: image.Pt(400, 300), maxSize: image.Pt(0, 0), want: image.Pt(400, 300)},
+		{size: image.Pt(400, 300), maxSize: image.Pt(800, 800), want: image.Pt(400, 300)},
+		{size: image.Pt(400, 300), maxSize: image.Pt(200, 200), want: image.Pt(200, 150)},
+		{size: image.Pt(300, 400), maxSize: image.Pt(200, 200), want: image.Pt(150, 200)},
+		{size: image.Pt(400, 300), maxSize: image.Pt(100, 0), want: image.Pt(100, 75)},
+		{size: image.Pt(400, 300), maxSize: image.Pt(0, 30), want: image.Pt(40, 30)},
+		{size: image.Pt(1000, 1), maxSize: image.Pt(10, 10), want: image.Pt(10, 1)},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.DownscaledImageSize(tc.size, tc.maxSize); got != tc.want {
+			t.Errorf("DownscaledImageSize(%v, %v): got: %v, want: %v", tc.size, tc.maxSize, got, tc.want)
+		}
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	testCases := []struct {
+		capacity int
+		keys     []string
+		getKeys  []string
+		want     []string
+	}{
+		{capacity: 3, keys: []string{"a", "b"}, want: []string{"b", "a"}},
+		{capacity: 3, keys: []string{"a", "b", "c", "d"}, want: []string{"d", "c", "b"}},
+		{capacity: 3, keys: []string{"a", "b", "c"}, getKeys: []string{"a"}, want: []string{"a", "c", "b"}},
+		{capacity: 2, keys: []string{"a", "b", "a", "c"}, want: []string{"c", "a"}},
+		{capacity: 0, keys: []string{"a"}, want: nil},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.LRUCacheKeys(tc.capacity, tc.keys, tc.getKeys); !slices.Equal(got, tc.want) {
+			t.Errorf("LRUCacheKeys(%d, %q, %q): got: %q, want: %q", tc.capacity, tc.keys, tc.getKeys, got, tc.want)
+		}
+	}
+}
@@ -586,3 +586,34 @@ func OverlapsWithRoundedCorner(bounds image.Rectangle, radius int, srcBounds ima
 	}
 	return srcBounds.Overlaps(b1) || srcBounds.Overlaps(b2) || srcBounds.Overlaps(b3) || srcBounds.Overlaps(b4)
 }
+
+// DrawArc strokes an arc from startAngle to endAngle clockwise with round caps.
+func DrawArc(dst *ebiten.Image, cx, cy, radius, startAngle, endAngle, strokeWidth float32, clr color.Color) {
+	var path vector.Path
+	path.MoveTo(cx+radius*float32(math.Cos(float64(startAngle))), cy+radius*float32(math.Sin(float64(startAngle))))
+	path.Arc(cx, cy, radius, startAngle, endAngle, vector.Clockwise)
+	strokeOp := &vector.StrokeOptions{}
+	strokeOp.Width = strokeWidth
+	strokeOp.LineCap = vector.LineCapRound
+	drawOp := &vector.DrawPathOptions{}
+	drawOp.AntiAlias = true
+	drawOp.ColorScale.ScaleWithColor(clr)
+	vector.StrokePath(dst, &path, strokeOp, drawOp)
+}
+
+// DrawSpinner draws a rotating arc at the center of bounds.
+// rate is the phase of the rotation in [0, 1).
+func DrawSpinner(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, clr color.Color, rate float64) {
+	if !dst.Bounds().Overlaps(bounds) {
+		return
+	}
+	width := float32(2 * context.Scale())
+	r := float32(min(bounds.Dx(), bounds.Dy()))/2 - width
+	if r <= 0 {
+		return
+	}
+	cx := float32(bounds.Min.X+bounds.Max.X) / 2
+	cy := float32(bounds.Min.Y+bounds.Max.Y) / 2
+	start := float32(2 * math.Pi * rate)
+	DrawArc(dst, cx, cy, r, start, start+1.5*math.Pi, width, clr)
+}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package textutil
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+// visualRun is a range of a line with a single direction.
+type visualRun struct {
+	// start and end are the positions in the line in bytes.
+	start int
+	end   int
+	rtl   bool
+
+	// level is the embedding level of the run by the Unicode Bidirectional Algorithm.
+	level int
+
+	// x is the left position of the run from the line's left.
+	x     float64
+	width float64
+}
+
+func hasRightToLeft(str string) bool {
+	for _, r := range str {
+		// Fast path for ASCII characters.
+		if r < 0x0590 {
+			continue
+		}
+		p, _ := bidi.LookupRune(r)
+		switch p.Class() {
+		case bidi.R, bidi.AL, bidi.AN:
+			return true
+		}
+	}
+	return false
+}
+
+// firstStrongDirection returns the direction of the first strong character in str.
+// ok is false if str has no strong characters.
+func firstStrongDirection(str string) (rtl bool, ok bool) {
+	for _, r := range str {
+		p, _ := bidi.LookupRune(r)
+		switch p.Class() {
+		case bidi.L:
+			return false, true
+		case bidi.R, bidi.AL:
+			return true, true
+		case bidi.B:
+			return false, false
+		}
+	}
+	return false, false
+}
+
+// IsRightToLeftParagraph reports whether the paragraph including the given index in str is right-to-left.
+// The direction is determined by the first strong character of the paragraph (the rule P2 and P3 of the Unicode Bidirectional Algorithm).
+// If the paragraph has no strong characters, defaultRTL is returned.
+func IsRightToLeftParagraph(str string, index int, defaultRTL bool) bool {
+	index = min(max(index, 0), len(str))
+	start := index
+	for start > 0 {
+		r, s := utf8.DecodeLastRuneInString(str[:start])
+		if p, _ := bidi.LookupRune(r); p.Class() == bidi.B {
+			break
+		}
+		start -= s
+	}
+	if rtl, ok := firstStrongDirection(str[start:]); ok {
+		return rtl
+	}
+	return defaultRTL
+}
+
+var (
+	theBidiParagraph bidi.Paragraph
+	theBidiRunes     []bidiRune
+)
+
+// bidiRune is a rune with its bidirectional class and its embedding level.
+type bidiRune struct {
+	// pos is the position in the line in bytes.
+	pos   int
+	class bidi.Class
+	level int
+}
+
+// appendVisualRuns appends the runs of line in the visual order, from the left to the right.
+// line must not include a line break.
+//
+// The runs are reordered by their embedding levels (the rule L2 of the Unicode Bidirectional Algorithm).
+// golang.org/x/text/unicode/bidi resolves only the directions of the runs, so the levels are restored from the directions:
+// in a right-to-left paragraph, the levels are 1 and 2, and in a left-to-right paragraph,
+// the numbers after a right-to-left character are at the level 2 and the other left-to-right characters are at the level 0.
+// Explicit embeddings and isolates are not considered for the levels.
+func appendVisualRuns(runs []visualRun, line string, rtl bool) []visualRun {
+	origLen := len(runs)
+	if !hasRightToLeft(line) {
+		return append(runs, visualRun{
+			start: 0,
+			end:   len(line),
+			rtl:   rtl && !hasLeftToRight(line),
+		})
+	}
+
+	dir := bidi.LeftToRight
+	if rtl {
+		dir = bidi.RightToLeft
+	}
+	if _, err := theBidiParagraph.SetString(line, bidi.DefaultDirection(dir)); err != nil {
+		return append(runs, visualRun{
+			start: 0,
+			end:   len(line),
+			rtl:   rtl,
+		})
+	}
+	o, err := theBidiParagraph.Order()
+	if err != nil || o.NumRuns() == 0 {
+		return append(runs, visualRun{
+			start: 0,
+			end:   len(line),
+			rtl:   rtl,
+		})
+	}
+
+	// Resolve the level of each rune.
+	theBidiRunes = slices.Delete(theBidiRunes, 0, len(theBidiRunes))
+	for pos, r := range line {
+		p, _ := bidi.LookupRune(r)
+		theBidiRunes = append(theBidiRunes, bidiRune{
+			pos:   pos,
+			class: p.Class(),
+		})
+	}
+	var lastStrongRTL bool
+	for i := range o.NumRuns() {
+		run := o.Run(i)
+		runeStart, runeEnd := run.Pos()
+		runeEnd = min(runeEnd+1, len(theBidiRunes))
+		runRTL := run.Direction() == bidi.RightToLeft
+		switch {
+		case rtl && runRTL:
+			setBidiLevels(theBidiRunes[runeStart:runeEnd], 1)
+		case rtl && !runRTL:
+			setBidiLevels(theBidiRunes[runeStart:runeEnd], 2)
+		case !rtl && runRTL:
+			setBidiLevels(theBidiRunes[runeStart:runeEnd], 1)
+		default:
+			resolveLeftToRightLevels(theBidiRunes[runeStart:runeEnd], lastStrongRTL)
+		}
+		for _, r := range theBidiRunes[runeStart:runeEnd] {
+			switch r.class {
+			case bidi.L:
+				lastStrongRTL = false
+			case bidi.R, bidi.AL:
+				lastStrongRTL = true
+			}
+		}
+	}
+
+	// Split the line into the runs with the same levels.
+	var levelMin, levelMax int
+	for i, r := range theBidiRunes {
+		if i == 0 || theBidiRunes[i-1].level != r.level {
+			if len(runs) > origLen {
+				runs[len(runs)-1].end = r.pos
+			}
+			runs = append(runs, visualRun{
+				start: r.pos,
+				level: r.level,
+				rtl:   r.level%2 == 1,
+			})
+		}
+		if i == 0 {
+			levelMin, levelMax = r.level, r.level
+		}
+		levelMin = min(levelMin, r.level)
+		levelMax = max(levelMax, r.level)
+	}
+	runs[len(runs)-1].end = len(line)
+
+	// From the highest level to the lowest odd level, reverse any contiguous runs at that level or higher (the rule L2).
+	if levelMin%2 == 0 {
+		levelMin++
+	}
+	lineRuns := runs[origLen:]
+	for level := levelMax; level >= levelMin; level-- {
+		for i := 0; i < len(lineRuns); {
+			if lineRuns[i].level < level {
+				i++
+				continue
+			}
+			j := i + 1
+			for j < len(lineRuns) && lineRuns[j].level >= level {
+				j++
+			}
+			slices.Reverse(lineRuns[i:j])
+			i = j
+		}
+	}
+	return runs
+}
+
+func setBidiLevels(runes []bidiRune, level int) {
+	for i := range runes {
+		runes[i].level = level
+	}
+}
+
+// resolveLeftToRightLevels resolves the levels of runes resolved as left-to-right in a left-to-right paragraph.
+//
+// The numbers after a right-to-left character are at the level 2 (the rule W2, W7 and I1).
+// The separators and the terminators adjacent to the numbers are also at the level 2 (the rule W4 and W5).
+// The other characters are at the level 0.
+func resolveLeftToRightLevels(runes []bidiRune, lastStrongRTL bool) {
+	isNumber := func(i int) bool {
+		if i < 0 || i >= len(runes) {
+			return false
+		}
+		return (runes[i].class == bidi.EN || runes[i].class == bidi.AN) && runes[i].level == 2
+	}
+
+	for i := range runes {
+		r := &runes[i]
+		r.level = 0
+		switch r.class {
+		case bidi.L:
+			lastStrongRTL = false
+		case bidi.R, bidi.AL:
+			lastStrongRTL = true
+		case bidi.EN, bidi.AN:
+			if lastStrongRTL {
+				r.level = 2
+			}
+		}
+	}
+
+	for i := 0; i < len(runes); {
+		j := i
+		var separatorCount int
+	loop:
+		for ; j < len(runes); j++ {
+			switch runes[j].class {
+			case bidi.ET, bidi.NSM, bidi.BN:
+			case bidi.ES, bidi.CS:
+				separatorCount++
+			default:
+				break loop
+			}
+		}
+		if i == j {
+			i++
+			continue
+		}
+		prev, next := isNumber(i-1), isNumber(j)
+		if (separatorCount == 0 && (prev || next)) || (separatorCount == 1 && prev && next) {
+			setBidiLevels(runes[i:j], 2)
+		}
+		i = j
+	}
+}
+
+// hasLeftToRight reports whether str has characters rendered left-to-right even in a right-to-left paragraph.
+func hasLeftToRight(str string) bool {
+	for _, r := range str {
+		p, _ := bidi.LookupRune(r)
+		switch p.Class() {
+		case bidi.L, bidi.EN:
+			return true
+		}
+	}
+	return false
+}
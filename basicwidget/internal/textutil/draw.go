@@ -21,6 +21,15 @@ type DrawOptions struct {
 
 	TextColor color.Color
 
+	// DrawCurrentLine draws the background of the logical line including CurrentLineIndex in the full width.
+	DrawCurrentLine  bool
+	CurrentLineIndex int
+	CurrentLineColor color.Color
+
+	// Highlights are ranges drawn behind the text like the selection, e.g. search results.
+	// Highlights must be sorted by their positions.
+	Highlights []Highlight
+
 	DrawSelection  bool
 	SelectionStart int
 	SelectionEnd   int
@@ -36,6 +45,13 @@ type DrawOptions struct {
 	CompositionBorderWidth   float32
 }
 
+// Highlight represents a highlighted range of a text in bytes.
+type Highlight struct {
+	Start int
+	End   int
+	Color color.Color
+}
+
 var theCachedLines []line
 
 func Draw(bounds image.Rectangle, dst *ebiten.Image, str string, options *DrawOptions) {
@@ -53,116 +69,189 @@ func Draw(bounds image.Rectangle, dst *ebiten.Image, str string, options *DrawOp
 	op.GeoM.Translate(0, yOffset)
 
 	theCachedLines = theCachedLines[:0]
-	for line := range lines(bounds.Dx(), str, options.AutoWrap, func(str string) float64 {
-		return advance(str, options.Face, options.TabWidth, options.KeepTailingSpace)
-	}) {
+	for line := range linesWithOptions(bounds.Dx(), str, &options.Options) {
 		theCachedLines = append(theCachedLines, line)
 	}
 
+	var currentLineStart, currentLineEnd int
+	if options.DrawCurrentLine {
+		currentLineStart = strings.LastIndex(str[:options.CurrentLineIndex], "\n") + 1
+		currentLineEnd = len(str)
+		if i := strings.Index(str[options.CurrentLineIndex:], "\n"); i >= 0 {
+			currentLineEnd = options.CurrentLineIndex + i
+		}
+	}
+
 	for _, line := range theCachedLines {
-		y := op.GeoM.Element(1, 2)
-		if int(math.Ceil(y+options.LineHeight)) < bounds.Min.Y {
+		box := newLineBox(line, &options.Options)
+		lineTop := op.GeoM.Element(1, 2)
+		if int(math.Ceil(lineTop+box.height)) < bounds.Min.Y {
+			op.GeoM.Translate(0, box.height)
 			continue
 		}
-		if int(math.Floor(y)) >= bounds.Max.Y {
+		if int(math.Floor(lineTop)) >= bounds.Max.Y {
 			break
 		}
 
 		start := line.pos
 		end := line.pos + len(line.str) - tailingLineBreakLen(line.str)
+		ll := newLineLayout(bounds.Dx(), str, line, &options.Options)
+		y := lineTop + box.padding
+		height := float32(box.height - 2*box.padding)
+
+		if options.DrawCurrentLine && start >= currentLineStart && start <= currentLineEnd {
+			x := float32(bounds.Min.X)
+			vector.DrawFilledRect(dst, x, float32(lineTop), float32(bounds.Dx()), float32(box.height), options.CurrentLineColor, false)
+		}
+
+		// Skip the highlights before the line.
+		hIdx, _ := slices.BinarySearchFunc(options.Highlights, start, func(h Highlight, pos int) int {
+			return h.End - pos
+		})
+		for _, h := range options.Highlights[hIdx:] {
+			if h.Start > end {
+				break
+			}
+			for _, r := range ll.ranges(h.Start, h.End, &options.Options) {
+				x := float32(r.x0) + float32(bounds.Min.X)
+				vector.DrawFilledRect(dst, x, float32(y), float32(r.x1-r.x0), height, h.Color, false)
+			}
+		}
 
 		if options.DrawSelection {
 			if start <= options.SelectionEnd && end >= options.SelectionStart {
-				start := max(start, options.SelectionStart)
-				end := min(end, options.SelectionEnd)
-				if start != end {
-					posStart0, posStart1, countStart := textPositionFromIndex(bounds.Dx(), str, slices.Values(theCachedLines), start, &options.Options)
-					posEnd0, _, countEnd := textPositionFromIndex(bounds.Dx(), str, slices.Values(theCachedLines), end, &options.Options)
-					if countStart > 0 && countEnd > 0 {
-						posStart := posStart0
-						if countStart == 2 {
-							posStart = posStart1
-						}
-						posEnd := posEnd0
-						x := float32(posStart.X) + float32(bounds.Min.X)
-						y := float32(posStart.Top) + float32(bounds.Min.Y)
-						width := float32(posEnd.X - posStart.X)
-						height := float32(posStart.Bottom - posStart.Top)
-						vector.DrawFilledRect(dst, x, y, width, height, options.SelectionColor, false)
-					}
+				for _, r := range ll.ranges(options.SelectionStart, options.SelectionEnd, &options.Options) {
+					x := float32(r.x0) + float32(bounds.Min.X)
+					vector.DrawFilledRect(dst, x, float32(y), float32(r.x1-r.x0), height, options.SelectionColor, false)
 				}
 			}
 		}
 
 		if options.DrawComposition {
 			if start <= options.CompositionEnd && end >= options.CompositionStart {
-				start := max(start, options.CompositionStart)
-				end := min(end, options.CompositionEnd)
-				if start != end {
-					posStart0, posStart1, countStart := textPositionFromIndex(bounds.Dx(), str, slices.Values(theCachedLines), start, &options.Options)
-					posEnd0, _, countEnd := textPositionFromIndex(bounds.Dx(), str, slices.Values(theCachedLines), end, &options.Options)
-					if countStart > 0 && countEnd > 0 {
-						posStart := posStart0
-						if countStart == 2 {
-							posStart = posStart1
-						}
-						posEnd := posEnd0
-						x := float32(posStart.X) + float32(bounds.Min.X)
-						y := float32(posStart.Bottom) + float32(bounds.Min.Y) - options.CompositionBorderWidth
-						w := float32(posEnd.X - posStart.X)
-						h := options.CompositionBorderWidth
-						vector.DrawFilledRect(dst, x, y, w, h, options.InactiveCompositionColor, false)
-					}
+				for _, r := range ll.ranges(options.CompositionStart, options.CompositionEnd, &options.Options) {
+					x := float32(r.x0) + float32(bounds.Min.X)
+					y := float32(y) + height - options.CompositionBorderWidth
+					vector.DrawFilledRect(dst, x, y, float32(r.x1-r.x0), options.CompositionBorderWidth, options.InactiveCompositionColor, false)
 				}
 			}
 			if start <= options.CompositionActiveEnd && end >= options.CompositionActiveStart {
-				start := max(start, options.CompositionActiveStart)
-				end := min(end, options.CompositionActiveEnd)
-				if start != end {
-					posStart0, posStart1, countStart := textPositionFromIndex(bounds.Dx(), str, slices.Values(theCachedLines), start, &options.Options)
-					posEnd0, _, countEnd := textPositionFromIndex(bounds.Dx(), str, slices.Values(theCachedLines), end, &options.Options)
-					if countStart > 0 && countEnd > 0 {
-						posStart := posStart0
-						if countStart == 2 {
-							posStart = posStart1
-						}
-						posEnd := posEnd0
-						x := float32(posStart.X) + float32(bounds.Min.X)
-						y := float32(posStart.Bottom) + float32(bounds.Min.Y) - options.CompositionBorderWidth
-						w := float32(posEnd.X - posStart.X)
-						h := options.CompositionBorderWidth
-						vector.DrawFilledRect(dst, x, y, w, h, options.ActiveCompositionColor, false)
-					}
+				for _, r := range ll.ranges(options.CompositionActiveStart, options.CompositionActiveEnd, &options.Options) {
+					x := float32(r.x0) + float32(bounds.Min.X)
+					y := float32(y) + height - options.CompositionBorderWidth
+					vector.DrawFilledRect(dst, x, y, float32(r.x1-r.x0), options.CompositionBorderWidth, options.ActiveCompositionColor, false)
 				}
 			}
 		}
 
 		// Draw the text.
-		lineStr := line.str
-		origGeoM := op.GeoM
-		if !options.KeepTailingSpace {
-			lineStr = strings.TrimRightFunc(lineStr, unicode.IsSpace)
+		op.GeoM.Translate(0, box.padding)
+		for _, r := range ll.runs {
+			runStr := ll.str[r.start:r.end]
+			if !options.KeepTailingSpace && r.end == len(ll.str) {
+				runStr = strings.TrimRightFunc(runStr, unicode.IsSpace)
+			}
+			drawRun(dst, ll.pos+r.start, runStr, r.rtl, ll.left+r.x, r.width, &box, options, op)
 		}
-		x := oneLineLeft(bounds.Dx(), lineStr, options.Face, options.HorizontalAlign, options.TabWidth, options.KeepTailingSpace)
+		op.GeoM.Translate(0, box.height-box.padding)
+	}
+}
+
+// drawSegment draws str at x in the current line, and returns the position after str.
+func drawSegment(dst *ebiten.Image, x float64, str string, face text.Face, tabWidth float64, op *text.DrawOptions) float64 {
+	origGeoM := op.GeoM
+	defer func() {
+		op.GeoM = origGeoM
+	}()
+
+	if tabWidth == 0 {
+		op.GeoM.Translate(x, 0)
+		text.Draw(dst, str, face, op)
+		return x + text.Advance(str, face)
+	}
+	for {
+		head, tail, ok := strings.Cut(str, "\t")
+		op.GeoM = origGeoM
 		op.GeoM.Translate(x, 0)
-		if options.TabWidth == 0 {
-			text.Draw(dst, lineStr, options.Face, op)
+		text.Draw(dst, head, face, op)
+		x += text.Advance(head, face)
+		if !ok {
+			break
+		}
+		x = nextIndentPosition(x, tabWidth)
+		str = tail
+	}
+	return x
+}
+
+// drawRun draws a run str with a single direction at x from the left of the bounds.
+// pos is the position of str in the whole text.
+func drawRun(dst *ebiten.Image, pos int, str string, rtl bool, x float64, width float64, box *lineBox, options *DrawOptions, op *text.DrawOptions) {
+	origGeoM := op.GeoM
+	origColorScale := op.ColorScale
+	defer func() {
+		op.GeoM = origGeoM
+		op.ColorScale = origColorScale
+	}()
+	op.GeoM.Translate(x, 0)
+
+	// The current position is at the glyphs' top of the line.
+	baseline := box.ascent
+
+	// In a right-to-left run, segments are placed from the right.
+	// The glyphs in each segment are already in the visual order by the shaper.
+	var segX float64
+	if rtl {
+		segX = width
+	}
+	for seg := range segments(pos, str, &options.Options) {
+		var segWidth float64
+		if rtl {
+			segWidth = advanceFrom(0, seg.str, seg.face, options.TabWidth)
+			segX -= segWidth
 		} else {
-			var origX float64
-			for {
-				head, tail, ok := strings.Cut(lineStr, "\t")
-				text.Draw(dst, head, options.Face, op)
-				if !ok {
-					break
+			segWidth = advanceFrom(segX, seg.str, seg.face, options.TabWidth) - segX
+		}
+
+		x0 := float32(op.GeoM.Element(0, 2) + segX)
+		x1 := x0 + float32(segWidth)
+		y := op.GeoM.Element(1, 2)
+		if seg.span != nil && seg.span.BackgroundColor != nil {
+			vector.DrawFilledRect(dst, x0, float32(y), x1-x0, float32(box.ascent+box.descent), seg.span.BackgroundColor, false)
+		}
+
+		clr := options.TextColor
+		if seg.span != nil && seg.span.Color != nil {
+			clr = seg.span.Color
+		}
+		op.ColorScale.Reset()
+		op.ColorScale.ScaleWithColor(clr)
+
+		// Align the baselines of the faces.
+		sm := seg.face.Metrics()
+		dy := baseline - sm.HAscent
+		op.GeoM.Translate(0, dy)
+		drawSegment(dst, segX, seg.str, seg.face, options.TabWidth, op)
+		op.GeoM.Translate(0, -dy)
+
+		if seg.span != nil && (seg.span.Underline || seg.span.Strikethrough) {
+			thickness := float32(max(1, (sm.HAscent+sm.HDescent)/16))
+			if seg.span.Underline {
+				ly := float32(y+baseline) + 1.5*thickness
+				vector.StrokeLine(dst, x0, ly, x1, ly, thickness, clr, false)
+			}
+			if seg.span.Strikethrough {
+				xHeight := sm.XHeight
+				if xHeight == 0 {
+					xHeight = sm.HAscent / 2
 				}
-				x := origX + text.Advance(head, options.Face)
-				nextX := nextIndentPosition(x, options.TabWidth)
-				op.GeoM.Translate(nextX-origX, 0)
-				origX = nextX
-				lineStr = tail
+				ly := float32(y + baseline - xHeight/2)
+				vector.StrokeLine(dst, x0, ly, x1, ly, thickness, clr, false)
 			}
 		}
-		op.GeoM = origGeoM
-		op.GeoM.Translate(0, options.LineHeight)
+
+		if !rtl {
+			segX += segWidth
+		}
 	}
 }
@@ -14,7 +14,9 @@ type Line struct {
 
 func Lines(width int, str string, autoWrap bool, advance func(str string) float64) iter.Seq[Line] {
 	return func(yield func(Line) bool) {
-		for l := range lines(width, str, autoWrap, advance) {
+		for l := range lines(width, str, autoWrap, func(pos int, str string) float64 {
+			return advance(str)
+		}) {
 			if !yield(Line{
 				Pos: l.pos,
 				Str: l.str,
@@ -28,3 +30,21 @@ func Lines(width int, str string, autoWrap bool, advance func(str string) float6
 func NextIndentPosition(position float64, indentWidth float64) float64 {
 	return nextIndentPosition(position, indentWidth)
 }
+
+type VisualRun struct {
+	Start int
+	End   int
+	RTL   bool
+}
+
+func VisualRuns(line string, rtl bool) []VisualRun {
+	var runs []VisualRun
+	for _, r := range appendVisualRuns(nil, line, rtl) {
+		runs = append(runs, VisualRun{
+			Start: r.start,
+			End:   r.end,
+			RTL:   r.rtl,
+		})
+	}
+	return runs
+}
@@ -6,7 +6,9 @@ package textutil
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"iter"
+	"slices"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -27,20 +29,40 @@ func advance(str string, face text.Face, tabWidth float64, keepTailingSpace bool
 	if !keepTailingSpace {
 		str = strings.TrimRightFunc(str, unicode.IsSpace)
 	}
+	return advanceFrom(0, str, face, tabWidth)
+}
+
+// advanceFrom returns the position after str that starts at x in a line.
+func advanceFrom(x float64, str string, face text.Face, tabWidth float64) float64 {
 	if tabWidth == 0 {
-		return text.Advance(str, face)
+		return x + text.Advance(str, face)
 	}
-	var width float64
 	for {
 		head, tail, ok := strings.Cut(str, "\t")
-		width += text.Advance(head, face)
+		x += text.Advance(head, face)
 		if !ok {
 			break
 		}
-		width = nextIndentPosition(width, tabWidth)
+		x = nextIndentPosition(x, tabWidth)
 		str = tail
 	}
-	return width
+	return x
+}
+
+// advanceAt is like advance, but considers the spans in options.
+// pos is the position of str in the whole text.
+func advanceAt(pos int, str string, options *Options, keepTailingSpace bool) float64 {
+	if len(options.Spans) == 0 {
+		return advance(str, options.Face, options.TabWidth, keepTailingSpace)
+	}
+	if !keepTailingSpace {
+		str = strings.TrimRightFunc(str, unicode.IsSpace)
+	}
+	var x float64
+	for seg := range segments(pos, str, options) {
+		x = advanceFrom(x, seg.str, seg.face, options.TabWidth)
+	}
+	return x
 }
 
 type Options struct {
@@ -51,6 +73,90 @@ type Options struct {
 	VerticalAlign    VerticalAlign
 	TabWidth         float64
 	KeepTailingSpace bool
+
+	// RightToLeft specifies the writing direction of the UI.
+	// This is used as the direction of a paragraph without strong characters,
+	// and HorizontalAlignStart and HorizontalAlignEnd are the right and the left in a right-to-left UI.
+	RightToLeft bool
+
+	// Spans are styled ranges of the text.
+	// Spans must be sorted by their positions and must not overlap.
+	Spans []Span
+}
+
+// Span represents a styled range of a text in bytes.
+type Span struct {
+	Start int
+	End   int
+
+	// Face is the face for the range. If Face is nil, Options.Face is used.
+	Face text.Face
+
+	// Color is the text color for the range. If Color is nil, DrawOptions.TextColor is used.
+	Color color.Color
+
+	BackgroundColor color.Color
+	Underline       bool
+	Strikethrough   bool
+
+	// LineHeight is the minimum height of the lines including the range. If LineHeight is 0, Options.LineHeight is used.
+	LineHeight float64
+}
+
+type segment struct {
+	pos  int
+	str  string
+	face text.Face
+	span *Span
+}
+
+// segments splits str into ranges with the same style.
+// pos is the position of str in the whole text.
+func segments(pos int, str string, options *Options) iter.Seq[segment] {
+	return func(yield func(segment) bool) {
+		end := pos + len(str)
+		cur := pos
+		for i := range options.Spans {
+			span := &options.Spans[i]
+			if span.End <= cur {
+				continue
+			}
+			if span.Start >= end {
+				break
+			}
+			if span.Start > cur {
+				if !yield(segment{
+					pos:  cur,
+					str:  str[cur-pos : span.Start-pos],
+					face: options.Face,
+				}) {
+					return
+				}
+				cur = span.Start
+			}
+			e := min(span.End, end)
+			face := span.Face
+			if face == nil {
+				face = options.Face
+			}
+			if !yield(segment{
+				pos:  cur,
+				str:  str[cur-pos : e-pos],
+				face: face,
+				span: span,
+			}) {
+				return
+			}
+			cur = e
+		}
+		if cur < end || len(str) == 0 {
+			yield(segment{
+				pos:  cur,
+				str:  str[cur-pos:],
+				face: options.Face,
+			})
+		}
+	}
 }
 
 type HorizontalAlign int
@@ -80,7 +186,7 @@ type line struct {
 	str string
 }
 
-func lines(width int, str string, autoWrap bool, advance func(str string) float64) iter.Seq[line] {
+func lines(width int, str string, autoWrap bool, advance func(pos int, str string) float64) iter.Seq[line] {
 	return func(yield func(line) bool) {
 		origStr := str
 
@@ -115,7 +221,7 @@ func lines(width int, str string, autoWrap bool, advance func(str string) float6
 				if lineEnd-lineStart > 0 {
 					l := origStr[lineStart : lineEnd+len(segment)]
 					// TODO: Consider a line alignment and/or editable/selectable states when calculating the width.
-					if advance(l[:len(l)-tailingLineBreakLen(l)]) > float64(width) {
+					if advance(lineStart, l[:len(l)-tailingLineBreakLen(l)]) > float64(width) {
 						if !yield(line{
 							pos: pos,
 							str: origStr[lineStart:lineEnd],
@@ -164,60 +270,253 @@ func lines(width int, str string, autoWrap bool, advance func(str string) float6
 	}
 }
 
-func oneLineLeft(width int, line string, face text.Face, hAlign HorizontalAlign, tabWidth float64, keepTailingSpace bool) float64 {
-	w := advance(line[:len(line)-tailingLineBreakLen(line)], face, tabWidth, keepTailingSpace)
+func linesWithOptions(width int, str string, options *Options) iter.Seq[line] {
+	return lines(width, str, options.AutoWrap, func(pos int, str string) float64 {
+		return advanceAt(pos, str, options, options.KeepTailingSpace)
+	})
+}
+
+func oneLineLeft(width int, pos int, line string, options *Options) float64 {
+	w := advanceAt(pos, line[:len(line)-tailingLineBreakLen(line)], options, options.KeepTailingSpace)
+	hAlign := options.HorizontalAlign
+	switch hAlign {
+	case HorizontalAlignStart:
+		if options.RightToLeft {
+			hAlign = HorizontalAlignRight
+		}
+	case HorizontalAlignEnd:
+		if options.RightToLeft {
+			hAlign = HorizontalAlignLeft
+		}
+	}
 	switch hAlign {
 	case HorizontalAlignStart, HorizontalAlignLeft:
-		// For RTL languages, HorizontalAlignStart should be the same as HorizontalAlignRight.
 		return 0
 	case HorizontalAlignCenter:
 		return (float64(width) - w) / 2
 	case HorizontalAlignEnd, HorizontalAlignRight:
-		// For RTL languages, HorizontalAlignEnd should be the same as HorizontalAlignLeft.
 		return float64(width) - w
 	default:
 		panic(fmt.Sprintf("textutil: invalid HorizontalAlign: %d", hAlign))
 	}
 }
 
-func TextIndexFromPosition(width int, position image.Point, str string, options *Options) int {
-	// Determine the line first.
-	padding := textPadding(options.Face, options.LineHeight)
-	n := int((float64(position.Y) + padding) / options.LineHeight)
-
-	var pos int
-	var line string
-	var lineIndex int
-	for l := range lines(width, str, options.AutoWrap, func(str string) float64 {
-		return advance(str, options.Face, options.TabWidth, options.KeepTailingSpace)
-	}) {
-		line = l.str
-		pos = l.pos
-		if lineIndex >= n {
+// lineLayout is a line's layout in the visual order.
+type lineLayout struct {
+	// pos is the position of the line in the whole text.
+	pos int
+
+	// str is the line without a tailing line break.
+	str string
+
+	// left is the left position of the line.
+	left float64
+
+	// runs are the runs of the line from the left to the right.
+	runs []visualRun
+}
+
+func newLineLayout(width int, wholeStr string, l line, options *Options) lineLayout {
+	str := l.str[:len(l.str)-tailingLineBreakLen(l.str)]
+	rtl := IsRightToLeftParagraph(wholeStr, l.pos, options.RightToLeft)
+	ll := lineLayout{
+		pos:  l.pos,
+		str:  str,
+		left: oneLineLeft(width, l.pos, l.str, options),
+		runs: appendVisualRuns(nil, str, rtl),
+	}
+	var x float64
+	for i := range ll.runs {
+		r := &ll.runs[i]
+		r.x = x
+		r.width = advanceAt(l.pos+r.start, str[r.start:r.end], options, true)
+		x += r.width
+	}
+	// Tailing spaces are not counted for the alignment, but they are at the left in a right-to-left paragraph.
+	if rtl && !options.KeepTailingSpace {
+		ll.left -= x - advanceAt(l.pos, str, options, false)
+	}
+	return ll
+}
+
+func (l *lineLayout) xInRun(run *visualRun, indexInLine int, options *Options) float64 {
+	a := advanceAt(l.pos+run.start, l.str[run.start:indexInLine], options, true)
+	if run.rtl {
+		return run.x + run.width - a
+	}
+	return run.x + a
+}
+
+// x returns the x position of the given index in the whole text.
+func (l *lineLayout) x(index int, options *Options) float64 {
+	i := index - l.pos
+	for j := range l.runs {
+		r := &l.runs[j]
+		if r.start <= i && i < r.end {
+			return l.left + l.xInRun(r, i, options)
+		}
+	}
+	// The index is at the end of the line.
+	for j := range l.runs {
+		r := &l.runs[j]
+		if r.end == i {
+			return l.left + l.xInRun(r, i, options)
+		}
+	}
+	return l.left
+}
+
+// index returns the index in the whole text at the given x position.
+func (l *lineLayout) index(x float64, options *Options) int {
+	if len(l.runs) == 0 {
+		return l.pos
+	}
+	x -= l.left
+	run := &l.runs[len(l.runs)-1]
+	for i := range l.runs {
+		if x < l.runs[i].x+l.runs[i].width {
+			run = &l.runs[i]
 			break
 		}
-		lineIndex++
+	}
+	d := x - run.x
+	if run.rtl {
+		d = run.x + run.width - x
 	}
 
-	// Deterine the line index.
-	left := oneLineLeft(width, line, options.Face, options.HorizontalAlign, options.TabWidth, options.KeepTailingSpace)
+	str := l.str[run.start:run.end]
+	clusters := visibleCulsters(str, options.Face)
+	if run.rtl {
+		// The glyphs of a right-to-left text are in the visual order.
+		slices.SortStableFunc(clusters, func(a, b text.Glyph) int {
+			return a.StartIndexInBytes - b.StartIndexInBytes
+		})
+	}
 	var prevA float64
-	var clusterFound bool
-	for _, c := range visibleCulsters(line, options.Face) {
-		a := advance(line[:c.EndIndexInBytes], options.Face, options.TabWidth, true)
-		if (float64(position.X) - left) < (prevA + (a-prevA)/2) {
-			pos += c.StartIndexInBytes
-			clusterFound = true
-			break
+	for _, c := range clusters {
+		a := advanceAt(l.pos+run.start, str[:c.EndIndexInBytes], options, true)
+		if d < prevA+(a-prevA)/2 {
+			return l.pos + run.start + c.StartIndexInBytes
 		}
 		prevA = a
 	}
-	if !clusterFound {
-		pos += len(line)
-		pos -= tailingLineBreakLen(line)
+	return l.pos + run.end
+}
+
+type xRange struct {
+	x0 float64
+	x1 float64
+}
+
+// ranges returns the horizontal ranges of the text range [start, end) in the line.
+func (l *lineLayout) ranges(start, end int, options *Options) []xRange {
+	var rs []xRange
+	for j := range l.runs {
+		r := &l.runs[j]
+		s := max(start-l.pos, r.start)
+		e := min(end-l.pos, r.end)
+		if s >= e {
+			continue
+		}
+		x0 := l.xInRun(r, s, options)
+		x1 := l.xInRun(r, e, options)
+		if x0 > x1 {
+			x0, x1 = x1, x0
+		}
+		rs = append(rs, xRange{
+			x0: l.left + x0,
+			x1: l.left + x1,
+		})
+	}
+	return rs
+}
+
+// NextVisualPosition returns the position next to index in the visual order of the line including index.
+// If right is true, the position at the right of index is returned. Otherwise, the position at the left of index is returned.
+// The returned position is on a grapheme boundary.
+//
+// ok is false if index is at the edge of the line.
+func NextVisualPosition(width int, str string, index int, right bool, options *Options) (int, bool) {
+	if index < 0 || index > len(str) {
+		return index, false
+	}
+
+	var l line
+	for l = range linesWithOptions(width, str, options) {
+		if index < l.pos+len(l.str) {
+			break
+		}
+	}
+	ll := newLineLayout(width, str, l, options)
+	i := index - ll.pos
+	if i < 0 || i > len(ll.str) {
+		return index, false
+	}
+
+	// An index at the boundary of two runs is shown in one of the runs. See lineLayout.x.
+	runAt := func(i int) int {
+		for j := range ll.runs {
+			if r := &ll.runs[j]; r.start <= i && i < r.end {
+				return j
+			}
+		}
+		for j := range ll.runs {
+			if ll.runs[j].end == i {
+				return j
+			}
+		}
+		return -1
+	}
+
+	// Collect the positions in the visual order, from the left to the right.
+	var positions []int
+	for j := range ll.runs {
+		r := &ll.runs[j]
+		origLen := len(positions)
+		pos := r.start
+		if runAt(pos) == j {
+			positions = append(positions, pos)
+		}
+		for c := range graphemes(ll.str[r.start:r.end]) {
+			pos += len(c)
+			if runAt(pos) == j {
+				positions = append(positions, pos)
+			}
+		}
+		if r.rtl {
+			slices.Reverse(positions[origLen:])
+		}
+	}
+
+	k := slices.Index(positions, i)
+	if k < 0 {
+		return index, false
+	}
+	if right {
+		k++
+	} else {
+		k--
 	}
+	if k < 0 || k >= len(positions) {
+		return index, false
+	}
+	return ll.pos + positions[k], true
+}
 
-	return pos
+func TextIndexFromPosition(width int, position image.Point, str string, options *Options) int {
+	// Determine the line first.
+	var l line
+	var y float64
+	for l = range linesWithOptions(width, str, options) {
+		b := newLineBox(l, options)
+		if float64(position.Y)+b.padding < y+b.height {
+			break
+		}
+		y += b.height
+	}
+
+	ll := newLineLayout(width, str, l, options)
+	return ll.index(float64(position.X), options)
 }
 
 type TextPosition struct {
@@ -230,9 +529,7 @@ func TextPositionFromIndex(width int, str string, index int, options *Options) (
 	if index < 0 || index > len(str) {
 		return TextPosition{}, TextPosition{}, 0
 	}
-	return textPositionFromIndex(width, str, lines(width, str, options.AutoWrap, func(str string) float64 {
-		return advance(str, options.Face, options.TabWidth, options.KeepTailingSpace)
-	}), index, options)
+	return textPositionFromIndex(width, str, linesWithOptions(width, str, options), index, options)
 }
 
 func textPositionFromIndex(width int, str string, lines iter.Seq[line], index int, options *Options) (position0, position1 TextPosition, count int) {
@@ -241,50 +538,49 @@ func textPositionFromIndex(width int, str string, lines iter.Seq[line], index in
 	}
 
 	var y, y0, y1 float64
-	var indexInLine0, indexInLine1 int
-	var line0, line1 string
+	var line0, line1 line
+	var box0, box1 lineBox
 	var found0, found1 bool
 	for l := range lines {
+		b := newLineBox(l, options)
 		// When auto wrap is on, there can be two positions:
 		// one in the tail of the previous line and one in the head of the next line.
 		if tailingLineBreakLen(l.str) == 0 && index == l.pos+len(l.str) {
 			found0 = true
-			line0 = l.str
-			indexInLine0 = index - l.pos
+			line0 = l
+			box0 = b
 			y0 = y
 		} else if l.pos <= index && index < l.pos+len(l.str) {
 			found1 = true
-			line1 = l.str
-			indexInLine1 = index - l.pos
+			line1 = l
+			box1 = b
 			y1 = y
 			break
 		}
-		y += options.LineHeight
+		y += b.height
 	}
 
 	if !found0 && !found1 {
 		return TextPosition{}, TextPosition{}, 0
 	}
 
-	paddingY := textPadding(options.Face, options.LineHeight)
-
 	var pos0, pos1 TextPosition
 	if found0 {
-		x0 := oneLineLeft(width, line0, options.Face, options.HorizontalAlign, options.TabWidth, options.KeepTailingSpace)
-		x0 += advance(line0[:indexInLine0], options.Face, options.TabWidth, true)
+		ll := newLineLayout(width, str, line0, options)
+		x0 := ll.x(index, options)
 		pos0 = TextPosition{
 			X:      x0,
-			Top:    y0 + paddingY,
-			Bottom: y0 + options.LineHeight - paddingY,
+			Top:    y0 + box0.padding,
+			Bottom: y0 + box0.height - box0.padding,
 		}
 	}
 	if found1 {
-		x1 := oneLineLeft(width, line1, options.Face, options.HorizontalAlign, options.TabWidth, options.KeepTailingSpace)
-		x1 += advance(line1[:indexInLine1], options.Face, options.TabWidth, true)
+		ll := newLineLayout(width, str, line1, options)
+		x1 := ll.x(index, options)
 		pos1 = TextPosition{
 			X:      x1,
-			Top:    y1 + paddingY,
-			Bottom: y1 + options.LineHeight - paddingY,
+			Top:    y1 + box1.padding,
+			Bottom: y1 + box1.height - box1.padding,
 		}
 	}
 	if found0 && !found1 {
@@ -348,49 +644,95 @@ func trimTailingLineBreak(str string) string {
 	return str
 }
 
-func lineCount(width int, str string, autoWrap bool, face text.Face, tabWidth float64, keepTailingSpace bool) int {
-	var count int
-	for range lines(width, str, autoWrap, func(str string) float64 {
-		return advance(str, face, tabWidth, keepTailingSpace)
-	}) {
-		count++
+// LogicalLineTops returns an iterator of the logical lines separated by hard line breaks.
+// The iterator yields the index of each logical line and the top of its first visual line from the top of the bounds.
+func LogicalLineTops(size image.Point, str string, options *Options) iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		y := textPositionYOffset(size, str, options)
+		var idx int
+		head := true
+		for l := range linesWithOptions(size.X, str, options) {
+			if head {
+				if !yield(idx, y) {
+					return
+				}
+				idx++
+			}
+			head = tailingLineBreakLen(l.str) > 0
+			y += newLineBox(l, options).height
+		}
 	}
-	return count
 }
 
-func Measure(width int, str string, autoWrap bool, face text.Face, lineHeight float64, tabWidth float64, keepTailingSpace bool) (float64, float64) {
+func textHeight(width int, str string, options *Options) float64 {
+	var height float64
+	for l := range linesWithOptions(width, str, options) {
+		height += newLineBox(l, options).height
+	}
+	return height
+}
+
+func Measure(width int, str string, options *Options) (float64, float64) {
 	var maxWidth, height float64
-	for l := range lines(width, str, autoWrap, func(str string) float64 {
-		return advance(str, face, tabWidth, keepTailingSpace)
-	}) {
+	for l := range linesWithOptions(width, str, options) {
 		line := l.str
-		if !keepTailingSpace {
+		if !options.KeepTailingSpace {
 			line = trimTailingLineBreak(line)
 		}
-		maxWidth = max(maxWidth, advance(line, face, tabWidth, keepTailingSpace))
-		// The text is already shifted by (lineHeight - (m.HAscent + m.Descent)) / 2.
-		// Thus, just counting the line number is enough.
-		height += lineHeight
+		maxWidth = max(maxWidth, advanceAt(l.pos, line, options, options.KeepTailingSpace))
+		// The text is already shifted by the padding in each line.
+		// Thus, just adding the line heights is enough.
+		height += newLineBox(l, options).height
 	}
 	return maxWidth, height
 }
 
-func textPadding(face text.Face, lineHeight float64) float64 {
-	m := face.Metrics()
-	padding := (lineHeight - (m.HAscent + m.HDescent)) / 2
-	return padding
+// lineBox is the vertical metrics of a line.
+type lineBox struct {
+	height float64
+
+	// padding is the space between the line's top and the glyphs' top.
+	padding float64
+
+	// ascent is the distance between the glyphs' top and the baseline.
+	ascent  float64
+	descent float64
+}
+
+// newLineBox returns the vertical metrics of l.
+// The height is the largest line height of the spans in the line,
+// and the glyphs of all the faces in the line are centered vertically.
+func newLineBox(l line, options *Options) lineBox {
+	m := options.Face.Metrics()
+	b := lineBox{
+		height:  options.LineHeight,
+		ascent:  m.HAscent,
+		descent: m.HDescent,
+	}
+	if len(options.Spans) > 0 {
+		for seg := range segments(l.pos, l.str, options) {
+			if seg.span == nil {
+				continue
+			}
+			b.height = max(b.height, seg.span.LineHeight)
+			m := seg.face.Metrics()
+			b.ascent = max(b.ascent, m.HAscent)
+			b.descent = max(b.descent, m.HDescent)
+		}
+	}
+	b.padding = (b.height - (b.ascent + b.descent)) / 2
+	return b
 }
 
+// textPositionYOffset returns the top of the first line from the top of the bounds.
 func textPositionYOffset(size image.Point, str string, options *Options) float64 {
-	c := lineCount(size.X, str, options.AutoWrap, options.Face, options.TabWidth, options.KeepTailingSpace)
-	textHeight := options.LineHeight * float64(c)
-	yOffset := textPadding(options.Face, options.LineHeight)
+	var yOffset float64
 	switch options.VerticalAlign {
 	case VerticalAlignTop:
 	case VerticalAlignMiddle:
-		yOffset += (float64(size.Y) - textHeight) / 2
+		yOffset += (float64(size.Y) - textHeight(size.X, str, options)) / 2
 	case VerticalAlignBottom:
-		yOffset += float64(size.Y) - textHeight
+		yOffset += float64(size.Y) - textHeight(size.X, str, options)
 	}
 	return yOffset
 }
@@ -8,6 +8,9 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/basicfont"
+
 	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
 )
 
@@ -132,3 +135,135 @@ func TestNextIndentPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestMeasureWithSpans(t *testing.T) {
+	face := text.NewGoXFace(basicfont.Face7x13)
+	testCases := []struct {
+		name   string
+		spans  []textutil.Span
+		height float64
+	}{
+		{
+			name:   "no spans",
+			height: 60,
+		},
+		{
+			name: "tall span in the second line",
+			spans: []textutil.Span{
+				{Start: 2, End: 3, Face: face, LineHeight: 40},
+			},
+			height: 80,
+		},
+		{
+			name: "short span",
+			spans: []textutil.Span{
+				{Start: 0, End: 1, Face: face, LineHeight: 10},
+			},
+			height: 60,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, got := textutil.Measure(0, "a\nb\nc", &textutil.Options{
+				Face:       face,
+				LineHeight: 20,
+				Spans:      tc.spans,
+			})
+			if got != tc.height {
+				t.Errorf("got %f, want %f", got, tc.height)
+			}
+		})
+	}
+}
+
+func TestVisualRuns(t *testing.T) {
+	testCases := []struct {
+		str  string
+		rtl  bool
+		runs []textutil.VisualRun
+	}{
+		{
+			str: "abc",
+			rtl: false,
+			runs: []textutil.VisualRun{
+				{Start: 0, End: 3, RTL: false},
+			},
+		},
+		{
+			str: "abc אבג",
+			rtl: false,
+			runs: []textutil.VisualRun{
+				{Start: 0, End: 4, RTL: false},
+				{Start: 4, End: 10, RTL: true},
+			},
+		},
+		{
+			// The numbers after a right-to-left text are at a higher level in the right-to-left text.
+			str: "abc אבג 123 דהו",
+			rtl: false,
+			runs: []textutil.VisualRun{
+				{Start: 0, End: 4, RTL: false},
+				{Start: 14, End: 21, RTL: true},
+				{Start: 11, End: 14, RTL: false},
+				{Start: 4, End: 11, RTL: true},
+			},
+		},
+		{
+			str: "אבג abc 123",
+			rtl: true,
+			runs: []textutil.VisualRun{
+				{Start: 7, End: 14, RTL: false},
+				{Start: 0, End: 7, RTL: true},
+			},
+		},
+		{
+			str: "אבג 12.5 דהו",
+			rtl: true,
+			runs: []textutil.VisualRun{
+				{Start: 11, End: 18, RTL: true},
+				{Start: 7, End: 11, RTL: false},
+				{Start: 0, End: 7, RTL: true},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q (rtl=%t)", tc.str, tc.rtl), func(t *testing.T) {
+			got := textutil.VisualRuns(tc.str, tc.rtl)
+			if !slices.Equal(got, tc.runs) {
+				t.Errorf("got %v, want %v", got, tc.runs)
+			}
+		})
+	}
+}
+
+func TestNextVisualPosition(t *testing.T) {
+	// The visual positions are 0, 1, 2, 3, 10, 8, 6, and 4 from the left.
+	const str = "abc אבג"
+	testCases := []struct {
+		index int
+		right bool
+		pos   int
+		ok    bool
+	}{
+		{index: 0, right: true, pos: 1, ok: true},
+		{index: 0, right: false, pos: 0, ok: false},
+		{index: 3, right: true, pos: 10, ok: true},
+		{index: 10, right: true, pos: 8, ok: true},
+		{index: 10, right: false, pos: 3, ok: true},
+		{index: 6, right: true, pos: 4, ok: true},
+		{index: 4, right: true, pos: 4, ok: false},
+		{index: 4, right: false, pos: 6, ok: true},
+	}
+	face := text.NewGoXFace(basicfont.Face7x13)
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("index=%d right=%t", tc.index, tc.right), func(t *testing.T) {
+			pos, ok := textutil.NextVisualPosition(0, str, tc.index, tc.right, &textutil.Options{
+				Face:       face,
+				LineHeight: 20,
+			})
+			if pos != tc.pos || ok != tc.ok {
+				t.Errorf("got (%d, %t), want (%d, %t)", pos, ok, tc.pos, tc.ok)
+			}
+		})
+	}
+}
@@ -37,6 +37,9 @@ type ListItem[T comparable] struct {
 	Value        T
 	IndentLevel  int
 	Collapsed    bool
+
+	// Icon is the name of a registered icon shown before Text. Icon is ignored if Content is set.
+	Icon string
 }
 
 func (l *ListItem[T]) selectable() bool {
@@ -63,6 +66,13 @@ func (l *List[T]) SetOnItemsMoved(f func(from, count, to int)) {
 	l.list.SetOnItemsMoved(f)
 }
 
+// SetOnContextMenuRequested sets the function called when a context menu is requested on an item
+// by a right click or a long press.
+// position is the position of the pointer.
+func (l *List[T]) SetOnContextMenuRequested(f func(index int, position image.Point)) {
+	l.list.SetOnContextMenuRequested(f)
+}
+
 func (l *List[T]) SetOnItemExpanderToggled(f func(index int, expanded bool)) {
 	l.list.SetOnItemExpanderToggled(f)
 }
@@ -102,6 +112,7 @@ func (l *List[T]) Update(context *guigui.Context) error {
 		item := &l.listItemWidgets[i]
 		item.text.SetBold(item.item.Header || l.list.style == ListStyleSidebar && l.SelectedItemIndex() == i)
 		item.text.SetColor(l.ItemTextColor(context, i))
+		item.icon.SetIconColor(l.ItemTextColor(context, i))
 		context.SetEnabled(item, !item.item.Disabled)
 	}
 	return nil
@@ -203,6 +214,7 @@ type listItemWidget[T comparable] struct {
 
 	item ListItem[T]
 	text Text
+	icon Image
 
 	heightPlus1 int
 	style       ListStyle
@@ -229,19 +241,54 @@ func (l *listItemWidget[T]) AddChildren(context *guigui.Context, adder *guigui.C
 		adder.AddChild(l.item.Content)
 		return
 	}
+	if l.item.Icon != "" {
+		adder.AddChild(&l.icon)
+	}
 	adder.AddChild(&l.text)
 }
 
 func (l *listItemWidget[T]) Update(context *guigui.Context) error {
 	l.text.SetValue(l.item.Text)
 	l.text.SetVerticalAlign(VerticalAlignMiddle)
+	l.icon.SetIconName(l.item.Icon)
 	return nil
 }
 
+func (l *listItemWidget[T]) hasIcon() bool {
+	return l.item.Icon != "" && l.item.Content == nil
+}
+
+// iconWidth returns the width of the icon including the gap between the icon and the text.
+func (l *listItemWidget[T]) iconWidth(context *guigui.Context) int {
+	if !l.hasIcon() {
+		return 0
+	}
+	return defaultIconSize(context) + UnitSize(context)/4
+}
+
 func (l *listItemWidget[T]) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
 	switch widget {
+	case &l.icon:
+		b := context.Bounds(l)
+		s := defaultIconSize(context)
+		r := image.Rectangle{
+			Min: image.Pt(b.Min.X, b.Min.Y+(b.Dy()-s)/2),
+		}
+		r.Max = r.Min.Add(image.Pt(s, s))
+		if context.IsRightToLeft() {
+			r = guigui.MirrorRectangle(r, b)
+		}
+		return r
 	case &l.text, l.item.Content:
 		b := context.Bounds(l)
+		if widget == &l.text {
+			iw := l.iconWidth(context)
+			if context.IsRightToLeft() {
+				b.Max.X -= iw
+			} else {
+				b.Min.X += iw
+			}
+		}
 		s := widget.Measure(context, guigui.FixedWidthConstraints(b.Dx()))
 		if l.style != ListStyleMenu {
 			s.X = b.Dx()
@@ -281,7 +328,7 @@ func (l *listItemWidget[T]) Measure(context *guigui.Context, constraints guigui.
 		s = l.item.Content.Measure(context, constraints)
 	}
 	// Assume that every item can use a bold font.
-	s.X = max(s.X, l.text.boldTextSize(context, constraints).X)
+	s.X = max(s.X, l.text.boldTextSize(context, constraints).X+l.iconWidth(context))
 
 	if l.style != ListStyleMenu || l.item.Border {
 		if w, ok := constraints.FixedWidth(); ok {
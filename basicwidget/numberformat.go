@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// NumberFormatter formats a number into a string and parses a string into a number.
+type NumberFormatter interface {
+	// FormatNumber formats value with precision digits after the decimal point.
+	FormatNumber(value *big.Rat, precision int) string
+
+	// ParseNumber parses str. ok is false if str is not a valid number.
+	ParseNumber(str string) (value *big.Rat, ok bool)
+}
+
+// NumberStyle represents a style to format a number.
+type NumberStyle int
+
+const (
+	NumberStyleDecimal NumberStyle = iota
+
+	// NumberStylePercent formats a value multiplied by 100 with a percent sign, e.g. 0.25 as "25%".
+	// The precision is reduced by 2 accordingly.
+	NumberStylePercent
+
+	// NumberStyleCurrency formats a value with the symbol of NumberFormat.Currency.
+	NumberStyleCurrency
+)
+
+// NumberFormat represents options to format a number.
+type NumberFormat struct {
+	Style NumberStyle
+
+	// Currency is the currency for NumberStyleCurrency.
+	Currency currency.Unit
+
+	// Prefix and Suffix are units put before and after a number, e.g. "px" or " ms".
+	Prefix string
+	Suffix string
+
+	// NoGrouping disables the grouping separators, e.g. "1234" instead of "1,234".
+	NoGrouping bool
+}
+
+// LocaleNumberFormatter is a NumberFormatter using the conventions of a locale,
+// including the grouping, the decimal separator, and the native digits.
+type LocaleNumberFormatter struct {
+	Locale language.Tag
+	Format NumberFormat
+}
+
+// numberSymbols represents the symbols to format a number in a locale.
+type numberSymbols struct {
+	zero    rune
+	decimal rune
+	group   rune
+
+	// primaryGroupSize is the size of the last group of the integer part, and
+	// secondaryGroupSize is the size of the other groups, e.g. 3 and 2 for "12,34,567".
+	// primaryGroupSize is 0 if the locale doesn't use grouping.
+	primaryGroupSize   int
+	secondaryGroupSize int
+
+	percentPrefix string
+	percentSuffix string
+}
+
+type currencyAffixesKey struct {
+	locale   language.Tag
+	currency currency.Unit
+}
+
+type currencyAffixes struct {
+	prefix string
+	suffix string
+}
+
+var (
+	theNumberSymbolsCache   map[language.Tag]*numberSymbols
+	theCurrencyAffixesCache map[currencyAffixesKey]currencyAffixes
+)
+
+// numberAffixes returns the texts before the first digit and after the last digit in str.
+func numberAffixes(str string) (string, string) {
+	first := strings.IndexFunc(str, unicode.IsDigit)
+	if first < 0 {
+		return "", ""
+	}
+	last := strings.LastIndexFunc(str, unicode.IsDigit)
+	_, s := utf8.DecodeRuneInString(str[last:])
+	return str[:first], str[last+s:]
+}
+
+// newNumberSymbols extracts the symbols from a sample number formatted like "1,234,567.5".
+func newNumberSymbols(decimalSample, percentSample string) *numberSymbols {
+	s := &numberSymbols{
+		zero:    '0',
+		decimal: '.',
+	}
+
+	var groups []int
+	var digits int
+	var sep rune
+	for _, r := range decimalSample {
+		if unicode.IsDigit(r) {
+			if len(groups) == 0 && digits == 0 {
+				// The first digit is 1.
+				s.zero = r - 1
+			}
+			digits++
+			continue
+		}
+		if digits == 0 {
+			continue
+		}
+		if len(groups) == 0 {
+			s.group = r
+		}
+		groups = append(groups, digits)
+		digits = 0
+		sep = r
+	}
+	// The last separator is the decimal separator, and the digits before it are the integer part.
+	if len(groups) > 0 {
+		s.decimal = sep
+	}
+	if len(groups) >= 2 {
+		s.primaryGroupSize = groups[len(groups)-1]
+		s.secondaryGroupSize = s.primaryGroupSize
+	} else {
+		s.group = 0
+	}
+	if len(groups) >= 3 {
+		s.secondaryGroupSize = groups[len(groups)-2]
+	}
+
+	s.percentPrefix, s.percentSuffix = numberAffixes(percentSample)
+	return s
+}
+
+func localeNumberSymbols(locale language.Tag) *numberSymbols {
+	if s, ok := theNumberSymbolsCache[locale]; ok {
+		return s
+	}
+	p := message.NewPrinter(locale)
+	s := newNumberSymbols(p.Sprint(number.Decimal(1234567.5, number.Scale(1))), p.Sprint(number.Percent(0)))
+	if theNumberSymbolsCache == nil {
+		theNumberSymbolsCache = map[language.Tag]*numberSymbols{}
+	}
+	theNumberSymbolsCache[locale] = s
+	return s
+}
+
+func localeCurrencyAffixes(locale language.Tag, cur currency.Unit) (string, string) {
+	key := currencyAffixesKey{
+		locale:   locale,
+		currency: cur,
+	}
+	if a, ok := theCurrencyAffixesCache[key]; ok {
+		return a.prefix, a.suffix
+	}
+	p := message.NewPrinter(locale)
+	prefix, suffix := numberAffixes(p.Sprint(currency.Symbol(cur.Amount(0))))
+	if theCurrencyAffixesCache == nil {
+		theCurrencyAffixesCache = map[currencyAffixesKey]currencyAffixes{}
+	}
+	theCurrencyAffixesCache[key] = currencyAffixes{
+		prefix: prefix,
+		suffix: suffix,
+	}
+	return prefix, suffix
+}
+
+func (l LocaleNumberFormatter) affixes(symbols *numberSymbols) (string, string) {
+	switch l.Format.Style {
+	case NumberStylePercent:
+		return l.Format.Prefix + symbols.percentPrefix, symbols.percentSuffix + l.Format.Suffix
+	case NumberStyleCurrency:
+		prefix, suffix := localeCurrencyAffixes(l.Locale, l.Format.Currency)
+		return l.Format.Prefix + prefix, suffix + l.Format.Suffix
+	}
+	return l.Format.Prefix, l.Format.Suffix
+}
+
+var ratHundred = big.NewRat(100, 1)
+
+// FormatNumber implements NumberFormatter.
+func (l LocaleNumberFormatter) FormatNumber(value *big.Rat, precision int) string {
+	s := localeNumberSymbols(l.Locale)
+	if l.Format.Style == NumberStylePercent {
+		value = (&big.Rat{}).Mul(value, ratHundred)
+		precision = max(precision-2, 0)
+	}
+	str := value.FloatString(precision)
+
+	prefix, suffix := l.affixes(s)
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	if strings.HasPrefix(str, "-") {
+		b.WriteByte('-')
+		str = str[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	for i, r := range intPart {
+		if i > 0 && !l.Format.NoGrouping && s.group != 0 && s.primaryGroupSize > 0 {
+			if n := len(intPart) - i; n == s.primaryGroupSize || (n > s.primaryGroupSize && (n-s.primaryGroupSize)%s.secondaryGroupSize == 0) {
+				b.WriteRune(s.group)
+			}
+		}
+		b.WriteRune(s.zero + r - '0')
+	}
+	if fracPart != "" {
+		b.WriteRune(s.decimal)
+		for _, r := range fracPart {
+			b.WriteRune(s.zero + r - '0')
+		}
+	}
+	b.WriteString(suffix)
+	return b.String()
+}
+
+var numberTextReplacer = strings.NewReplacer(
+	"\u2212", "-",
+	"\ufe62", "+",
+	"\ufe63", "-",
+	"\uff0b", "+",
+	"\uff0d", "-",
+	"\uff10", "0",
+	"\uff11", "1",
+	"\uff12", "2",
+	"\uff13", "3",
+	"\uff14", "4",
+	"\uff15", "5",
+	"\uff16", "6",
+	"\uff17", "7",
+	"\uff18", "8",
+	"\uff19", "9",
+	"\uff0e", ".",
+	"\uff05", "%",
+	// Bidi marks are ignored.
+	"\u200e", "",
+	"\u200f", "",
+	"\u061c", "",
+)
+
+// ParseNumber implements NumberFormatter.
+// ParseNumber is lenient: ASCII digits are accepted in addition to the native digits,
+// and the units and the grouping separators are optional.
+func (l LocaleNumberFormatter) ParseNumber(str string) (*big.Rat, bool) {
+	s := localeNumberSymbols(l.Locale)
+	prefix, suffix := l.affixes(s)
+	prefix = strings.TrimSpace(numberTextReplacer.Replace(prefix))
+	suffix = strings.TrimSpace(numberTextReplacer.Replace(suffix))
+
+	str = strings.TrimSpace(numberTextReplacer.Replace(str))
+	str = strings.TrimSpace(strings.TrimPrefix(str, prefix))
+	str = strings.TrimSpace(strings.TrimSuffix(str, suffix))
+	if l.Format.Style == NumberStylePercent {
+		str = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(str, "%"), "\u066a"))
+	}
+
+	var b strings.Builder
+	for _, r := range str {
+		switch {
+		case r >= s.zero && r <= s.zero+9:
+			b.WriteRune('0' + r - s.zero)
+		case r == s.decimal:
+			b.WriteByte('.')
+		case r == s.group:
+		case unicode.IsSpace(s.group) && unicode.IsSpace(r):
+		case s.group == '’' && r == '\'':
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	v, ok := parseDecimal(b.String())
+	if !ok {
+		return nil, false
+	}
+	if l.Format.Style == NumberStylePercent {
+		v.Quo(v, ratHundred)
+	}
+	return v, true
+}
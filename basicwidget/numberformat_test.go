@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"math/big"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestLocaleNumberFormatterFormat(t *testing.T) {
+	testCases := []struct {
+		locale    string
+		format    basicwidget.NumberFormat
+		value     string
+		precision int
+		want      string
+	}{
+		{locale: "en", value: "1234567.5", precision: 2, want: "1,234,567.50"},
+		{locale: "de", value: "-1234567.5", precision: 1, want: "-1.234.567,5"},
+		{locale: "hi-IN", value: "1234567", precision: 0, want: "12,34,567"},
+		{locale: "ar", value: "1234.25", precision: 2, want: "١٬٢٣٤٫٢٥"},
+		{locale: "en", format: basicwidget.NumberFormat{Suffix: " px"}, value: "12", precision: 0, want: "12 px"},
+		{locale: "en", format: basicwidget.NumberFormat{Style: basicwidget.NumberStylePercent}, value: "0.255", precision: 3, want: "25.5%"},
+		{locale: "en", format: basicwidget.NumberFormat{NoGrouping: true}, value: "1234", precision: 0, want: "1234"},
+	}
+	for _, tc := range testCases {
+		v, _ := (&big.Rat{}).SetString(tc.value)
+		f := basicwidget.LocaleNumberFormatter{
+			Locale: language.MustParse(tc.locale),
+			Format: tc.format,
+		}
+		if got := f.FormatNumber(v, tc.precision); got != tc.want {
+			t.Errorf("FormatNumber(%s, %d) with %s: got: %q, want: %q", tc.value, tc.precision, tc.locale, got, tc.want)
+		}
+	}
+}
+
+func TestLocaleNumberFormatterParse(t *testing.T) {
+	testCases := []struct {
+		locale string
+		format basicwidget.NumberFormat
+		str    string
+		want   string
+		ok     bool
+	}{
+		{locale: "en", str: "1,234.5", want: "2469/2", ok: true},
+		{locale: "de", str: "1.234,5", want: "2469/2", ok: true},
+		{locale: "de", str: "1,5", want: "3/2", ok: true},
+		{locale: "hi-IN", str: "12,34,567", want: "1234567", ok: true},
+		{locale: "fr", str: "1 234,5", want: "2469/2", ok: true},
+		{locale: "ar", str: "٣٫٥", want: "7/2", ok: true},
+		{locale: "en", str: "１２３", want: "123", ok: true},
+		{locale: "en", format: basicwidget.NumberFormat{Suffix: " px"}, str: "12px", want: "12", ok: true},
+		{locale: "en", format: basicwidget.NumberFormat{Style: basicwidget.NumberStylePercent}, str: "25%", want: "1/4", ok: true},
+		{locale: "en", str: "abc", ok: false},
+		{locale: "en", str: "1/3", ok: false},
+		{locale: "en", str: "", ok: false},
+	}
+	for _, tc := range testCases {
+		f := basicwidget.LocaleNumberFormatter{
+			Locale: language.MustParse(tc.locale),
+			Format: tc.format,
+		}
+		got, ok := f.ParseNumber(tc.str)
+		if ok != tc.ok {
+			t.Errorf("ParseNumber(%q) with %s: got ok: %t, want: %t", tc.str, tc.locale, ok, tc.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got.RatString() != tc.want {
+			t.Errorf("ParseNumber(%q) with %s: got: %s, want: %s", tc.str, tc.locale, got.RatString(), tc.want)
+		}
+	}
+}
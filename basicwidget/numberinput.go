@@ -7,6 +7,7 @@ import (
 	"image"
 	"math"
 	"math/big"
+	"reflect"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
@@ -38,7 +39,10 @@ type NumberInput struct {
 	downButton Button
 
 	abstractNumberInput abstractNumberInput
-	nextValue           *big.Int
+	nextValue           *big.Rat
+
+	format    NumberFormat
+	formatter NumberFormatter
 }
 
 func (n *NumberInput) IsEditable() bool {
@@ -65,79 +69,94 @@ func (n *NumberInput) SetOnValueChangedUint64(f func(value uint64, committed boo
 	n.abstractNumberInput.SetOnValueChangedUint64(n, f)
 }
 
+func (n *NumberInput) SetOnValueChangedFloat64(f func(value float64, committed bool)) {
+	n.abstractNumberInput.SetOnValueChangedFloat64(n, f)
+}
+
+func (n *NumberInput) SetOnValueChangedBigRat(f func(value *big.Rat, committed bool)) {
+	n.abstractNumberInput.SetOnValueChangedBigRat(n, f)
+}
+
 func (n *NumberInput) SetOnKeyJustPressed(f func(key ebiten.Key) (handled bool)) {
 	n.textInput.SetOnKeyJustPressed(f)
 }
 
+// Value returns the integer part of the value.
 func (n *NumberInput) Value() int {
 	if n.nextValue != nil {
-		if n.nextValue.Cmp(&maxInt) > 0 {
-			return math.MaxInt
-		}
-		if n.nextValue.Cmp(&minInt) < 0 {
-			return math.MinInt
-		}
-		if n.nextValue.IsInt64() {
-			return int(n.nextValue.Int64())
-		}
-		return 0
+		return bigIntToInt(ratToInt(n.nextValue))
 	}
 	return n.abstractNumberInput.Value()
 }
 
 func (n *NumberInput) ValueBigInt() *big.Int {
 	if n.nextValue != nil {
-		return n.nextValue
+		return ratToInt(n.nextValue)
 	}
 	return n.abstractNumberInput.ValueBigInt()
 }
 
 func (n *NumberInput) ValueInt64() int64 {
 	if n.nextValue != nil {
-		if n.nextValue.IsInt64() {
-			return n.nextValue.Int64()
-		}
-		if n.nextValue.Cmp(&maxInt64) > 0 {
-			return math.MaxInt64
-		}
-		return math.MinInt64
+		return bigIntToInt64(ratToInt(n.nextValue))
 	}
 	return n.abstractNumberInput.ValueInt64()
 }
 
 func (n *NumberInput) ValueUint64() uint64 {
 	if n.nextValue != nil {
-		if n.nextValue.Cmp(&maxUint64) > 0 {
-			return math.MaxUint64
-		}
-		if n.nextValue.Sign() < 0 {
-			return 0
-		}
-		return n.nextValue.Uint64()
+		return bigIntToUint64(ratToInt(n.nextValue))
 	}
 	return n.abstractNumberInput.ValueUint64()
 }
 
-func (n *NumberInput) SetValueBigInt(value *big.Int) {
+func (n *NumberInput) ValueFloat64() float64 {
+	if n.nextValue != nil {
+		f, _ := n.nextValue.Float64()
+		return f
+	}
+	return n.abstractNumberInput.ValueFloat64()
+}
+
+func (n *NumberInput) ValueBigRat() *big.Rat {
+	if n.nextValue != nil {
+		return (&big.Rat{}).Set(n.nextValue)
+	}
+	return n.abstractNumberInput.ValueBigRat()
+}
+
+func (n *NumberInput) SetValueBigRat(value *big.Rat) {
 	if n.nextValue != nil && n.nextValue.Cmp(value) == 0 {
 		return
 	}
 	if n.nextValue == nil {
-		n.nextValue = &big.Int{}
+		n.nextValue = &big.Rat{}
 	}
 	n.nextValue.Set(value)
 }
 
 func (n *NumberInput) SetValue(value int) {
-	n.SetValueBigInt((&big.Int{}).SetInt64(int64(value)))
+	n.SetValueBigRat((&big.Rat{}).SetInt64(int64(value)))
+}
+
+func (n *NumberInput) SetValueBigInt(value *big.Int) {
+	n.SetValueBigRat((&big.Rat{}).SetInt(value))
 }
 
 func (n *NumberInput) SetValueInt64(value int64) {
-	n.SetValueBigInt((&big.Int{}).SetInt64(value))
+	n.SetValueBigRat((&big.Rat{}).SetInt64(value))
 }
 
 func (n *NumberInput) SetValueUint64(value uint64) {
-	n.SetValueBigInt((&big.Int{}).SetUint64(value))
+	n.SetValueBigRat((&big.Rat{}).SetUint64(value))
+}
+
+func (n *NumberInput) SetValueFloat64(value float64) {
+	v, ok := float64ToRat(value)
+	if !ok {
+		return
+	}
+	n.SetValueBigRat(v)
 }
 
 func (n *NumberInput) ForceSetValue(value int) {
@@ -156,6 +175,14 @@ func (n *NumberInput) ForceSetValueUint64(value uint64) {
 	n.abstractNumberInput.ForceSetValueUint64(n, value, true)
 }
 
+func (n *NumberInput) ForceSetValueFloat64(value float64) {
+	n.abstractNumberInput.ForceSetValueFloat64(n, value, true)
+}
+
+func (n *NumberInput) ForceSetValueBigRat(value *big.Rat) {
+	n.abstractNumberInput.ForceSetValueBigRat(n, value, true)
+}
+
 func (n *NumberInput) MinimumValueBigInt() *big.Int {
 	return n.abstractNumberInput.MinimumValueBigInt()
 }
@@ -176,6 +203,18 @@ func (n *NumberInput) SetMinimumValueUint64(minimum uint64) {
 	n.abstractNumberInput.SetMinimumValueUint64(n, minimum)
 }
 
+func (n *NumberInput) MinimumValueBigRat() *big.Rat {
+	return n.abstractNumberInput.MinimumValueBigRat()
+}
+
+func (n *NumberInput) SetMinimumValueFloat64(minimum float64) {
+	n.abstractNumberInput.SetMinimumValueFloat64(n, minimum)
+}
+
+func (n *NumberInput) SetMinimumValueBigRat(minimum *big.Rat) {
+	n.abstractNumberInput.SetMinimumValueBigRat(n, minimum)
+}
+
 func (n *NumberInput) MaximumValueBigInt() *big.Int {
 	return n.abstractNumberInput.MaximumValueBigInt()
 }
@@ -196,6 +235,18 @@ func (n *NumberInput) SetMaximumValueUint64(maximum uint64) {
 	n.abstractNumberInput.SetMaximumValueUint64(n, maximum)
 }
 
+func (n *NumberInput) MaximumValueBigRat() *big.Rat {
+	return n.abstractNumberInput.MaximumValueBigRat()
+}
+
+func (n *NumberInput) SetMaximumValueFloat64(maximum float64) {
+	n.abstractNumberInput.SetMaximumValueFloat64(n, maximum)
+}
+
+func (n *NumberInput) SetMaximumValueBigRat(maximum *big.Rat) {
+	n.abstractNumberInput.SetMaximumValueBigRat(n, maximum)
+}
+
 func (n *NumberInput) SetStep(step int) {
 	n.abstractNumberInput.SetStep(step)
 }
@@ -212,6 +263,65 @@ func (n *NumberInput) SetStepUint64(step uint64) {
 	n.abstractNumberInput.SetStepUint64(step)
 }
 
+func (n *NumberInput) SetStepFloat64(step float64) {
+	n.abstractNumberInput.SetStepFloat64(step)
+}
+
+func (n *NumberInput) SetStepBigRat(step *big.Rat) {
+	n.abstractNumberInput.SetStepBigRat(step)
+}
+
+// SetPrecision sets the number of digits after the decimal point.
+// The default precision is 0, which means the value is an integer.
+func (n *NumberInput) SetPrecision(precision int) {
+	n.abstractNumberInput.SetPrecision(n, precision)
+}
+
+// SetRoundingMode sets the rounding mode used to round a value to the precision.
+func (n *NumberInput) SetRoundingMode(mode RoundingMode) {
+	n.abstractNumberInput.SetRoundingMode(n, mode)
+}
+
+// SetNumberFormat sets the format used by the default formatter, like the style and the units.
+func (n *NumberInput) SetNumberFormat(format NumberFormat) {
+	if n.format == format {
+		return
+	}
+	n.format = format
+	guigui.RequestRedraw(n)
+}
+
+// SetNumberFormatter sets the formatter to format and parse the value.
+// If formatter is nil, a LocaleNumberFormatter with the first locale of the context is used.
+//
+// SetNumberFormatter does nothing if formatter equals the current formatter.
+// A formatter of a non-comparable type, e.g. a struct with a slice, is never regarded as equal and redraws the widget.
+// Set such a formatter once instead of at every Update.
+func (n *NumberInput) SetNumberFormatter(formatter NumberFormatter) {
+	if formatter == nil && n.formatter == nil {
+		return
+	}
+	if reflect.ValueOf(formatter).Comparable() && formatter == n.formatter {
+		return
+	}
+	n.formatter = formatter
+	guigui.RequestRedraw(n)
+}
+
+func (n *NumberInput) numberFormatter(context *guigui.Context) NumberFormatter {
+	if n.formatter != nil {
+		return n.formatter
+	}
+	return LocaleNumberFormatter{
+		Locale: n.textInput.text.lang(context),
+		Format: n.format,
+	}
+}
+
+func (n *NumberInput) valueText(context *guigui.Context) string {
+	return n.numberFormatter(context).FormatNumber(&n.abstractNumberInput.value, n.abstractNumberInput.Precision())
+}
+
 func (n *NumberInput) CommitWithCurrentInputValue() {
 	n.textInput.CommitWithCurrentInputValue()
 }
@@ -224,11 +334,12 @@ func (n *NumberInput) AddChildren(context *guigui.Context, adder *guigui.ChildAd
 
 func (n *NumberInput) Update(context *guigui.Context) error {
 	if n.nextValue != nil && !n.textInput.isFocused(context) && !context.IsFocused(n) {
-		n.abstractNumberInput.SetValueBigInt(n, n.nextValue, true)
+		n.abstractNumberInput.SetValueBigRat(n, n.nextValue, true)
 		n.nextValue = nil
 	}
 
-	n.abstractNumberInput.SetOnValueChangedString(n, func(text string, force bool) {
+	n.abstractNumberInput.SetOnValueChangedString(n, func(_ string, force bool) {
+		text := n.valueText(context)
 		if force {
 			n.textInput.ForceSetValue(text)
 		} else {
@@ -237,27 +348,20 @@ func (n *NumberInput) Update(context *guigui.Context) error {
 		n.nextValue = nil
 	})
 
-	n.textInput.SetValue(n.abstractNumberInput.ValueString())
+	n.textInput.SetValue(n.valueText(context))
 	n.textInput.SetHorizontalAlign(HorizontalAlignRight)
 	n.textInput.SetTabular(true)
 	n.textInput.setPaddingEnd(UnitSize(context) / 2)
 	n.textInput.SetOnValueChanged(func(text string, committed bool) {
-		n.abstractNumberInput.SetString(n, text, false, committed)
+		if v, ok := n.numberFormatter(context).ParseNumber(text); ok {
+			n.abstractNumberInput.setValue(n, v, false, committed)
+		}
 		if committed {
 			n.nextValue = nil
 		}
 	})
 
-	imgUp, err := theResourceImages.Get("keyboard_arrow_up", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgDown, err := theResourceImages.Get("keyboard_arrow_down", context.ColorMode())
-	if err != nil {
-		return err
-	}
-
-	n.upButton.SetIcon(imgUp)
+	n.upButton.SetVectorIcon(vectorIconKeyboardArrowUp)
 	n.upButton.setSharpenCorners(draw.SharpenCorners{
 		LowerStart: true,
 		LowerEnd:   true,
@@ -268,7 +372,7 @@ func (n *NumberInput) Update(context *guigui.Context) error {
 	})
 	context.SetEnabled(&n.upButton, n.IsEditable() && n.abstractNumberInput.CanIncrement())
 
-	n.downButton.SetIcon(imgDown)
+	n.downButton.SetVectorIcon(vectorIconKeyboardArrowDown)
 	n.downButton.setSharpenCorners(draw.SharpenCorners{
 		UpperStart: true,
 		UpperEnd:   true,
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestRoundDecimal(t *testing.T) {
+	testCases := []struct {
+		str       string
+		precision int
+		mode      basicwidget.RoundingMode
+		want      string
+	}{
+		{str: "1.25", precision: 1, mode: basicwidget.RoundingModeHalfAwayFromZero, want: "1.3"},
+		{str: "-1.25", precision: 1, mode: basicwidget.RoundingModeHalfAwayFromZero, want: "-1.3"},
+		{str: "1.25", precision: 1, mode: basicwidget.RoundingModeHalfEven, want: "1.2"},
+		{str: "1.35", precision: 1, mode: basicwidget.RoundingModeHalfEven, want: "1.4"},
+		{str: "1.29", precision: 1, mode: basicwidget.RoundingModeTowardZero, want: "1.2"},
+		{str: "-1.29", precision: 1, mode: basicwidget.RoundingModeTowardZero, want: "-1.2"},
+		{str: "1.21", precision: 1, mode: basicwidget.RoundingModeAwayFromZero, want: "1.3"},
+		{str: "-1.21", precision: 1, mode: basicwidget.RoundingModeFloor, want: "-1.3"},
+		{str: "1.21", precision: 1, mode: basicwidget.RoundingModeFloor, want: "1.2"},
+		{str: "1.21", precision: 1, mode: basicwidget.RoundingModeCeiling, want: "1.3"},
+		{str: "2.5", precision: 0, mode: basicwidget.RoundingModeHalfEven, want: "2"},
+		{str: "0.125", precision: 2, mode: basicwidget.RoundingModeHalfAwayFromZero, want: "0.13"},
+		{str: "3", precision: 2, mode: basicwidget.RoundingModeHalfAwayFromZero, want: "3.00"},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.RoundDecimal(tc.str, tc.precision, tc.mode); got != tc.want {
+			t.Errorf("RoundDecimal(%q, %d, %d): got: %q, want: %q", tc.str, tc.precision, tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	testCases := []struct {
+		str  string
+		want string
+		ok   bool
+	}{
+		{str: "-12", want: "-12", ok: true},
+		{str: "+3.25", want: "13/4", ok: true},
+		{str: ".5", want: "1/2", ok: true},
+		{str: "5.", want: "5", ok: true},
+		{str: "1e-3", want: "1/1000", ok: true},
+		{str: "2.5E+2", want: "250", ok: true},
+		{str: "", ok: false},
+		{str: ".", ok: false},
+		{str: "-", ok: false},
+		{str: "1/3", ok: false},
+		{str: "0x10", ok: false},
+		{str: "0b101", ok: false},
+		{str: "0o17", ok: false},
+		{str: "1_000", ok: false},
+		{str: "1e", ok: false},
+		{str: "e3", ok: false},
+		{str: "1e1000000", ok: false},
+		{str: "1e-99999999999999999999", ok: false},
+		{str: " 1", ok: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.ParseDecimal(tc.str)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("ParseDecimal(%q): got: %q, %v, want: %q, %v", tc.str, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -112,6 +112,25 @@ func (p *Panel) contentSize(context *guigui.Context) image.Point {
 	}
 }
 
+// Measure returns the content's size within constraints. The content beyond the constraints is scrolled.
+func (p *Panel) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	if p.content == nil {
+		return p.DefaultWidget.Measure(context, constraints)
+	}
+	var contentConstraints guigui.Constraints
+	switch p.contentConstraints {
+	case PanelContentConstraintsFixedWidth:
+		if w, ok := constraints.MaxWidth(); ok {
+			contentConstraints = guigui.FixedWidthConstraints(w)
+		}
+	case PanelContentConstraintsFixedHeight:
+		if h, ok := constraints.MaxHeight(); ok {
+			contentConstraints = guigui.FixedHeightConstraints(h)
+		}
+	}
+	return constraints.Constrain(context.Measure(p.content, contentConstraints))
+}
+
 func (p *Panel) Update(context *guigui.Context) error {
 	if p.content == nil {
 		return nil
@@ -202,13 +221,17 @@ func (p *panelBorder) Draw(context *guigui.Context, dst *ebiten.Image) {
 		r = p.scrollOverlay.scrollRange(context)
 	}
 	clr := draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.8)
-	if (p.scrollOverlay != nil && p.autoBorder && offsetX < float64(r.Max.X)) || p.borders.Start {
+	left, right := p.borders.Start, p.borders.End
+	if context.IsRightToLeft() {
+		left, right = right, left
+	}
+	if (p.scrollOverlay != nil && p.autoBorder && offsetX < float64(r.Max.X)) || left {
 		vector.StrokeLine(dst, x0+strokeWidth/2, y0, x0+strokeWidth/2, y1, strokeWidth, clr, false)
 	}
 	if (p.scrollOverlay != nil && p.autoBorder && offsetY < float64(r.Max.Y)) || p.borders.Top {
 		vector.StrokeLine(dst, x0, y0+strokeWidth/2, x1, y0+strokeWidth/2, strokeWidth, clr, false)
 	}
-	if (p.scrollOverlay != nil && p.autoBorder && offsetX > float64(r.Min.X)) || p.borders.End {
+	if (p.scrollOverlay != nil && p.autoBorder && offsetX > float64(r.Min.X)) || right {
 		vector.StrokeLine(dst, x1-strokeWidth/2, y0, x1-strokeWidth/2, y1, strokeWidth, clr, false)
 	}
 	if (p.scrollOverlay != nil && p.autoBorder && offsetY > float64(r.Min.Y)) || p.borders.Bottom {
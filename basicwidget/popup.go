@@ -58,6 +58,28 @@ type Popup struct {
 	nextContentPosition    image.Point
 	hasNextContentPosition bool
 	openAfterClose         bool
+	keepFocus              bool
+}
+
+// popupBoundsForAnchor returns the bounds of a popup of size below anchor, e.g. a text input.
+// The popup is placed above anchor if there is no room below anchor.
+func popupBoundsForAnchor(context *guigui.Context, anchor image.Rectangle, size image.Point) image.Rectangle {
+	gap := UnitSize(context) / 8
+	r := image.Rectangle{
+		Min: image.Pt(anchor.Min.X, anchor.Max.Y+gap),
+	}
+	r.Max = r.Min.Add(size)
+
+	as := context.AppBounds()
+	if r.Max.Y > as.Max.Y && anchor.Min.Y-gap-size.Y >= as.Min.Y {
+		r.Min.Y = anchor.Min.Y - gap - size.Y
+		r.Max.Y = anchor.Min.Y - gap
+	}
+	if r.Max.X > as.Max.X {
+		r.Min.X = max(as.Max.X-size.X, as.Min.X)
+		r.Max.X = r.Min.X + size.X
+	}
+	return r
 }
 
 func (p *Popup) IsOpen() bool {
@@ -101,6 +123,12 @@ func (p *Popup) SetAnimationDuringFade(animateOnFading bool) {
 	p.animateOnFading = animateOnFading
 }
 
+// setKeepFocus makes the popup not take the focus when opened.
+// This is useful when the popup shows suggestions for a focused widget like a text input.
+func (p *Popup) setKeepFocus(keepFocus bool) {
+	p.keepFocus = keepFocus
+}
+
 func (p *Popup) SetOnClosed(f func(reason PopupClosedReason)) {
 	guigui.RegisterEventHandler(p, popupEventClosed, f)
 }
@@ -195,7 +223,7 @@ func (p *Popup) IsWidgetOrBackgroundHitAtCursor(context *guigui.Context, target
 	if context.IsWidgetHitAtCursor(target) {
 		return true
 	}
-	if context.IsWidgetHitAtCursor(&p.background) && image.Pt(ebiten.CursorPosition()).In(context.VisibleBounds(target)) {
+	if context.IsWidgetHitAtCursor(&p.background) && context.PointerPosition().In(context.VisibleBounds(target)) {
 		return true
 	}
 	return false
@@ -203,7 +231,9 @@ func (p *Popup) IsWidgetOrBackgroundHitAtCursor(context *guigui.Context, target
 
 func (p *Popup) Tick(context *guigui.Context) error {
 	if p.showing {
-		context.SetFocused(p, true)
+		if !p.keepFocus {
+			context.SetFocused(p, true)
+		}
 		if p.openingCount < popupMaxOpeningCount() {
 			p.openingCount += 3
 			p.openingCount = min(p.openingCount, popupMaxOpeningCount())
@@ -226,7 +256,9 @@ func (p *Popup) Tick(context *guigui.Context) error {
 			p.openingCount = max(p.openingCount, 0)
 		}
 		if p.openingCount == 0 {
-			context.SetFocused(p, false)
+			if !p.keepFocus {
+				context.SetFocused(p, false)
+			}
 			p.hiding = false
 			guigui.DispatchEventHandler(p, popupEventClosed, p.closedReason)
 			p.closedReason = PopupClosedReasonNone
@@ -337,7 +369,7 @@ func (p *popupBackground) HandlePointingInput(context *guigui.Context) guigui.Ha
 
 	if context.IsWidgetHitAtCursor(p) {
 		if p.popup.closeByClickingOutside {
-			if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+			if context.IsPointerJustPressed() || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
 				p.popup.close(PopupClosedReasonClickOutside)
 				// Continue handling inputs so that clicking a right button can be handled by other widgets.
 				if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
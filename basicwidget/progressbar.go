@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+type ProgressBarStyle int
+
+const (
+	ProgressBarStyleHorizontal ProgressBarStyle = iota
+	ProgressBarStyleCircular
+)
+
+// ProgressBar shows the progress of an operation.
+type ProgressBar struct {
+	guigui.DefaultWidget
+
+	label Text
+
+	value         float64
+	max           float64
+	maxSet        bool
+	indeterminate bool
+	style         ProgressBarStyle
+
+	count int
+}
+
+// SetValue sets the current progress. The value is clamped between 0 and the maximum value.
+func (p *ProgressBar) SetValue(value float64) {
+	if p.value == value {
+		return
+	}
+	p.value = value
+	guigui.RequestRedraw(p)
+}
+
+func (p *ProgressBar) Value() float64 {
+	return p.value
+}
+
+// SetMaximum sets the value of the completed progress. The default maximum value is 1.
+func (p *ProgressBar) SetMaximum(maximum float64) {
+	if p.maxSet && p.max == maximum {
+		return
+	}
+	p.max = maximum
+	p.maxSet = true
+	guigui.RequestRedraw(p)
+}
+
+func (p *ProgressBar) Maximum() float64 {
+	if !p.maxSet {
+		return 1
+	}
+	return p.max
+}
+
+// Rate returns the rate of the progress in [0, 1].
+func (p *ProgressBar) Rate() float64 {
+	m := p.Maximum()
+	if m <= 0 {
+		return 0
+	}
+	return min(max(p.value/m, 0), 1)
+}
+
+// SetIndeterminate sets whether the progress is unknown.
+// An indeterminate progress bar shows an animation instead of the value.
+func (p *ProgressBar) SetIndeterminate(indeterminate bool) {
+	if p.indeterminate == indeterminate {
+		return
+	}
+	p.indeterminate = indeterminate
+	p.count = 0
+	guigui.RequestRedraw(p)
+}
+
+func (p *ProgressBar) IsIndeterminate() bool {
+	return p.indeterminate
+}
+
+func (p *ProgressBar) SetStyle(style ProgressBarStyle) {
+	if p.style == style {
+		return
+	}
+	p.style = style
+	guigui.RequestRedraw(p)
+}
+
+// SetLabel sets the text shown with the bar, e.g. "42%".
+// The label is shown at the end of a horizontal bar, or at the center of a circular bar.
+func (p *ProgressBar) SetLabel(label string) {
+	if p.label.Value() == label {
+		return
+	}
+	p.label.SetValue(label)
+	// The track's bounds depend on the label's width.
+	guigui.RequestRedraw(p)
+}
+
+func (p *ProgressBar) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	if p.label.Value() != "" {
+		adder.AddChild(&p.label)
+	}
+}
+
+func (p *ProgressBar) Update(context *guigui.Context) error {
+	p.label.SetTabular(true)
+	p.label.SetVerticalAlign(VerticalAlignMiddle)
+	p.label.SetColor(draw.TextColor(context.ColorMode(), context.IsEnabled(p)))
+	switch p.style {
+	case ProgressBarStyleHorizontal:
+		p.label.SetHorizontalAlign(HorizontalAlignEnd)
+	case ProgressBarStyleCircular:
+		p.label.SetHorizontalAlign(HorizontalAlignCenter)
+	}
+	return nil
+}
+
+func (p *ProgressBar) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	if widget != &p.label {
+		return image.Rectangle{}
+	}
+	b := context.Bounds(p)
+	if p.style == ProgressBarStyleCircular {
+		return b
+	}
+	r := b
+	r.Min.X = b.Max.X - p.labelWidth(context)
+	if context.IsRightToLeft() {
+		r = guigui.MirrorRectangle(r, b)
+	}
+	return r
+}
+
+func (p *ProgressBar) labelWidth(context *guigui.Context) int {
+	if p.label.Value() == "" {
+		return 0
+	}
+	return p.label.Measure(context, guigui.Constraints{}).X
+}
+
+func (p *ProgressBar) Tick(context *guigui.Context) error {
+	if !p.indeterminate || !isOnScreen(context, p) {
+		return nil
+	}
+	p.count++
+	guigui.RequestRedraw(p)
+	return nil
+}
+
+// animationRate returns the phase of the indeterminate animation in [0, 1).
+func (p *ProgressBar) animationRate() float64 {
+	period := ebiten.TPS() * 3 / 2
+	return float64(p.count%period) / float64(period)
+}
+
+func progressBarThickness(context *guigui.Context) int {
+	return UnitSize(context) / 4
+}
+
+func (p *ProgressBar) trackBounds(context *guigui.Context) image.Rectangle {
+	b := context.Bounds(p)
+	r := b
+	if w := p.labelWidth(context); w > 0 {
+		r.Max.X -= w + UnitSize(context)/4
+	}
+	t := progressBarThickness(context)
+	r.Min.Y = b.Min.Y + (b.Dy()-t)/2
+	r.Max.Y = r.Min.Y + t
+	if context.IsRightToLeft() {
+		r = guigui.MirrorRectangle(r, b)
+	}
+	return r
+}
+
+func (p *ProgressBar) Draw(context *guigui.Context, dst *ebiten.Image) {
+	switch p.style {
+	case ProgressBarStyleHorizontal:
+		p.drawHorizontal(context, dst)
+	case ProgressBarStyleCircular:
+		p.drawCircular(context, dst)
+	}
+}
+
+func (p *ProgressBar) colors(context *guigui.Context) (on, off color.Color) {
+	on = draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
+	off = draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.8)
+	if !context.IsEnabled(p) {
+		on = draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.6)
+	}
+	return on, off
+}
+
+func (p *ProgressBar) drawHorizontal(context *guigui.Context, dst *ebiten.Image) {
+	tb := p.trackBounds(context)
+	if tb.Dx() <= 0 || tb.Dy() <= 0 {
+		return
+	}
+	r := tb.Dy() / 2
+	on, off := p.colors(context)
+	draw.DrawRoundedRect(context, dst, tb, off, r)
+
+	var fill image.Rectangle
+	if p.indeterminate {
+		// A stripe of a third of the track moves from the start to the end.
+		w := tb.Dx() / 3
+		x := tb.Min.X - w + int(float64(tb.Dx()+w)*p.animationRate())
+		fill = image.Rect(x, tb.Min.Y, x+w, tb.Max.Y)
+	} else {
+		fill = tb
+		fill.Max.X = tb.Min.X + int(float64(tb.Dx())*p.Rate())
+	}
+	if context.IsRightToLeft() {
+		fill = guigui.MirrorRectangle(fill, tb)
+	}
+	if fill.Dx() > 0 {
+		draw.DrawRoundedRect(context, dst.SubImage(tb).(*ebiten.Image), fill, on, r)
+	}
+
+	borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset, false)
+	draw.DrawRoundedRectBorder(context, dst, tb, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+}
+
+func (p *ProgressBar) drawCircular(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(p)
+	t := float32(progressBarThickness(context)) / 2
+	radius := float32(min(b.Dx(), b.Dy()))/2 - t
+	if radius <= 0 {
+		return
+	}
+	cx := float32(b.Min.X+b.Max.X) / 2
+	cy := float32(b.Min.Y+b.Max.Y) / 2
+	on, off := p.colors(context)
+	vector.StrokeCircle(dst, cx, cy, radius, t, off, true)
+
+	if p.indeterminate {
+		start := float32(2 * math.Pi * p.animationRate())
+		draw.DrawArc(dst, cx, cy, radius, start, start+math.Pi/2, t, on)
+		return
+	}
+	if rate := p.Rate(); rate > 0 {
+		// The arc starts at the top.
+		start := float32(-math.Pi / 2)
+		draw.DrawArc(dst, cx, cy, radius, start, start+float32(2*math.Pi*rate), t, on)
+	}
+}
+
+func (p *ProgressBar) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	u := UnitSize(context)
+	var labelSize image.Point
+	if p.label.Value() != "" {
+		labelSize = p.label.Measure(context, guigui.Constraints{})
+	}
+	switch p.style {
+	case ProgressBarStyleCircular:
+		s := max(2*u, labelSize.X+u/2)
+		return image.Pt(s, s)
+	default:
+		w := 6 * u
+		if labelSize.X > 0 {
+			w += labelSize.X + u/4
+		}
+		if fw, ok := constraints.FixedWidth(); ok {
+			w = fw
+		}
+		return image.Pt(w, max(progressBarThickness(context), labelSize.Y))
+	}
+}
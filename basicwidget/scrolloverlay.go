@@ -5,10 +5,10 @@ package basicwidget
 
 import (
 	"image"
+	"math"
 	"runtime"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
@@ -60,13 +60,21 @@ type scrollOverlay struct {
 	lastWheelY              float64
 	lastOffsetX             float64
 	lastOffsetY             float64
+	drag                    guigui.DragRecognizer
 	draggingX               bool
 	draggingY               bool
-	draggingStartPosition   image.Point
 	draggingStartOffsetX    float64
 	draggingStartOffsetY    float64
 	onceBuilt               bool
 
+	// touchPressed is true when a touch is pressed on the overlay out of the bars, which might start panning.
+	touchPressed      bool
+	touchLastPosition image.Point
+
+	// velocityX and velocityY are the velocity of panning in pixels per tick, used for kinetic scrolling.
+	velocityX float64
+	velocityY float64
+
 	barCount int
 }
 
@@ -130,9 +138,8 @@ func adjustedWheel() (float64, float64) {
 func (s *scrollOverlay) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
 	hovered := context.IsWidgetHitAtCursor(s)
 	if hovered {
-		x, y := ebiten.CursorPosition()
 		dx, dy := adjustedWheel()
-		s.lastCursorPositionPlus1 = image.Pt(x, y).Add(image.Pt(1, 1))
+		s.lastCursorPositionPlus1 = context.PointerPosition().Add(image.Pt(1, 1))
 		s.lastWheelX = dx
 		s.lastWheelY = dy
 	} else {
@@ -141,17 +148,30 @@ func (s *scrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 		s.lastWheelY = 0
 	}
 
-	if !s.draggingX && !s.draggingY && hovered && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
+	// Capture the pointer after a drag starts so that the content can handle a tap.
+	s.drag.CaptureAfterStart = true
+	s.drag.Update(context, s)
+
+	if s.drag.JustPressed() {
+		s.touchPressed = false
+		pos := s.drag.StartPosition()
 		hb, vb := s.barBounds(context)
-		if image.Pt(x, y).In(hb) {
-			s.setDragging(true, s.draggingY)
-			s.draggingStartPosition.X = x
+		switch {
+		case pos.In(hb):
+			s.setDragging(true, false)
 			s.draggingStartOffsetX = s.offsetX
-		} else if image.Pt(x, y).In(vb) {
-			s.setDragging(s.draggingX, true)
-			s.draggingStartPosition.Y = y
+		case pos.In(vb):
+			s.setDragging(false, true)
 			s.draggingStartOffsetY = s.offsetY
+		case context.IsPointerTouch():
+			s.touchPressed = true
+			s.touchLastPosition = pos
+		default:
+			// Leave a mouse press out of the bars to the content, e.g. for selecting text by dragging.
+			s.drag.Cancel(context, s)
+		}
+		if s.draggingX || s.draggingY || s.touchPressed {
+			s.stopKineticScroll()
 		}
 		if s.draggingX || s.draggingY {
 			return guigui.HandleInputByWidget(s)
@@ -159,17 +179,21 @@ func (s *scrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 	}
 
 	if dx, dy := adjustedWheel(); dx != 0 || dy != 0 {
+		s.drag.Cancel(context, s)
+	}
+
+	if !s.drag.IsPressed() {
 		s.setDragging(false, false)
 	}
 
-	if (s.draggingX || s.draggingY) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
+	if s.draggingX || s.draggingY {
+		delta := s.drag.Delta()
 		var dx, dy float64
 		if s.draggingX {
-			dx = float64(x - s.draggingStartPosition.X)
+			dx = float64(delta.X)
 		}
 		if s.draggingY {
-			dy = float64(y - s.draggingStartPosition.Y)
+			dy = float64(delta.Y)
 		}
 		if dx != 0 || dy != 0 {
 			prevOffsetX := s.offsetX
@@ -194,24 +218,17 @@ func (s *scrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 		return guigui.HandleInputByWidget(s)
 	}
 
-	if (s.draggingX || s.draggingY) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		s.setDragging(false, false)
+	if r, ok := s.handleTouchPanning(context); ok {
+		return r
 	}
 
 	if dx, dy := adjustedWheel(); dx != 0 || dy != 0 {
 		if !hovered {
 			return guigui.HandleInputResult{}
 		}
-		s.setDragging(false, false)
+		s.stopKineticScroll()
 
-		prevOffsetX := s.offsetX
-		prevOffsetY := s.offsetY
-		s.offsetX += dx * 4 * context.Scale()
-		s.offsetY += dy * 4 * context.Scale()
-		s.adjustOffset(context)
-		if prevOffsetX != s.offsetX || prevOffsetY != s.offsetY {
-			guigui.DispatchEventHandler(s, scrollOverlayEventScroll, s.offsetX, s.offsetY)
-			guigui.RequestRedraw(s)
+		if s.scrollBy(context, dx*4*context.Scale(), dy*4*context.Scale()) {
 			return guigui.HandleInputByWidget(s)
 		}
 		return guigui.HandleInputResult{}
@@ -220,10 +237,89 @@ func (s *scrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 	return guigui.HandleInputResult{}
 }
 
+// handleTouchPanning scrolls the content by dragging it with a touch.
+//
+// A press is not handled so that a tap works for the content.
+// Panning starts when the touch moves farther than the drag threshold,
+// and then the drag recognizer captures the pointer so that the content no longer handles the touch.
+func (s *scrollOverlay) handleTouchPanning(context *guigui.Context) (guigui.HandleInputResult, bool) {
+	if !s.touchPressed {
+		return guigui.HandleInputResult{}, false
+	}
+
+	if s.drag.JustEnded() {
+		// Keep the velocity for kinetic scrolling.
+		s.touchPressed = false
+		return guigui.HandleInputByWidget(s), true
+	}
+	if !s.drag.IsPressed() {
+		// The touch is released without panning, or the press is discarded.
+		s.touchPressed = false
+		s.stopKineticScroll()
+		return guigui.HandleInputResult{}, false
+	}
+	if !s.drag.IsDragging() {
+		return guigui.HandleInputResult{}, false
+	}
+
+	pos := s.drag.Position()
+	d := pos.Sub(s.touchLastPosition)
+	s.touchLastPosition = pos
+	// Smooth the velocity as touch positions are jittery.
+	s.velocityX = (s.velocityX + float64(d.X)) / 2
+	s.velocityY = (s.velocityY + float64(d.Y)) / 2
+	s.scrollBy(context, float64(d.X), float64(d.Y))
+	return guigui.HandleInputByWidget(s), true
+}
+
+// scrollBy scrolls the content by (dx, dy), and reports whether the offset is changed.
+func (s *scrollOverlay) scrollBy(context *guigui.Context, dx, dy float64) bool {
+	prevOffsetX := s.offsetX
+	prevOffsetY := s.offsetY
+	s.offsetX += dx
+	s.offsetY += dy
+	s.adjustOffset(context)
+	if prevOffsetX == s.offsetX && prevOffsetY == s.offsetY {
+		return false
+	}
+	guigui.DispatchEventHandler(s, scrollOverlayEventScroll, s.offsetX, s.offsetY)
+	guigui.RequestRedraw(s)
+	return true
+}
+
+// isTouchPanning reports whether the content is being panned by a touch.
+func (s *scrollOverlay) isTouchPanning() bool {
+	return s.touchPressed && s.drag.IsDragging()
+}
+
+func (s *scrollOverlay) stopKineticScroll() {
+	s.velocityX = 0
+	s.velocityY = 0
+}
+
+// tickKineticScroll scrolls the content with the velocity after panning, and decelerates the velocity.
+func (s *scrollOverlay) tickKineticScroll(context *guigui.Context) {
+	if s.isTouchPanning() || (s.velocityX == 0 && s.velocityY == 0) {
+		return
+	}
+	if !s.scrollBy(context, s.velocityX, s.velocityY) {
+		// The content reaches the edge.
+		s.stopKineticScroll()
+		return
+	}
+	// Decelerate the velocity independently of TPS, so that the velocity becomes about 5% per second.
+	f := math.Pow(0.05, 1/float64(ebiten.TPS()))
+	s.velocityX *= f
+	s.velocityY *= f
+	if math.Abs(s.velocityX) < 0.1 && math.Abs(s.velocityY) < 0.1 {
+		s.stopKineticScroll()
+	}
+}
+
 func (s *scrollOverlay) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
-	x, y := ebiten.CursorPosition()
+	pos := context.PointerPosition()
 	hb, vb := s.barBounds(context)
-	if image.Pt(x, y).In(hb) || image.Pt(x, y).In(vb) {
+	if pos.In(hb) || pos.In(vb) {
 		return ebiten.CursorShapeDefault, true
 	}
 	return 0, false
@@ -262,7 +358,7 @@ func (s *scrollOverlay) isBarVisible(context *guigui.Context) bool {
 		return false
 	}
 
-	if s.draggingX || s.draggingY {
+	if s.draggingX || s.draggingY || s.isTouchPanning() {
 		return true
 	}
 	if s.lastWheelX != 0 || s.lastWheelY != 0 {
@@ -319,6 +415,8 @@ func (s *scrollOverlay) showBars(context *guigui.Context) {
 }
 
 func (s *scrollOverlay) Tick(context *guigui.Context) error {
+	s.tickKineticScroll(context)
+
 	shouldShowBar := s.isBarVisible(context)
 
 	if s.lastOffsetX != s.offsetX || s.lastOffsetY != s.offsetY {
@@ -23,8 +23,12 @@ type SegmentedControlItem[T comparable] struct {
 	Text      string
 	Icon      *ebiten.Image
 	IconAlign IconAlign
-	Disabled  bool
-	Value     T
+
+	// IconName is the name of a registered icon. IconName is used when Icon is nil.
+	IconName string
+
+	Disabled bool
+	Value    T
 }
 
 func (s SegmentedControlItem[T]) value() T {
@@ -98,7 +102,11 @@ func (s *SegmentedControl[T]) updateButtons(context *guigui.Context) {
 	for i := range s.abstractList.ItemCount() {
 		item, _ := s.abstractList.ItemByIndex(i)
 		s.buttons[i].SetText(item.Text)
-		s.buttons[i].SetIcon(item.Icon)
+		if item.Icon == nil && item.IconName != "" {
+			s.buttons[i].SetIconName(item.IconName)
+		} else {
+			s.buttons[i].SetIcon(item.Icon)
+		}
 		s.buttons[i].SetIconAlign(item.IconAlign)
 		s.buttons[i].SetTextBold(s.abstractList.SelectedItemIndex() == i)
 		s.buttons[i].setUseAccentColor(true)
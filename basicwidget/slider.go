@@ -15,14 +15,41 @@ import (
 	"github.com/guigui-gui/guigui"
 )
 
+const (
+	sliderEventRangeChanged        = "rangeChanged"
+	sliderEventRangeChangedFloat64 = "rangeChangedFloat64"
+	sliderEventRangeChangedBigRat  = "rangeChangedBigRat"
+)
+
+type sliderThumb int
+
+const (
+	sliderThumbLower sliderThumb = iota
+	sliderThumbUpper
+)
+
 type Slider struct {
 	guigui.DefaultWidget
 
+	tickLabels   []Text
+	valueTooltip sliderValueTooltip
+
 	abstractNumberInput abstractNumberInput
 
+	rangeMode           bool
+	upperValue          big.Rat
+	vertical            bool
+	ticks               []SliderTick
+	snapToTicks         bool
+	valueTooltipEnabled bool
+
+	drag               guigui.DragRecognizer
 	dragging           bool
-	draggingStartValue big.Int
-	draggingStartX     int
+	draggingStartValue big.Rat
+	draggingStartPos   int
+
+	// activeThumb is the thumb dragged or moved by the keyboard.
+	activeThumb sliderThumb
 
 	prevThumbHovered bool
 }
@@ -67,6 +94,26 @@ func (s *Slider) SetOnValueChangedUint64(f func(value uint64)) {
 	})
 }
 
+func (s *Slider) SetOnValueChangedFloat64(f func(value float64)) {
+	if f == nil {
+		s.abstractNumberInput.SetOnValueChangedFloat64(s, nil)
+		return
+	}
+	s.abstractNumberInput.SetOnValueChangedFloat64(s, func(value float64, committed bool) {
+		f(value)
+	})
+}
+
+func (s *Slider) SetOnValueChangedBigRat(f func(value *big.Rat)) {
+	if f == nil {
+		s.abstractNumberInput.SetOnValueChangedBigRat(s, nil)
+		return
+	}
+	s.abstractNumberInput.SetOnValueChangedBigRat(s, func(value *big.Rat, committed bool) {
+		f(value)
+	})
+}
+
 func (s *Slider) Value() int {
 	return s.abstractNumberInput.Value()
 }
@@ -83,38 +130,60 @@ func (s *Slider) ValueUint64() uint64 {
 	return s.abstractNumberInput.ValueUint64()
 }
 
+func (s *Slider) ValueFloat64() float64 {
+	return s.abstractNumberInput.ValueFloat64()
+}
+
+func (s *Slider) ValueBigRat() *big.Rat {
+	return s.abstractNumberInput.ValueBigRat()
+}
+
 func (s *Slider) SetValue(value int) {
-	changed := value != s.abstractNumberInput.Value()
-	s.abstractNumberInput.SetValue(s, value, true)
-	if changed {
-		guigui.RequestRedraw(s)
-	}
+	s.SetValueBigRat((&big.Rat{}).SetInt64(int64(value)))
 }
 
 func (s *Slider) SetValueBigInt(value *big.Int) {
-	changed := value.Cmp(s.abstractNumberInput.ValueBigInt()) != 0
-	s.abstractNumberInput.SetValueBigInt(s, value, true)
-	if changed {
-		guigui.RequestRedraw(s)
-	}
+	s.SetValueBigRat((&big.Rat{}).SetInt(value))
 }
 
 func (s *Slider) SetValueInt64(value int64) {
-	changed := value != s.abstractNumberInput.ValueInt64()
-	s.abstractNumberInput.SetValueInt64(s, value, true)
-	if changed {
-		guigui.RequestRedraw(s)
-	}
+	s.SetValueBigRat((&big.Rat{}).SetInt64(value))
 }
 
 func (s *Slider) SetValueUint64(value uint64) {
-	changed := value != s.abstractNumberInput.ValueUint64()
-	s.abstractNumberInput.SetValueUint64(s, value, true)
-	if changed {
-		guigui.RequestRedraw(s)
+	s.SetValueBigRat((&big.Rat{}).SetUint64(value))
+}
+
+func (s *Slider) SetValueFloat64(value float64) {
+	v, ok := float64ToRat(value)
+	if !ok {
+		return
+	}
+	s.SetValueBigRat(v)
+}
+
+func (s *Slider) SetValueBigRat(value *big.Rat) {
+	if s.setLowerValue(value) && s.rangeMode {
+		s.fireRangeChangeEvents()
 	}
 }
 
+// setLowerValue sets the value, or the lower value in the range mode, and reports whether the value is changed.
+func (s *Slider) setLowerValue(value *big.Rat) bool {
+	if s.rangeMode {
+		if upper := s.actualUpperValue(); value.Cmp(upper) > 0 {
+			value = upper
+		}
+	}
+	prev := s.abstractNumberInput.ValueBigRat()
+	s.abstractNumberInput.SetValueBigRat(s, value, true)
+	if prev.Cmp(&s.abstractNumberInput.value) == 0 {
+		return false
+	}
+	guigui.RequestRedraw(s)
+	return true
+}
+
 func (s *Slider) MinimumValueBigInt() *big.Int {
 	return s.abstractNumberInput.MinimumValueBigInt()
 }
@@ -155,107 +224,513 @@ func (s *Slider) SetMaximumValueUint64(maximum uint64) {
 	s.abstractNumberInput.SetMaximumValueUint64(s, maximum)
 }
 
+func (s *Slider) MinimumValueBigRat() *big.Rat {
+	return s.abstractNumberInput.MinimumValueBigRat()
+}
+
+func (s *Slider) SetMinimumValueFloat64(minimum float64) {
+	s.abstractNumberInput.SetMinimumValueFloat64(s, minimum)
+}
+
+func (s *Slider) SetMinimumValueBigRat(minimum *big.Rat) {
+	s.abstractNumberInput.SetMinimumValueBigRat(s, minimum)
+}
+
+func (s *Slider) MaximumValueBigRat() *big.Rat {
+	return s.abstractNumberInput.MaximumValueBigRat()
+}
+
+func (s *Slider) SetMaximumValueFloat64(maximum float64) {
+	s.abstractNumberInput.SetMaximumValueFloat64(s, maximum)
+}
+
+func (s *Slider) SetMaximumValueBigRat(maximum *big.Rat) {
+	s.abstractNumberInput.SetMaximumValueBigRat(s, maximum)
+}
+
+// SetStep sets the step of the value. A dragged value snaps to the multiple of the step from the minimum value.
+func (s *Slider) SetStep(step int) {
+	s.abstractNumberInput.SetStep(step)
+}
+
+func (s *Slider) SetStepFloat64(step float64) {
+	s.abstractNumberInput.SetStepFloat64(step)
+}
+
+func (s *Slider) SetStepBigRat(step *big.Rat) {
+	s.abstractNumberInput.SetStepBigRat(step)
+}
+
+// SetPrecision sets the number of digits after the decimal point.
+// The default precision is 0, which means the value is an integer.
+func (s *Slider) SetPrecision(precision int) {
+	s.abstractNumberInput.SetPrecision(s, precision)
+}
+
+func (s *Slider) SetRoundingMode(mode RoundingMode) {
+	s.abstractNumberInput.SetRoundingMode(s, mode)
+}
+
+// SetRangeMode enables the range mode, where the slider has two thumbs for the lower and the upper values.
+// In the range mode, Value returns the lower value.
+func (s *Slider) SetRangeMode(rangeMode bool) {
+	if s.rangeMode == rangeMode {
+		return
+	}
+	s.rangeMode = rangeMode
+	s.activeThumb = sliderThumbLower
+	guigui.RequestRedraw(s)
+}
+
+func (s *Slider) IsRangeMode() bool {
+	return s.rangeMode
+}
+
+func (s *Slider) SetOnRangeChanged(f func(lower, upper int)) {
+	guigui.RegisterEventHandler(s, sliderEventRangeChanged, f)
+}
+
+func (s *Slider) SetOnRangeChangedFloat64(f func(lower, upper float64)) {
+	guigui.RegisterEventHandler(s, sliderEventRangeChangedFloat64, f)
+}
+
+func (s *Slider) SetOnRangeChangedBigRat(f func(lower, upper *big.Rat)) {
+	guigui.RegisterEventHandler(s, sliderEventRangeChangedBigRat, f)
+}
+
+func (s *Slider) fireRangeChangeEvents() {
+	upper := s.actualUpperValue()
+	fUpper, _ := upper.Float64()
+	guigui.DispatchEventHandler(s, sliderEventRangeChanged, s.Value(), bigIntToInt(ratToInt(upper)))
+	guigui.DispatchEventHandler(s, sliderEventRangeChangedFloat64, s.ValueFloat64(), fUpper)
+	guigui.DispatchEventHandler(s, sliderEventRangeChangedBigRat, s.ValueBigRat(), upper)
+}
+
+// actualUpperValue returns the upper value in the range between the lower value and the maximum value.
+func (s *Slider) actualUpperValue() *big.Rat {
+	v := (&big.Rat{}).Set(&s.upperValue)
+	if v.Cmp(&s.abstractNumberInput.value) < 0 {
+		v.Set(&s.abstractNumberInput.value)
+	}
+	if s.abstractNumberInput.maxSet && v.Cmp(&s.abstractNumberInput.max) > 0 {
+		v.Set(&s.abstractNumberInput.max)
+	}
+	return v
+}
+
+// UpperValue returns the integer part of the upper value in the range mode.
+func (s *Slider) UpperValue() int {
+	return bigIntToInt(ratToInt(s.actualUpperValue()))
+}
+
+func (s *Slider) UpperValueFloat64() float64 {
+	f, _ := s.actualUpperValue().Float64()
+	return f
+}
+
+func (s *Slider) UpperValueBigRat() *big.Rat {
+	return s.actualUpperValue()
+}
+
+func (s *Slider) SetRangeValues(lower, upper int) {
+	s.SetRangeValuesBigRat((&big.Rat{}).SetInt64(int64(lower)), (&big.Rat{}).SetInt64(int64(upper)))
+}
+
+func (s *Slider) SetRangeValuesFloat64(lower, upper float64) {
+	l, ok := float64ToRat(lower)
+	if !ok {
+		return
+	}
+	u, ok := float64ToRat(upper)
+	if !ok {
+		return
+	}
+	s.SetRangeValuesBigRat(l, u)
+}
+
+// SetRangeValuesBigRat sets the lower and the upper values in the range mode.
+// If lower is greater than upper, the values are swapped.
+func (s *Slider) SetRangeValuesBigRat(lower, upper *big.Rat) {
+	if lower.Cmp(upper) > 0 {
+		lower, upper = upper, lower
+	}
+	prevUpper := s.actualUpperValue()
+	s.upperValue.Set(upper)
+	roundRat(&s.upperValue, s.abstractNumberInput.precision, s.abstractNumberInput.roundingMode)
+	changed := s.setLowerValue(lower)
+	if prevUpper.Cmp(s.actualUpperValue()) != 0 {
+		changed = true
+		guigui.RequestRedraw(s)
+	}
+	if changed && s.rangeMode {
+		s.fireRangeChangeEvents()
+	}
+}
+
+// setUpperValue sets the upper value, and reports whether the value is changed.
+func (s *Slider) setUpperValue(value *big.Rat) bool {
+	prev := s.actualUpperValue()
+	v := (&big.Rat{}).Set(value)
+	roundRat(v, s.abstractNumberInput.precision, s.abstractNumberInput.roundingMode)
+	s.abstractNumberInput.clamp(v)
+	s.upperValue.Set(v)
+	if prev.Cmp(s.actualUpperValue()) == 0 {
+		return false
+	}
+	guigui.RequestRedraw(s)
+	return true
+}
+
+func (s *Slider) thumbValue(thumb sliderThumb) *big.Rat {
+	if thumb == sliderThumbUpper {
+		return s.actualUpperValue()
+	}
+	return s.abstractNumberInput.ValueBigRat()
+}
+
+func (s *Slider) setThumbValue(thumb sliderThumb, value *big.Rat) {
+	if thumb == sliderThumbUpper {
+		if s.setUpperValue(value) {
+			s.fireRangeChangeEvents()
+		}
+		return
+	}
+	s.SetValueBigRat(value)
+}
+
+// SetVertical makes the slider vertical. The minimum value is at the bottom.
+func (s *Slider) SetVertical(vertical bool) {
+	if s.vertical == vertical {
+		return
+	}
+	s.vertical = vertical
+	guigui.RequestRedraw(s)
+}
+
+func (s *Slider) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	s.tickLabels = adjustSliceSize(s.tickLabels, len(s.ticks))
+	for i := range s.tickLabels {
+		if s.ticks[i].Label == "" {
+			continue
+		}
+		adder.AddChild(&s.tickLabels[i])
+	}
+	if s.valueTooltipEnabled && s.dragging {
+		adder.AddChild(&s.valueTooltip)
+	}
+}
+
 func (s *Slider) Update(context *guigui.Context) error {
 	if hovered := s.isThumbHovered(context); s.prevThumbHovered != hovered {
 		s.prevThumbHovered = hovered
 		guigui.RequestRedraw(s)
 	}
+
+	for i := range s.tickLabels {
+		t := &s.tickLabels[i]
+		t.SetValue(s.ticks[i].Label)
+		t.SetScale(0.875)
+		t.SetColor(draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.4, 0.6))
+		if s.vertical {
+			t.SetHorizontalAlign(HorizontalAlignStart)
+		} else {
+			t.SetHorizontalAlign(HorizontalAlignCenter)
+		}
+		t.SetVerticalAlign(VerticalAlignMiddle)
+	}
+
+	if s.valueTooltipEnabled && s.dragging {
+		f := LocaleNumberFormatter{
+			Locale: s.valueTooltip.text.lang(context),
+		}
+		s.valueTooltip.text.SetValue(f.FormatNumber(s.thumbValue(s.activeThumb), s.abstractNumberInput.precision))
+	}
 	return nil
 }
 
+func (s *Slider) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	if widget == &s.valueTooltip {
+		return s.valueTooltipBounds(context)
+	}
+	for i := range s.tickLabels {
+		if widget == &s.tickLabels[i] {
+			return s.tickLabelBounds(context, i)
+		}
+	}
+	return image.Rectangle{}
+}
+
 func (s *Slider) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
-	max := s.abstractNumberInput.MaximumValueBigInt()
-	min := s.abstractNumberInput.MinimumValueBigInt()
-	if max == nil || min == nil {
+	if !s.abstractNumberInput.maxSet || !s.abstractNumberInput.minSet {
 		return guigui.HandleInputResult{}
 	}
 
-	if context.IsEnabled(s) && context.IsWidgetHitAtCursor(s) && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !s.dragging {
+	// The drag recognizer captures the pointer while the button is pressed.
+	// This keeps dragging even when the cursor is outside, and prevents a scrollable parent from scrolling by touches.
+	if context.IsEnabled(s) {
+		s.drag.Update(context, s)
+	} else {
+		s.drag.Cancel(context, s)
+	}
+
+	if s.drag.JustPressed() {
 		context.SetFocused(s, true)
+		s.activeThumb = s.thumbAtCursor(context)
 		if !s.isThumbHovered(context) {
 			s.setValueFromCursor(context)
 		}
 		s.dragging = true
-		x, _ := ebiten.CursorPosition()
-		s.draggingStartX = x
-		s.draggingStartValue.Set(s.abstractNumberInput.ValueBigInt())
+		s.draggingStartPos = s.trackPosition(context, s.drag.StartPosition())
+		s.draggingStartValue.Set(s.thumbValue(s.activeThumb))
 		guigui.RequestRedraw(s)
 		return guigui.HandleInputByWidget(s)
 	}
 
-	if !context.IsEnabled(s) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !s.drag.IsPressed() {
 		if s.dragging {
 			guigui.RequestRedraw(s)
 		}
 		s.dragging = false
-		s.draggingStartX = 0
-		s.draggingStartValue = big.Int{}
+		s.draggingStartPos = 0
+		s.draggingStartValue = big.Rat{}
 		return guigui.HandleInputResult{}
 	}
 
-	if context.IsEnabled(s) && s.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		s.setValueFromCursorDelta(context)
-		return guigui.HandleInputByWidget(s)
+	s.setValueFromCursorDelta(context)
+	return guigui.HandleInputByWidget(s)
+}
+
+func (s *Slider) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsEnabled(s) || !s.abstractNumberInput.maxSet || !s.abstractNumberInput.minSet {
+		return guigui.HandleInputResult{}
+	}
+
+	// In a right-to-left horizontal slider, the left arrow key increases the value.
+	forwardKey, backwardKey := ebiten.KeyRight, ebiten.KeyLeft
+	if !s.vertical && context.IsRightToLeft() {
+		forwardKey, backwardKey = backwardKey, forwardKey
 	}
 
-	return guigui.HandleInputResult{}
+	thumb := s.activeThumb
+	v := s.thumbValue(thumb)
+	switch {
+	case isKeyRepeating(forwardKey) || isKeyRepeating(ebiten.KeyUp):
+		s.setThumbValue(thumb, s.nextValue(v, 1, false))
+	case isKeyRepeating(backwardKey) || isKeyRepeating(ebiten.KeyDown):
+		s.setThumbValue(thumb, s.nextValue(v, -1, false))
+	case isKeyRepeating(ebiten.KeyPageUp):
+		s.setThumbValue(thumb, s.nextValue(v, 1, true))
+	case isKeyRepeating(ebiten.KeyPageDown):
+		s.setThumbValue(thumb, s.nextValue(v, -1, true))
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		s.setThumbValue(thumb, &s.abstractNumberInput.min)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		s.setThumbValue(thumb, &s.abstractNumberInput.max)
+	default:
+		return guigui.HandleInputResult{}
+	}
+	return guigui.HandleInputByWidget(s)
+}
+
+// nextValue returns the value moved from v by a step in the direction dir.
+// If page is true, the value is moved by a tenth of the range at least.
+// If the values snap to the ticks, the value is moved to the next tick.
+func (s *Slider) nextValue(v *big.Rat, dir int, page bool) *big.Rat {
+	if s.snapToTicks && len(s.ticks) > 0 && !page {
+		if t, ok := s.nextTickValue(v, dir); ok {
+			return t
+		}
+		return v
+	}
+
+	step := s.abstractNumberInput.actualStep()
+	if page {
+		// Use the largest multiple of the step not exceeding a tenth of the range.
+		span := (&big.Rat{}).Sub(&s.abstractNumberInput.max, &s.abstractNumberInput.min)
+		span.Quo(span, big.NewRat(10, 1))
+		n := span.Quo(span, step)
+		roundRat(n, 0, RoundingModeTowardZero)
+		if n.Cmp(big.NewRat(1, 1)) > 0 {
+			step.Mul(step, n)
+		}
+	}
+	if dir < 0 {
+		step.Neg(step)
+	}
+	return step.Add(step, v)
 }
 
 func (s *Slider) setValueFromCursorDelta(context *guigui.Context) {
-	s.setValue(context, &s.draggingStartValue, s.draggingStartX)
+	s.setValue(context, &s.draggingStartValue, s.draggingStartPos)
 }
 
 func (s *Slider) setValueFromCursor(context *guigui.Context) {
-	min := s.abstractNumberInput.MinimumValueBigInt()
+	min := s.abstractNumberInput.MinimumValueBigRat()
 	if min == nil {
 		return
 	}
-
-	b := context.Bounds(s)
-	minX := b.Min.X + (b.Dx()-s.barWidth(context))/2
-	s.setValue(context, min, minX)
+	s.setValue(context, min, 0)
 }
 
-func (s *Slider) setValue(context *guigui.Context, originValue *big.Int, originX int) {
-	max := s.abstractNumberInput.MaximumValueBigInt()
-	min := s.abstractNumberInput.MinimumValueBigInt()
+// setValue sets the active thumb's value from the cursor position.
+// originValue is the value at originPos, the position on the track.
+func (s *Slider) setValue(context *guigui.Context, originValue *big.Rat, originPos int) {
+	max := s.abstractNumberInput.MaximumValueBigRat()
+	min := s.abstractNumberInput.MinimumValueBigRat()
 	if max == nil || min == nil {
 		return
 	}
 
-	c := image.Pt(ebiten.CursorPosition())
-	var v big.Int
+	l := s.trackLength(context)
+	if l <= 0 {
+		return
+	}
+
+	pos := s.trackPosition(context, context.PointerPosition())
+	var v big.Rat
 	v.Sub(max, min)
-	v.Mul(&v, (&big.Int{}).SetInt64(int64(c.X-originX)))
-	v.Div(&v, (&big.Int{}).SetInt64(int64(s.barWidth(context))))
+	v.Mul(&v, (&big.Rat{}).SetFrac64(int64(pos-originPos), int64(l)))
 	v.Add(&v, originValue)
-	changed := v.Cmp(s.abstractNumberInput.ValueBigInt()) != 0
-	s.abstractNumberInput.SetValueBigInt(s, &v, true)
-	if changed {
-		guigui.RequestRedraw(s)
+
+	if s.snapToTicks && len(s.ticks) > 0 {
+		if t, ok := s.nearestTickValue(&v); ok {
+			v.Set(t)
+		}
+	} else if s.abstractNumberInput.stepSet && s.abstractNumberInput.step.Sign() > 0 {
+		// Snap the value to the step.
+		step := &s.abstractNumberInput.step
+		v.Sub(&v, min)
+		v.Quo(&v, step)
+		roundRat(&v, 0, RoundingModeHalfAwayFromZero)
+		v.Mul(&v, step)
+		v.Add(&v, min)
+	}
+
+	s.setThumbValue(s.activeThumb, &v)
+}
+
+// trackBounds returns the bounds for the track and the thumbs, excluding the tick labels.
+func (s *Slider) trackBounds(context *guigui.Context) image.Rectangle {
+	b := context.Bounds(s)
+	if !s.hasTickLabels() {
+		return b
+	}
+	u := UnitSize(context)
+	if s.vertical {
+		if context.IsRightToLeft() {
+			b.Min.X = max(b.Min.X, b.Max.X-u)
+		} else {
+			b.Max.X = min(b.Max.X, b.Min.X+u)
+		}
+	} else {
+		b.Max.Y = min(b.Max.Y, b.Min.Y+u)
 	}
+	return b
 }
 
-func (s *Slider) barWidth(context *guigui.Context) int {
-	w := context.Bounds(s).Dx()
-	return w - 2*sliderThumbRadius(context)
+func (s *Slider) trackLength(context *guigui.Context) int {
+	b := s.trackBounds(context)
+	if s.vertical {
+		return b.Dy() - 2*sliderThumbRadius(context)
+	}
+	return b.Dx() - 2*sliderThumbRadius(context)
+}
+
+// trackPosition returns the position of the point along the track from the minimum value's end.
+func (s *Slider) trackPosition(context *guigui.Context, point image.Point) int {
+	b := s.trackBounds(context)
+	r := sliderThumbRadius(context)
+	switch {
+	case s.vertical:
+		return b.Max.Y - r - point.Y
+	case context.IsRightToLeft():
+		return b.Max.X - r - point.X
+	default:
+		return point.X - (b.Min.X + r)
+	}
+}
+
+// trackPoint returns the point on the center line of the track at the position pos.
+func (s *Slider) trackPoint(context *guigui.Context, pos int) image.Point {
+	b := s.trackBounds(context)
+	r := sliderThumbRadius(context)
+	switch {
+	case s.vertical:
+		return image.Pt((b.Min.X+b.Max.X)/2, b.Max.Y-r-pos)
+	case context.IsRightToLeft():
+		return image.Pt(b.Max.X-r-pos, (b.Min.Y+b.Max.Y)/2)
+	default:
+		return image.Pt(b.Min.X+r+pos, (b.Min.Y+b.Max.Y)/2)
+	}
+}
+
+// trackPositionOf returns the position of value on the track.
+func (s *Slider) trackPositionOf(context *guigui.Context, value *big.Rat) (int, bool) {
+	rate := s.abstractNumberInput.rateOf(value)
+	if math.IsNaN(rate) {
+		return 0, false
+	}
+	return int(rate * float64(s.trackLength(context))), true
+}
+
+// segmentBounds returns the bounds of the part of the track between the positions pos0 and pos1.
+func (s *Slider) segmentBounds(context *guigui.Context, pos0, pos1 int, thickness int) image.Rectangle {
+	b := image.Rectangle{
+		Min: s.trackPoint(context, pos0),
+		Max: s.trackPoint(context, pos1),
+	}.Canon()
+	r := thickness / 2
+	if s.vertical {
+		b.Min.X -= r
+		b.Max.X += r
+	} else {
+		b.Min.Y -= r
+		b.Max.Y += r
+	}
+	return b
 }
 
 func sliderThumbRadius(context *guigui.Context) int {
 	return int(UnitSize(context) * 7 / 16)
 }
 
-func (s *Slider) thumbBounds(context *guigui.Context) image.Rectangle {
-	rate := s.abstractNumberInput.Rate()
-	if math.IsNaN(rate) {
+func (s *Slider) thumbBounds(context *guigui.Context, thumb sliderThumb) image.Rectangle {
+	pos, ok := s.trackPositionOf(context, s.thumbValue(thumb))
+	if !ok {
 		return image.Rectangle{}
 	}
-	bounds := context.Bounds(s)
-	x := bounds.Min.X + int(rate*float64(s.barWidth(context)))
-	y := bounds.Min.Y + (bounds.Dy()-2*sliderThumbRadius(context))/2
-	w := 2 * sliderThumbRadius(context)
-	h := 2 * sliderThumbRadius(context)
-	return image.Rect(x, y, x+w, y+h)
+	c := s.trackPoint(context, pos)
+	r := sliderThumbRadius(context)
+	return image.Rect(c.X-r, c.Y-r, c.X+r, c.Y+r)
+}
+
+func (s *Slider) thumbs() []sliderThumb {
+	if s.rangeMode {
+		return []sliderThumb{sliderThumbLower, sliderThumbUpper}
+	}
+	return []sliderThumb{sliderThumbLower}
+}
+
+// thumbAtCursor returns the thumb to grab at the cursor.
+// In the range mode, the nearer thumb to the cursor is chosen.
+func (s *Slider) thumbAtCursor(context *guigui.Context) sliderThumb {
+	if !s.rangeMode {
+		return sliderThumbLower
+	}
+	pos := s.trackPosition(context, context.PointerPosition())
+	lower, _ := s.trackPositionOf(context, s.thumbValue(sliderThumbLower))
+	upper, _ := s.trackPositionOf(context, s.thumbValue(sliderThumbUpper))
+	if lower == upper {
+		// The thumbs overlap. Choose the thumb that can move toward the cursor.
+		if pos > upper {
+			return sliderThumbUpper
+		}
+		return sliderThumbLower
+	}
+	if 2*pos <= lower+upper {
+		return sliderThumbLower
+	}
+	return sliderThumbUpper
 }
 
 func (s *Slider) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
@@ -266,71 +741,105 @@ func (s *Slider) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, b
 }
 
 func (s *Slider) Draw(context *guigui.Context, dst *ebiten.Image) {
-	rate := s.abstractNumberInput.Rate()
-
-	b := context.Bounds(s)
-	x0 := b.Min.X + sliderThumbRadius(context)
-	x1 := x0
-	if !math.IsNaN(rate) {
-		x1 += int(float64(s.barWidth(context)) * float64(rate))
+	l := s.trackLength(context)
+
+	// The part of the track between pos0 and pos1 is highlighted.
+	var pos0, pos1 int
+	if s.rangeMode {
+		pos0, _ = s.trackPositionOf(context, s.thumbValue(sliderThumbLower))
+		pos1, _ = s.trackPositionOf(context, s.thumbValue(sliderThumbUpper))
+	} else {
+		pos1, _ = s.trackPositionOf(context, s.thumbValue(sliderThumbLower))
 	}
-	x2 := b.Max.X - sliderThumbRadius(context)
 	strokeWidth := int(5 * context.Scale())
 	r := strokeWidth / 2
-	y0 := (b.Min.Y+b.Max.Y)/2 - r
-	y1 := (b.Min.Y+b.Max.Y)/2 + r
 
 	bgColorOn := draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
 	bgColorOff := draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.8)
 	if !context.IsEnabled(s) {
 		bgColorOn = bgColorOff
 	}
+	borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset, false)
+
+	if 0 < pos0 {
+		b := s.segmentBounds(context, 0, pos0, strokeWidth)
+		draw.DrawRoundedRect(context, dst, b, bgColorOff, r)
+		draw.DrawRoundedRectBorder(context, dst, b, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+	}
 
-	if x0 < x1 {
-		b := image.Rect(x0, y0, x1, y1)
+	if pos0 < pos1 {
+		b := s.segmentBounds(context, pos0, pos1, strokeWidth)
 		draw.DrawRoundedRect(context, dst, b, bgColorOn, r)
 
 		if !context.IsEnabled(s) {
-			borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset, false)
 			draw.DrawRoundedRectBorder(context, dst, b, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
 		}
 	}
 
-	if x1 < x2 {
-		b := image.Rect(x1, y0, x2, y1)
+	if pos1 < l {
+		b := s.segmentBounds(context, pos1, l, strokeWidth)
 		draw.DrawRoundedRect(context, dst, b, bgColorOff, r)
-
-		borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset, false)
 		draw.DrawRoundedRectBorder(context, dst, b, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
 	}
 
-	if thumbBounds := s.thumbBounds(context); !thumbBounds.Empty() {
-		cm := context.ColorMode()
-		thumbColor := draw.ThumbColor(context.ColorMode(), context.IsEnabled(s))
-		if s.isActive(context) {
-			thumbColor = draw.Color2(cm, draw.ColorTypeBase, 0.95, 0.55)
-		} else if s.canPress(context) {
-			thumbColor = draw.Color2(cm, draw.ColorTypeBase, 0.975, 0.575)
+	s.drawTicks(context, dst, strokeWidth)
+
+	// Draw the active thumb at last so that it is above the other thumb.
+	for _, thumb := range s.thumbs() {
+		if s.rangeMode && thumb == s.activeThumb {
+			continue
 		}
-		thumbClr1, thumbClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset, false)
-		r := thumbBounds.Dy() / 2
-		draw.DrawRoundedRect(context, dst, thumbBounds, thumbColor, r)
-		draw.DrawRoundedRectBorder(context, dst, thumbBounds, thumbClr1, thumbClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+		s.drawThumb(context, dst, thumb)
+	}
+	if s.rangeMode {
+		s.drawThumb(context, dst, s.activeThumb)
 	}
 }
 
+func (s *Slider) drawThumb(context *guigui.Context, dst *ebiten.Image, thumb sliderThumb) {
+	thumbBounds := s.thumbBounds(context, thumb)
+	if thumbBounds.Empty() {
+		return
+	}
+	cm := context.ColorMode()
+	thumbColor := draw.ThumbColor(context.ColorMode(), context.IsEnabled(s))
+	if s.isActive(context, thumb) {
+		thumbColor = draw.Color2(cm, draw.ColorTypeBase, 0.95, 0.55)
+	} else if s.canPress(context) && s.isThumbHoveredAt(context, thumb) {
+		thumbColor = draw.Color2(cm, draw.ColorTypeBase, 0.975, 0.575)
+	}
+	thumbClr1, thumbClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset, false)
+	r := thumbBounds.Dy() / 2
+	draw.DrawRoundedRect(context, dst, thumbBounds, thumbColor, r)
+	draw.DrawRoundedRectBorder(context, dst, thumbBounds, thumbClr1, thumbClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+}
+
 func (s *Slider) canPress(context *guigui.Context) bool {
-	return context.IsEnabled(s) && s.isThumbHovered(context) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !s.dragging
+	return context.IsEnabled(s) && s.isThumbHovered(context) && !context.IsPointerPressed() && !s.dragging
 }
 
 func (s *Slider) isThumbHovered(context *guigui.Context) bool {
-	return context.IsWidgetHitAtCursor(s) && image.Pt(ebiten.CursorPosition()).In(s.thumbBounds(context))
+	for _, thumb := range s.thumbs() {
+		if s.isThumbHoveredAt(context, thumb) {
+			return true
+		}
+	}
+	return false
 }
 
-func (s *Slider) isActive(context *guigui.Context) bool {
-	return context.IsEnabled(s) && s.isThumbHovered(context) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && s.dragging
+func (s *Slider) isThumbHoveredAt(context *guigui.Context, thumb sliderThumb) bool {
+	return context.IsWidgetHitAtCursor(s) && context.PointerPosition().In(s.thumbBounds(context, thumb))
+}
+
+func (s *Slider) isActive(context *guigui.Context, thumb sliderThumb) bool {
+	return context.IsEnabled(s) && s.isThumbHoveredAt(context, thumb) && context.IsPointerPressed() && s.dragging && thumb == s.activeThumb
 }
 
 func (s *Slider) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
-	return image.Pt(6*UnitSize(context), UnitSize(context))
+	u := UnitSize(context)
+	labelSize := s.tickLabelsSize(context)
+	if s.vertical {
+		return image.Pt(u+labelSize.X, 6*u)
+	}
+	return image.Pt(6*u, u+labelSize.Y)
 }
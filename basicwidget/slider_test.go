@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+var testSliderTicks = []basicwidget.SliderTick{
+	{Value: 0},
+	{Value: 25},
+	{Value: 50},
+	{Value: 100},
+	{Value: 200},
+}
+
+func TestSliderNearestTickValue(t *testing.T) {
+	testCases := []struct {
+		value  float64
+		want   float64
+		wantOK bool
+	}{
+		{value: 0, want: 0, wantOK: true},
+		{value: 12, want: 0, wantOK: true},
+		{value: 13, want: 25, wantOK: true},
+		{value: 70, want: 50, wantOK: true},
+		{value: 80, want: 100, wantOK: true},
+		// The tick 200 is out of the range.
+		{value: 150, want: 100, wantOK: true},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.SliderNearestTickValue(testSliderTicks, 0, 100, tc.value)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("SliderNearestTickValue(%v): got: %v, %v, want: %v, %v", tc.value, got, ok, tc.want, tc.wantOK)
+		}
+	}
+
+	if _, ok := basicwidget.SliderNearestTickValue(nil, 0, 100, 50); ok {
+		t.Errorf("SliderNearestTickValue without ticks: got: true, want: false")
+	}
+}
+
+func TestSliderNextTickValue(t *testing.T) {
+	testCases := []struct {
+		value  float64
+		dir    int
+		want   float64
+		wantOK bool
+	}{
+		{value: 0, dir: 1, want: 25, wantOK: true},
+		{value: 10, dir: 1, want: 25, wantOK: true},
+		{value: 25, dir: 1, want: 50, wantOK: true},
+		{value: 50, dir: -1, want: 25, wantOK: true},
+		{value: 30, dir: -1, want: 25, wantOK: true},
+		{value: 0, dir: -1, wantOK: false},
+		// The tick 200 is out of the range.
+		{value: 100, dir: 1, wantOK: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.SliderNextTickValue(testSliderTicks, 0, 100, tc.value, tc.dir)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("SliderNextTickValue(%v, %d): got: %v, %v, want: %v, %v", tc.value, tc.dir, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestSliderNextValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ticks       []basicwidget.SliderTick
+		snapToTicks bool
+		min         float64
+		max         float64
+		step        float64
+		value       float64
+		dir         int
+		page        bool
+		want        float64
+	}{
+		{name: "step", min: 0, max: 100, step: 1, value: 10, dir: 1, want: 11},
+		{name: "step backward", min: 0, max: 100, step: 5, value: 10, dir: -1, want: 5},
+		{name: "page", min: 0, max: 100, step: 1, value: 10, dir: 1, page: true, want: 20},
+		{name: "page backward", min: 0, max: 100, step: 1, value: 50, dir: -1, page: true, want: 40},
+		// A tenth of the range is 10, and the largest multiple of 3 not exceeding 10 is 9.
+		{name: "page with step", min: 0, max: 100, step: 3, value: 0, dir: 1, page: true, want: 9},
+		// A tenth of the range is less than the step.
+		{name: "page with large step", min: 0, max: 100, step: 20, value: 0, dir: 1, page: true, want: 20},
+		{name: "snap", ticks: testSliderTicks, snapToTicks: true, min: 0, max: 100, step: 1, value: 25, dir: 1, want: 50},
+		{name: "snap at the end", ticks: testSliderTicks, snapToTicks: true, min: 0, max: 100, step: 1, value: 100, dir: 1, want: 100},
+		{name: "snap page", ticks: testSliderTicks, snapToTicks: true, min: 0, max: 100, step: 1, value: 25, dir: 1, page: true, want: 35},
+		{name: "snap without ticks", snapToTicks: true, min: 0, max: 100, step: 1, value: 10, dir: 1, want: 11},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := basicwidget.SliderNextValue(tc.ticks, tc.snapToTicks, tc.min, tc.max, tc.step, tc.value, tc.dir, tc.page)
+			if got != tc.want {
+				t.Errorf("got: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSliderRangeValues(t *testing.T) {
+	var s basicwidget.Slider
+	s.SetMinimumValue(0)
+	s.SetMaximumValue(100)
+	s.SetRangeMode(true)
+
+	s.SetRangeValues(70, 30)
+	if got0, got1 := s.Value(), s.UpperValue(); got0 != 30 || got1 != 70 {
+		t.Errorf("swapped values: got: %d, %d, want: 30, 70", got0, got1)
+	}
+
+	// The lower thumb cannot exceed the upper thumb.
+	s.SetValue(80)
+	if got0, got1 := s.Value(), s.UpperValue(); got0 != 70 || got1 != 70 {
+		t.Errorf("lower thumb: got: %d, %d, want: 70, 70", got0, got1)
+	}
+
+	// The upper thumb cannot go below the lower thumb.
+	s.SetRangeValues(30, 70)
+	basicwidget.SetSliderUpperThumbValue(&s, 10)
+	if got0, got1 := s.Value(), s.UpperValue(); got0 != 30 || got1 != 30 {
+		t.Errorf("upper thumb below the lower: got: %d, %d, want: 30, 30", got0, got1)
+	}
+
+	// The upper thumb is clamped by the maximum value.
+	basicwidget.SetSliderUpperThumbValue(&s, 150)
+	if got0, got1 := s.Value(), s.UpperValue(); got0 != 30 || got1 != 100 {
+		t.Errorf("upper thumb above the maximum: got: %d, %d, want: 30, 100", got0, got1)
+	}
+}
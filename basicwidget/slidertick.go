@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"math/big"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+// SliderTick represents a tick mark of a slider.
+type SliderTick struct {
+	Value float64
+
+	// Label is a text shown next to the tick mark. Label can be empty.
+	Label string
+}
+
+// SetTicks sets the tick marks. Ticks out of the range between the minimum and the maximum values are not shown.
+func (s *Slider) SetTicks(ticks []SliderTick) {
+	if slices.Equal(s.ticks, ticks) {
+		return
+	}
+	s.ticks = adjustSliceSize(s.ticks, len(ticks))
+	copy(s.ticks, ticks)
+	guigui.RequestRedraw(s)
+}
+
+// SetSnapToTicks makes a value snap to the nearest tick instead of the step.
+func (s *Slider) SetSnapToTicks(snap bool) {
+	s.snapToTicks = snap
+}
+
+// SetValueTooltipEnabled enables the tooltip showing the value while the thumb is dragged.
+func (s *Slider) SetValueTooltipEnabled(enabled bool) {
+	if s.valueTooltipEnabled == enabled {
+		return
+	}
+	s.valueTooltipEnabled = enabled
+	guigui.RequestRedraw(s)
+}
+
+func (s *Slider) hasTickLabels() bool {
+	for _, t := range s.ticks {
+		if t.Label != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Slider) tickValue(index int) (*big.Rat, bool) {
+	v, ok := float64ToRat(s.ticks[index].Value)
+	if !ok {
+		return nil, false
+	}
+	a := &s.abstractNumberInput
+	if a.minSet && v.Cmp(&a.min) < 0 {
+		return nil, false
+	}
+	if a.maxSet && v.Cmp(&a.max) > 0 {
+		return nil, false
+	}
+	return v, true
+}
+
+// nearestTickValue returns the value of the tick nearest to v.
+func (s *Slider) nearestTickValue(v *big.Rat) (*big.Rat, bool) {
+	var nearest *big.Rat
+	var minDist big.Rat
+	var dist big.Rat
+	for i := range s.ticks {
+		t, ok := s.tickValue(i)
+		if !ok {
+			continue
+		}
+		dist.Sub(t, v)
+		dist.Abs(&dist)
+		if nearest == nil || dist.Cmp(&minDist) < 0 {
+			nearest = t
+			minDist.Set(&dist)
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// nextTickValue returns the value of the nearest tick after v in the direction dir.
+func (s *Slider) nextTickValue(v *big.Rat, dir int) (*big.Rat, bool) {
+	var next *big.Rat
+	for i := range s.ticks {
+		t, ok := s.tickValue(i)
+		if !ok {
+			continue
+		}
+		c := t.Cmp(v)
+		if c*dir <= 0 {
+			continue
+		}
+		if next == nil || t.Cmp(next)*dir < 0 {
+			next = t
+		}
+	}
+	return next, next != nil
+}
+
+func (s *Slider) tickLabelsSize(context *guigui.Context) image.Point {
+	var size image.Point
+	for i := range s.tickLabels {
+		if s.ticks[i].Label == "" {
+			continue
+		}
+		ls := s.tickLabels[i].Measure(context, guigui.Constraints{})
+		size.X = max(size.X, ls.X)
+		size.Y = max(size.Y, ls.Y)
+	}
+	return size
+}
+
+func (s *Slider) tickLabelBounds(context *guigui.Context, index int) image.Rectangle {
+	v, ok := s.tickValue(index)
+	if !ok {
+		return image.Rectangle{}
+	}
+	pos, ok := s.trackPositionOf(context, v)
+	if !ok {
+		return image.Rectangle{}
+	}
+	p := s.trackPoint(context, pos)
+	size := s.tickLabels[index].Measure(context, guigui.Constraints{})
+	tb := s.trackBounds(context)
+	var pt image.Point
+	switch {
+	case !s.vertical:
+		pt = image.Pt(p.X-size.X/2, tb.Max.Y)
+	case context.IsRightToLeft():
+		pt = image.Pt(tb.Min.X-size.X, p.Y-size.Y/2)
+	default:
+		pt = image.Pt(tb.Max.X, p.Y-size.Y/2)
+	}
+	return image.Rectangle{
+		Min: pt,
+		Max: pt.Add(size),
+	}
+}
+
+func (s *Slider) drawTicks(context *guigui.Context, dst *ebiten.Image, strokeWidth int) {
+	if len(s.ticks) == 0 {
+		return
+	}
+	clr := draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.7, 0.4)
+	l := float32(UnitSize(context) / 6)
+	w := float32(context.Scale())
+	for i := range s.ticks {
+		v, ok := s.tickValue(i)
+		if !ok {
+			continue
+		}
+		pos, ok := s.trackPositionOf(context, v)
+		if !ok {
+			continue
+		}
+		p := s.trackPoint(context, pos)
+		x, y := float32(p.X), float32(p.Y)
+		// The tick marks are on the side of the labels.
+		switch {
+		case !s.vertical:
+			y += float32(strokeWidth)
+			vector.StrokeLine(dst, x, y, x, y+l, w, clr, false)
+		case context.IsRightToLeft():
+			x -= float32(strokeWidth)
+			vector.StrokeLine(dst, x, y, x-l, y, w, clr, false)
+		default:
+			x += float32(strokeWidth)
+			vector.StrokeLine(dst, x, y, x+l, y, w, clr, false)
+		}
+	}
+}
+
+func (s *Slider) valueTooltipBounds(context *guigui.Context) image.Rectangle {
+	thumb := s.thumbBounds(context, s.activeThumb)
+	if thumb.Empty() {
+		return image.Rectangle{}
+	}
+	size := s.valueTooltip.Measure(context, guigui.Constraints{})
+	gap := UnitSize(context) / 8
+	var pt image.Point
+	switch {
+	case !s.vertical:
+		pt = image.Pt((thumb.Min.X+thumb.Max.X-size.X)/2, thumb.Min.Y-gap-size.Y)
+	case context.IsRightToLeft():
+		pt = image.Pt(thumb.Max.X+gap, (thumb.Min.Y+thumb.Max.Y-size.Y)/2)
+	default:
+		pt = image.Pt(thumb.Min.X-gap-size.X, (thumb.Min.Y+thumb.Max.Y-size.Y)/2)
+	}
+	return image.Rectangle{
+		Min: pt,
+		Max: pt.Add(size),
+	}
+}
+
+type sliderValueTooltip struct {
+	guigui.DefaultWidget
+
+	text Text
+}
+
+func (s *sliderValueTooltip) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&s.text)
+}
+
+func (s *sliderValueTooltip) Update(context *guigui.Context) error {
+	s.text.SetHorizontalAlign(HorizontalAlignCenter)
+	s.text.SetVerticalAlign(VerticalAlignMiddle)
+	s.text.SetTabular(true)
+	return nil
+}
+
+func (s *sliderValueTooltip) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	if widget == &s.text {
+		return context.Bounds(s)
+	}
+	return image.Rectangle{}
+}
+
+func (s *sliderValueTooltip) Draw(context *guigui.Context, dst *ebiten.Image) {
+	bounds := context.Bounds(s)
+	clr := draw.Color(context.ColorMode(), draw.ColorTypeBase, 1)
+	draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context))
+	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset, false)
+	draw.DrawRoundedRectBorder(context, dst, bounds, clr1, clr2, RoundedCornerRadius(context), float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+}
+
+func (s *sliderValueTooltip) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	u := UnitSize(context)
+	size := s.text.Measure(context, guigui.Constraints{})
+	return image.Pt(size.X+u/2, size.Y+u/4)
+}
+
+func (s *sliderValueTooltip) ZDelta() int {
+	return 1
+}
+
+func (s *sliderValueTooltip) PassThrough() bool {
+	return true
+}
@@ -60,6 +60,13 @@ func (t *Table[T]) SetOnItemsMoved(f func(from, count, to int)) {
 	t.list.SetOnItemsMoved(f)
 }
 
+// SetOnContextMenuRequested sets the function called when a context menu is requested on an item
+// by a right click or a long press.
+// position is the position of the pointer.
+func (t *Table[T]) SetOnContextMenuRequested(f func(index int, position image.Point)) {
+	t.list.SetOnContextMenuRequested(f)
+}
+
 func (t *Table[T]) SetCheckmarkIndex(index int) {
 	t.list.SetCheckmarkIndex(index)
 }
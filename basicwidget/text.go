@@ -106,6 +106,7 @@ type Text struct {
 	scaleMinus1 float64
 	bold        bool
 	tabular     bool
+	monospace   bool
 	tabWidth    float64
 
 	selectable       bool
@@ -113,6 +114,7 @@ type Text struct {
 	multiline        bool
 	autoWrap         bool
 	keepTailingSpace bool
+	codeEditing      bool
 
 	selectionDragStartPlus1 int
 	selectionDragEndPlus1   int
@@ -123,19 +125,29 @@ type Text struct {
 	dragging    bool
 	prevFocused bool
 
-	clickCount         int
-	lastClickTick      int64
-	lastClickTextIndex int
+	click guigui.ClickRecognizer
+
+	spans    []TextSpan
+	tmpSpans []textutil.Span
+
+	find          textFind
+	tmpHighlights []textutil.Highlight
 
 	cursor textCursor
 
 	tmpClipboard string
 
 	cachedTextSizePlus1 [4]image.Point
+	// cachedTextSizeWidth is the width to wrap the text for cachedTextSizePlus1.
+	cachedTextSizeWidth [4]int
 	lastFace            text.Face
 	lastScale           float64
 	lastWidth           int
 
+	cachedLogicalLineTops      []float64
+	cachedLogicalLineTopsKey   logicalLineTopsCacheKey
+	cachedLogicalLineTopsValid bool
+
 	tmpLocales []language.Tag
 }
 
@@ -164,6 +176,7 @@ func (t *Text) resetCachedTextSize() {
 	for i := range t.cachedTextSizePlus1 {
 		t.cachedTextSizePlus1[i] = image.Point{}
 	}
+	t.cachedLogicalLineTopsValid = false
 }
 
 func (t *Text) resetAutoWrapCachedTextSize() {
@@ -355,6 +368,17 @@ func (t *Text) SetTabular(tabular bool) {
 	guigui.RequestRedraw(t)
 }
 
+// SetMonospace sets whether the text uses a monospace face.
+// A monospace face is registered by SetFaceSources with FaceSourceEntry.Monospace.
+func (t *Text) SetMonospace(monospace bool) {
+	if t.monospace == monospace {
+		return
+	}
+	t.monospace = monospace
+	t.resetCachedTextSize()
+	guigui.RequestRedraw(t)
+}
+
 func (t *Text) SetTabWidth(tabWidth float64) {
 	if t.tabWidth == tabWidth {
 		return
@@ -499,20 +523,26 @@ func (t *Text) face(context *guigui.Context, forceBold bool) text.Face {
 		weight = text.WeightBold
 	}
 
-	liga := !t.selectable && !t.editable
-	tnum := t.tabular
+	return fontFace(faceCacheKey{
+		size:      size,
+		weight:    weight,
+		liga:      !t.selectable && !t.editable,
+		tnum:      t.tabular,
+		monospace: t.monospace,
+		lang:      t.lang(context),
+	})
+}
 
-	var lang language.Tag
+func (t *Text) lang(context *guigui.Context) language.Tag {
 	if len(t.locales) > 0 {
-		lang = t.locales[0]
-	} else {
-		t.tmpLocales = slices.Delete(t.tmpLocales, 0, len(t.tmpLocales))
-		t.tmpLocales = context.AppendLocales(t.tmpLocales)
-		if len(t.tmpLocales) > 0 {
-			lang = t.tmpLocales[0]
-		}
+		return t.locales[0]
+	}
+	t.tmpLocales = slices.Delete(t.tmpLocales, 0, len(t.tmpLocales))
+	t.tmpLocales = context.AppendLocales(t.tmpLocales)
+	if len(t.tmpLocales) > 0 {
+		return t.tmpLocales[0]
 	}
-	return fontFace(size, weight, liga, tnum, lang)
+	return language.Tag{}
 }
 
 func (t *Text) lineHeight(context *guigui.Context) float64 {
@@ -520,13 +550,22 @@ func (t *Text) lineHeight(context *guigui.Context) float64 {
 }
 
 func (t *Text) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	t.click.Update(context, t)
+
+	if t.click.JustPressed() {
+		if link, ok := t.linkAt(context, context.PointerPosition()); ok {
+			guigui.DispatchEventHandler(t, textEventLinkClicked, link)
+			return guigui.HandleInputByWidget(t)
+		}
+	}
+
 	if !t.selectable && !t.editable {
 		return guigui.HandleInputResult{}
 	}
 
-	cursorPosition := image.Pt(ebiten.CursorPosition())
+	cursorPosition := context.PointerPosition()
 	if t.dragging {
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if context.IsPointerPressed() {
 			idx := t.textIndexFromPosition(context, cursorPosition, false)
 			start, end := idx, idx
 			if t.selectionDragStartPlus1-1 >= 0 {
@@ -541,7 +580,7 @@ func (t *Text) HandlePointingInput(context *guigui.Context) guigui.HandleInputRe
 				return guigui.AbortHandlingInputByWidget(t)
 			}
 		}
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if context.IsPointerJustReleased() {
 			t.dragging = false
 			t.selectionDragStartPlus1 = 0
 			t.selectionDragEndPlus1 = 0
@@ -550,11 +589,11 @@ func (t *Text) HandlePointingInput(context *guigui.Context) guigui.HandleInputRe
 		return guigui.AbortHandlingInputByWidget(t)
 	}
 
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		if context.IsWidgetHitAtCursor(t) {
-			t.handleClick(context, cursorPosition)
-			return guigui.HandleInputByWidget(t)
-		}
+	if t.click.JustPressed() {
+		t.handleClick(context, cursorPosition, t.click.Count())
+		return guigui.HandleInputByWidget(t)
+	}
+	if context.IsPointerJustPressed() {
 		context.SetFocused(t, false)
 	}
 
@@ -574,16 +613,12 @@ func (t *Text) HandlePointingInput(context *guigui.Context) guigui.HandleInputRe
 	return guigui.HandleInputResult{}
 }
 
-func (t *Text) handleClick(context *guigui.Context, cursorPosition image.Point) {
+// handleClick handles a press at cursorPosition.
+// count is the number of successive presses, e.g. 2 for a double-click. See guigui.ClickRecognizer.
+func (t *Text) handleClick(context *guigui.Context, cursorPosition image.Point, count int) {
 	idx := t.textIndexFromPosition(context, cursorPosition, false)
 
-	if ebiten.Tick()-t.lastClickTick < int64(doubleClickLimitInTicks()) && t.lastClickTextIndex == idx {
-		t.clickCount++
-	} else {
-		t.clickCount = 1
-	}
-
-	switch t.clickCount {
+	switch count {
 	case 1:
 		t.dragging = true
 		t.selectionDragStartPlus1 = idx + 1
@@ -603,8 +638,6 @@ func (t *Text) handleClick(context *guigui.Context, cursorPosition image.Point)
 	}
 
 	context.SetFocused(t, true)
-	t.lastClickTick = ebiten.Tick()
-	t.lastClickTextIndex = idx
 }
 
 func (t *Text) textToDraw(context *guigui.Context, showComposition bool) string {
@@ -709,6 +742,10 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 		// For Windows key binds, see:
 		// https://support.microsoft.com/en-us/windows/keyboard-shortcuts-in-windows-dcc61a57-8ff0-cffe-9796-cb9706c75eec#textediting
 
+		if t.codeEditing && t.handleCodeEditingKeys(context) {
+			return guigui.HandleInputByWidget(t)
+		}
+
 		switch {
 		case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
 			if t.multiline {
@@ -778,8 +815,13 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 	}
 
 	switch {
-	case isKeyRepeating(ebiten.KeyLeft) ||
-		useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyB):
+	case isKeyRepeating(ebiten.KeyLeft):
+		t.moveCursorVisually(context, false)
+		return guigui.HandleInputByWidget(t)
+	case isKeyRepeating(ebiten.KeyRight):
+		t.moveCursorVisually(context, true)
+		return guigui.HandleInputByWidget(t)
+	case useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyB):
 		start, end := t.field.Selection()
 		if ebiten.IsKeyPressed(ebiten.KeyShift) {
 			if t.selectionShiftIndexPlus1-1 == end {
@@ -798,8 +840,7 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			}
 		}
 		return guigui.HandleInputByWidget(t)
-	case isKeyRepeating(ebiten.KeyRight) ||
-		useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyF):
+	case useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyF):
 		start, end := t.field.Selection()
 		if ebiten.IsKeyPressed(ebiten.KeyShift) {
 			if t.selectionShiftIndexPlus1-1 == start {
@@ -830,7 +871,8 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			moveEnd = true
 		}
 		if pos, ok := t.textPosition(context, idx, false); ok {
-			y := (pos.Top+pos.Bottom)/2 - lh
+			// The lines can have different heights. Move from the top of the current line.
+			y := pos.Top - lh
 			idx := t.textIndexFromPosition(context, image.Pt(int(pos.X), int(y)), false)
 			if shift {
 				if moveEnd {
@@ -855,7 +897,8 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			moveStart = true
 		}
 		if pos, ok := t.textPosition(context, idx, false); ok {
-			y := (pos.Top+pos.Bottom)/2 + lh
+			// The lines can have different heights. Move from the bottom of the current line.
+			y := pos.Bottom + lh/2
 			idx := t.textIndexFromPosition(context, image.Pt(int(pos.X), int(y)), false)
 			if shift {
 				if moveStart {
@@ -965,12 +1008,26 @@ func (t *Text) Draw(context *guigui.Context, dst *ebiten.Image) {
 			Face:             face,
 			LineHeight:       t.lineHeight(context),
 			HorizontalAlign:  textutil.HorizontalAlign(t.hAlign),
+			RightToLeft:      context.IsRightToLeft(),
 			VerticalAlign:    textutil.VerticalAlign(t.vAlign),
 			TabWidth:         t.actualTabWidth(context),
 			KeepTailingSpace: t.keepTailingSpace,
+			Spans:            t.textutilSpans(context, false),
 		},
 		TextColor: textColor,
 	}
+	// The positions of the matches are not valid during the composition.
+	if _, _, ok := t.field.CompositionSelection(); !ok {
+		t.tmpHighlights = t.appendFindHighlights(context, t.tmpHighlights[:0])
+		if t.codeEditing && context.IsFocused(t) {
+			t.tmpHighlights = t.appendBracketHighlights(context, t.tmpHighlights)
+			start, _ := t.field.Selection()
+			op.DrawCurrentLine = true
+			op.CurrentLineIndex = start
+			op.CurrentLineColor = draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.95, 0.15)
+		}
+		op.Highlights = t.tmpHighlights
+	}
 	if start, end, ok := t.selectionToDraw(context); ok {
 		if context.IsFocused(t) {
 			op.DrawSelection = true
@@ -1003,28 +1060,42 @@ func (t *Text) boldTextSize(context *guigui.Context, constraints guigui.Constrai
 }
 
 func (t *Text) textSize(context *guigui.Context, constraints guigui.Constraints, forceBold bool) image.Point {
+	// Wrap the text at the maximum width. A fixed width is also a maximum width.
+	width := math.MaxInt
+	if w, ok := constraints.MaxWidth(); ok {
+		width = w
+	}
+
 	key := newTextSizeCacheKey(t.autoWrap, t.bold || forceBold)
-	if size := t.cachedTextSizePlus1[key]; size != (image.Point{}) {
+	// The width matters only when the text is wrapped.
+	if size := t.cachedTextSizePlus1[key]; size != (image.Point{}) && (!t.autoWrap || t.cachedTextSizeWidth[key] == width) {
 		return size.Sub(image.Pt(1, 1))
 	}
 
 	txt := t.textToDraw(context, true)
-	width := math.MaxInt
-	if w, ok := constraints.FixedWidth(); ok {
-		width = w
-	}
-	w, h := textutil.Measure(width, txt, t.autoWrap, t.face(context, forceBold), t.lineHeight(context), t.actualTabWidth(context), t.keepTailingSpace)
+	w, h := textutil.Measure(width, txt, &textutil.Options{
+		AutoWrap:         t.autoWrap,
+		Face:             t.face(context, forceBold),
+		LineHeight:       t.lineHeight(context),
+		TabWidth:         t.actualTabWidth(context),
+		KeepTailingSpace: t.keepTailingSpace,
+		Spans:            t.textutilSpans(context, forceBold),
+	})
 	// If width is 0, the text's bounds and visible bounds are empty, and nothing including its cursor is rendered.
 	// Force to set a positive number as the width.
 	w = max(w, 1)
 
 	s := image.Pt(int(math.Ceil(w)), int(math.Ceil(h)))
 	t.cachedTextSizePlus1[key] = s.Add(image.Pt(1, 1))
+	t.cachedTextSizeWidth[key] = width
 
 	return s
 }
 
 func (t *Text) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	if _, ok := t.linkAt(context, context.PointerPosition()); ok {
+		return ebiten.CursorShapePointer, true
+	}
 	if t.selectable || t.editable {
 		return ebiten.CursorShapeText, true
 	}
@@ -1068,9 +1139,11 @@ func (t *Text) textIndexFromPosition(context *guigui.Context, position image.Poi
 		Face:             t.face(context, false),
 		LineHeight:       t.lineHeight(context),
 		HorizontalAlign:  textutil.HorizontalAlign(t.hAlign),
+		RightToLeft:      context.IsRightToLeft(),
 		VerticalAlign:    textutil.VerticalAlign(t.vAlign),
 		TabWidth:         t.actualTabWidth(context),
 		KeepTailingSpace: t.keepTailingSpace,
+		Spans:            t.textutilSpans(context, false),
 	}
 	position = position.Sub(textBounds.Min)
 	idx := textutil.TextIndexFromPosition(textBounds.Dx(), position, txt, op)
@@ -1091,9 +1164,11 @@ func (t *Text) textPosition(context *guigui.Context, index int, showComposition
 		Face:             t.face(context, false),
 		LineHeight:       t.lineHeight(context),
 		HorizontalAlign:  textutil.HorizontalAlign(t.hAlign),
+		RightToLeft:      context.IsRightToLeft(),
 		VerticalAlign:    textutil.VerticalAlign(t.vAlign),
 		TabWidth:         t.actualTabWidth(context),
 		KeepTailingSpace: t.keepTailingSpace,
+		Spans:            t.textutilSpans(context, false),
 	}
 	pos0, pos1, count := textutil.TextPositionFromIndex(textBounds.Dx(), txt, index, op)
 	if count == 0 {
@@ -1110,6 +1185,67 @@ func (t *Text) textPosition(context *guigui.Context, index int, showComposition
 	}, true
 }
 
+// nextVisualPosition returns the position next to index visually.
+// If right is true, the position at the right of index is returned. Otherwise, the position at the left of index is returned.
+// At the edge of a line, the position moves logically to the next or previous line.
+func (t *Text) nextVisualPosition(context *guigui.Context, index int, right bool) int {
+	txt := t.field.Text()
+	op := &textutil.Options{
+		AutoWrap:         t.autoWrap,
+		Face:             t.face(context, false),
+		LineHeight:       t.lineHeight(context),
+		HorizontalAlign:  textutil.HorizontalAlign(t.hAlign),
+		RightToLeft:      context.IsRightToLeft(),
+		VerticalAlign:    textutil.VerticalAlign(t.vAlign),
+		TabWidth:         t.actualTabWidth(context),
+		KeepTailingSpace: t.keepTailingSpace,
+		Spans:            t.textutilSpans(context, false),
+	}
+	if pos, ok := textutil.NextVisualPosition(t.actualTextBounds(context).Dx(), txt, index, right, op); ok {
+		return pos
+	}
+	// In a right-to-left paragraph, the left edge is the end of the line.
+	if right != textutil.IsRightToLeftParagraph(txt, index, context.IsRightToLeft()) {
+		return textutil.NextPositionOnGraphemes(txt, index)
+	}
+	return textutil.PrevPositionOnGraphemes(txt, index)
+}
+
+// moveCursorVisually moves the cursor to the left or the right by the arrow keys.
+// With the shift key, the selection is extended.
+func (t *Text) moveCursorVisually(context *guigui.Context, right bool) {
+	start, end := t.field.Selection()
+	// forward reports whether the cursor moves forward in the logical order in the paragraph.
+	forward := right != textutil.IsRightToLeftParagraph(t.field.Text(), start, context.IsRightToLeft())
+
+	if !ebiten.IsKeyPressed(ebiten.KeyShift) {
+		switch {
+		case start != end && forward:
+			t.setTextAndSelection(t.field.Text(), end, end, -1)
+		case start != end && !forward:
+			t.setTextAndSelection(t.field.Text(), start, start, -1)
+		default:
+			pos := t.nextVisualPosition(context, start, right)
+			t.setTextAndSelection(t.field.Text(), pos, pos, -1)
+		}
+		return
+	}
+
+	// The shift index is the moving edge of the selection, and the other edge is the anchor.
+	moving, anchor := start, end
+	if forward {
+		moving, anchor = end, start
+	}
+	switch t.selectionShiftIndexPlus1 - 1 {
+	case start:
+		moving, anchor = start, end
+	case end:
+		moving, anchor = end, start
+	}
+	pos := t.nextVisualPosition(context, moving, right)
+	t.setTextAndSelection(t.field.Text(), anchor, pos, pos)
+}
+
 func textCursorWidth(context *guigui.Context) int {
 	return int(2 * context.Scale())
 }
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+)
+
+// TextFindOptions represents options to find a text.
+type TextFindOptions struct {
+	CaseSensitive bool
+	WholeWord     bool
+
+	// Regexp makes the query a regular expression in the syntax of the regexp package.
+	Regexp bool
+}
+
+type textFind struct {
+	query   string
+	options TextFindOptions
+	re      *regexp.Regexp
+	err     error
+
+	matches      []TextRange
+	matchesText  string
+	matchesValid bool
+}
+
+func newTextFindRegexp(query string, options TextFindOptions) (*regexp.Regexp, error) {
+	if query == "" {
+		return nil, nil
+	}
+	if !options.Regexp {
+		query = regexp.QuoteMeta(query)
+	}
+	if !options.CaseSensitive {
+		query = "(?i)" + query
+	}
+	return regexp.Compile(query)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// isWholeWord reports whether the range [start, end) in str starts and ends at word boundaries.
+func isWholeWord(str string, start, end int) bool {
+	if start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(str[:start])
+		first, _ := utf8.DecodeRuneInString(str[start:end])
+		if isWordRune(before) && isWordRune(first) {
+			return false
+		}
+	}
+	if end < len(str) {
+		last, _ := utf8.DecodeLastRuneInString(str[start:end])
+		after, _ := utf8.DecodeRuneInString(str[end:])
+		if isWordRune(last) && isWordRune(after) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendTextFindMatches appends the ranges of re in str.
+// Empty matches are ignored.
+func appendTextFindMatches(matches []TextRange, str string, re *regexp.Regexp, wholeWord bool) []TextRange {
+	if re == nil {
+		return matches
+	}
+	for _, loc := range re.FindAllStringIndex(str, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		if wholeWord && !isWholeWord(str, loc[0], loc[1]) {
+			continue
+		}
+		matches = append(matches, TextRange{Start: loc[0], End: loc[1]})
+	}
+	return matches
+}
+
+// SetFindQuery sets the query to find in the text.
+// All the matches are highlighted. An empty query clears the matches.
+func (t *Text) SetFindQuery(query string, options TextFindOptions) {
+	if t.find.query == query && t.find.options == options {
+		return
+	}
+	t.find.query = query
+	t.find.options = options
+	t.find.re, t.find.err = newTextFindRegexp(query, options)
+	t.find.matchesValid = false
+	guigui.RequestRedraw(t)
+}
+
+// FindError returns an error of the query, e.g. an invalid regular expression.
+func (t *Text) FindError() error {
+	return t.find.err
+}
+
+func (t *Text) findMatches() []TextRange {
+	if t.find.matchesValid && t.find.matchesText == t.field.Text() {
+		return t.find.matches
+	}
+	t.find.matches = appendTextFindMatches(t.find.matches[:0], t.field.Text(), t.find.re, t.find.options.WholeWord)
+	t.find.matchesText = t.field.Text()
+	t.find.matchesValid = true
+	return t.find.matches
+}
+
+// FindMatchCount returns the number of the matches of the query.
+func (t *Text) FindMatchCount() int {
+	return len(t.findMatches())
+}
+
+// CurrentFindMatchIndex returns the index of the match that is selected.
+func (t *Text) CurrentFindMatchIndex() (int, bool) {
+	start, end := t.field.Selection()
+	for i, m := range t.findMatches() {
+		if m.Start == start && m.End == end {
+			return i, true
+		}
+		if m.Start > start {
+			break
+		}
+	}
+	return 0, false
+}
+
+// FindNext selects the next match after the selection.
+// FindNext reports whether a match is found.
+func (t *Text) FindNext() bool {
+	matches := t.findMatches()
+	if len(matches) == 0 {
+		return false
+	}
+	// If the selection is empty, a match at the cursor is the next match.
+	start, end := t.field.Selection()
+	m := matches[0]
+	for _, mm := range matches {
+		if mm.Start > start || (start == end && mm.Start == start) {
+			m = mm
+			break
+		}
+	}
+	t.setSelection(m.Start, m.End)
+	return true
+}
+
+// FindPrevious selects the previous match before the selection.
+// FindPrevious reports whether a match is found.
+func (t *Text) FindPrevious() bool {
+	matches := t.findMatches()
+	if len(matches) == 0 {
+		return false
+	}
+	start, _ := t.field.Selection()
+	m := matches[len(matches)-1]
+	for i := len(matches) - 1; i >= 0; i-- {
+		if matches[i].Start < start {
+			m = matches[i]
+			break
+		}
+	}
+	t.setSelection(m.Start, m.End)
+	return true
+}
+
+// expandReplacement returns the replacement for the match m.
+// In the regexp mode, $1 or ${name} in replacement is expanded to the submatch.
+func (t *Text) expandReplacement(dst []byte, replacement string, m TextRange) []byte {
+	if !t.find.options.Regexp {
+		return append(dst, replacement...)
+	}
+	str := t.field.Text()
+	for _, loc := range t.find.re.FindAllStringSubmatchIndex(str, -1) {
+		if loc[0] == m.Start && loc[1] == m.End {
+			return t.find.re.ExpandString(dst, replacement, str, loc)
+		}
+		if loc[0] > m.Start {
+			break
+		}
+	}
+	return append(dst, replacement...)
+}
+
+// Replace replaces the selected match with replacement, and then selects the next match.
+// If the selection is not a match, Replace selects the next match without replacing.
+// Replace reports whether a text is replaced.
+func (t *Text) Replace(replacement string) bool {
+	idx, ok := t.CurrentFindMatchIndex()
+	if !ok {
+		t.FindNext()
+		return false
+	}
+	m := t.findMatches()[idx]
+	str := t.field.Text()
+	r := string(t.expandReplacement(nil, replacement, m))
+	t.setTextAndSelection(str[:m.Start]+r+str[m.End:], m.Start+len(r), m.Start+len(r), -1)
+	t.commit()
+	t.FindNext()
+	return true
+}
+
+// ReplaceAll replaces all the matches with replacement, and returns the number of the replaced matches.
+func (t *Text) ReplaceAll(replacement string) int {
+	matches := t.findMatches()
+	if len(matches) == 0 {
+		return 0
+	}
+	str := t.field.Text()
+	var buf []byte
+	var last int
+	for _, loc := range t.find.re.FindAllStringSubmatchIndex(str, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		if t.find.options.WholeWord && !isWholeWord(str, loc[0], loc[1]) {
+			continue
+		}
+		buf = append(buf, str[last:loc[0]]...)
+		if t.find.options.Regexp {
+			buf = t.find.re.ExpandString(buf, replacement, str, loc)
+		} else {
+			buf = append(buf, replacement...)
+		}
+		last = loc[1]
+	}
+	buf = append(buf, str[last:]...)
+	n := len(matches)
+	newStr := string(buf)
+	// Put the cursor at the end of the last replacement.
+	cursor := len(newStr) - (len(str) - last)
+	t.setTextAndSelection(newStr, cursor, cursor, -1)
+	t.commit()
+	return n
+}
+
+func (t *Text) appendFindHighlights(context *guigui.Context, highlights []textutil.Highlight) []textutil.Highlight {
+	if t.find.re == nil {
+		return highlights
+	}
+	start, end := t.field.Selection()
+	matchClr := draw.Color2(context.ColorMode(), draw.ColorTypeAccent, 0.9, 0.3)
+	currentClr := draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.8)
+	for _, m := range t.findMatches() {
+		clr := matchClr
+		if m.Start == start && m.End == end {
+			clr = currentClr
+		}
+		highlights = append(highlights, textutil.Highlight{
+			Start: m.Start,
+			End:   m.End,
+			Color: clr,
+		})
+	}
+	return highlights
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestFindTextMatches(t *testing.T) {
+	testCases := []struct {
+		str     string
+		query   string
+		options basicwidget.TextFindOptions
+		matches []basicwidget.TextRange
+	}{
+		{
+			str:     "foo Foo fooBar",
+			query:   "foo",
+			matches: []basicwidget.TextRange{{Start: 0, End: 3}, {Start: 4, End: 7}, {Start: 8, End: 11}},
+		},
+		{
+			str:     "foo Foo fooBar",
+			query:   "foo",
+			options: basicwidget.TextFindOptions{CaseSensitive: true},
+			matches: []basicwidget.TextRange{{Start: 0, End: 3}, {Start: 8, End: 11}},
+		},
+		{
+			str:     "foo Foo fooBar",
+			query:   "foo",
+			options: basicwidget.TextFindOptions{WholeWord: true},
+			matches: []basicwidget.TextRange{{Start: 0, End: 3}, {Start: 4, End: 7}},
+		},
+		{
+			str:     "a.b axb",
+			query:   "a.b",
+			matches: []basicwidget.TextRange{{Start: 0, End: 3}},
+		},
+		{
+			str:     "a.b axb",
+			query:   "a.b",
+			options: basicwidget.TextFindOptions{Regexp: true},
+			matches: []basicwidget.TextRange{{Start: 0, End: 3}, {Start: 4, End: 7}},
+		},
+		{
+			str:     "abc",
+			query:   "x*",
+			options: basicwidget.TextFindOptions{Regexp: true},
+		},
+		{
+			str:   "abc",
+			query: "",
+		},
+	}
+	for _, tc := range testCases {
+		got, err := basicwidget.FindTextMatches(tc.str, tc.query, tc.options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tc.matches) {
+			t.Errorf("FindTextMatches(%q, %q, %+v): got: %v, want: %v", tc.str, tc.query, tc.options, got, tc.matches)
+		}
+	}
+}
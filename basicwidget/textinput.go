@@ -7,7 +7,6 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
@@ -34,12 +33,19 @@ type TextInput struct {
 	frame          textInputFrame
 	scrollOverlay  scrollOverlay
 	focus          textInputFocus
+	gutter         textInputGutter
 
 	style        TextInputStyle
 	readonly     bool
 	paddingStart int
 	paddingEnd   int
 
+	codeEditorMode bool
+	findBarEnabled bool
+	findBar        *textInputFindBar
+
+	click guigui.ClickRecognizer
+
 	prevFocused bool
 	prevStart   int
 	prevEnd     int
@@ -142,6 +148,16 @@ func (t *TextInput) SetIcon(icon *ebiten.Image) {
 	t.icon.SetImage(icon)
 }
 
+// SetVectorIcon sets a resolution-independent icon instead of an image icon.
+func (t *TextInput) SetVectorIcon(icon *VectorIcon) {
+	t.icon.SetVectorIcon(icon)
+}
+
+// SetIconName sets the icon registered by RegisterIcon or RegisterVectorIcon.
+func (t *TextInput) SetIconName(name string) {
+	t.icon.SetIconName(name)
+}
+
 func (t *TextInput) textInputPaddingInScrollableContent(context *guigui.Context) (start, top, end, bottom int) {
 	var x, y int
 	switch t.style {
@@ -155,6 +171,9 @@ func (t *TextInput) textInputPaddingInScrollableContent(context *guigui.Context)
 	if t.icon.HasImage() {
 		start += defaultIconSize(context)
 	}
+	if t.isGutterVisible() {
+		start += t.gutterWidth(context)
+	}
 	top = y
 	end = x + t.paddingEnd
 	bottom = y
@@ -174,12 +193,18 @@ func (t *TextInput) isFocused(context *guigui.Context) bool {
 func (t *TextInput) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
 	adder.AddChild(&t.background)
 	adder.AddChild(&t.text)
+	if t.isGutterVisible() {
+		adder.AddChild(&t.gutter)
+	}
 	if t.icon.HasImage() {
 		adder.AddChild(&t.iconBackground)
 		adder.AddChild(&t.icon)
 	}
 	adder.AddChild(&t.frame)
 	adder.AddChild(&t.scrollOverlay)
+	if t.IsFindBarOpen() {
+		adder.AddChild(t.findBar)
+	}
 	if t.style != TextInputStyleInline && (context.IsFocused(t) || context.IsFocused(&t.text)) {
 		adder.AddChild(&t.focus)
 	}
@@ -196,7 +221,11 @@ func (t *TextInput) textBounds(context *guigui.Context) image.Rectangle {
 		Min: pt,
 		Max: pt.Add(s),
 	}
-	b = b.Add(image.Pt(paddingStart, paddingTop))
+	paddingLeft := paddingStart
+	if context.IsRightToLeft() {
+		paddingLeft = paddingEnd
+	}
+	b = b.Add(image.Pt(paddingLeft, paddingTop))
 
 	// As the text is rendered in an inset box, shift the text bounds down by 0.5 pixel.
 	b = b.Add(image.Pt(0, int(0.5*context.Scale())))
@@ -213,6 +242,15 @@ func (t *TextInput) Update(context *guigui.Context) error {
 		guigui.RequestRedraw(t)
 	}
 
+	// The gutter width is needed to calculate the content size.
+	if t.isGutterVisible() {
+		t.gutter.textInput = t
+		t.gutter.update(t.text.Value())
+		context.SetCustomDraw(&t.gutter, func(dst, widgetImage *ebiten.Image, op *ebiten.DrawImageOptions) {
+			draw.DrawInRoundedCornerRect(context, dst, context.Bounds(t), RoundedCornerRadius(context), widgetImage, op)
+		})
+	}
+
 	t.scrollOverlay.SetContentSize(context, t.scrollContentSize(context))
 
 	t.background.textInput = t
@@ -245,6 +283,10 @@ func (t *TextInput) Update(context *guigui.Context) error {
 
 	context.SetVisible(&t.scrollOverlay, t.text.IsMultiline())
 
+	if t.IsFindBarOpen() {
+		t.findBar.textInput = t
+	}
+
 	if t.style != TextInputStyleInline && (context.IsFocused(t) || context.IsFocused(&t.text)) {
 		t.focus.textInput = t
 	}
@@ -268,16 +310,42 @@ func (t *TextInput) Layout(context *guigui.Context, widget guigui.Widget) image.
 		})
 		imgBounds.Max = imgBounds.Min.Add(image.Pt(iconSize, iconSize))
 		if widget == &t.icon {
+			if context.IsRightToLeft() {
+				imgBounds = guigui.MirrorRectangle(imgBounds, b)
+			}
 			return imgBounds
 		}
 
 		imgBgBounds := b
 		imgBgBounds.Max.X = imgBounds.Max.X + UnitSize(context)/4
+		if context.IsRightToLeft() {
+			imgBgBounds = guigui.MirrorRectangle(imgBgBounds, b)
+		}
 		return imgBgBounds
 	case &t.frame:
 		return context.Bounds(t)
 	case &t.scrollOverlay:
 		return context.Bounds(t)
+	case &t.gutter:
+		b := context.Bounds(t)
+		b.Max.X = b.Min.X + t.gutterWidth(context)
+		if context.IsRightToLeft() {
+			b = guigui.MirrorRectangle(b, context.Bounds(t))
+		}
+		return b
+	case t.findBar:
+		b := context.Bounds(t)
+		u := UnitSize(context)
+		s := t.findBar.Measure(context, guigui.Constraints{})
+		s.X = min(s.X, b.Dx()-u/2)
+		r := image.Rectangle{
+			Min: image.Pt(b.Max.X-u/4-s.X, b.Min.Y+u/4),
+			Max: image.Pt(b.Max.X-u/4, b.Min.Y+u/4+s.Y),
+		}
+		if context.IsRightToLeft() {
+			r = guigui.MirrorRectangle(r, b)
+		}
+		return r
 	case &t.focus:
 		w := textInputFocusBorderWidth(context)
 		p := context.Bounds(t).Min.Add(image.Pt(-w, -w))
@@ -300,26 +368,35 @@ func (t *TextInput) adjustScrollOffsetIfNeeded(context *guigui.Context) {
 	t.prevStart = start
 	t.prevEnd = end
 	bounds := context.Bounds(t)
-	paddingStart, paddingTop, paddingEnd, paddingBottom := t.textInputPaddingInScrollableContent(context)
+	paddingLeft, paddingTop, paddingRight, paddingBottom := t.textInputPaddingInScrollableContent(context)
+	if context.IsRightToLeft() {
+		paddingLeft, paddingRight = paddingRight, paddingLeft
+	}
 	if pos, ok := t.text.textPosition(context, end, true); ok {
-		dx := min(float64(bounds.Max.X-paddingEnd)-pos.X, 0)
+		dx := min(float64(bounds.Max.X-paddingRight)-pos.X, 0)
 		dy := min(float64(bounds.Max.Y-paddingBottom)-pos.Bottom, 0)
 		t.scrollOverlay.SetOffsetByDelta(context, t.scrollContentSize(context), dx, dy)
 	}
 	if pos, ok := t.text.textPosition(context, start, true); ok {
-		dx := max(float64(bounds.Min.X+paddingStart)-pos.X, 0)
+		dx := max(float64(bounds.Min.X+paddingLeft)-pos.X, 0)
 		dy := max(float64(bounds.Min.Y+paddingTop)-pos.Top, 0)
 		t.scrollOverlay.SetOffsetByDelta(context, t.scrollContentSize(context), dx, dy)
 	}
 }
 
 func (t *TextInput) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
-	cp := image.Pt(ebiten.CursorPosition())
-	if context.IsWidgetHitAtCursor(t) {
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			t.text.handleClick(context, cp)
-			return guigui.HandleInputByWidget(t)
-		}
+	t.click.Update(context, t)
+	if t.click.JustPressed() {
+		t.text.handleClick(context, context.PointerPosition(), t.click.Count())
+		return guigui.HandleInputByWidget(t)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (t *TextInput) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if t.findBarEnabled && t.text.IsMultiline() && isFindShortcutJustPressed() {
+		t.OpenFindBar()
+		return guigui.HandleInputByWidget(t)
 	}
 	return guigui.HandleInputResult{}
 }
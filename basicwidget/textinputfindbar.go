@@ -0,0 +1,329 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+// SetFindBarEnabled enables the built-in find bar opened by Ctrl+F (Cmd+F on macOS).
+// The find bar is available only for a multiline text input.
+func (t *TextInput) SetFindBarEnabled(enabled bool) {
+	if t.findBarEnabled == enabled {
+		return
+	}
+	t.findBarEnabled = enabled
+	if !enabled {
+		t.CloseFindBar()
+	}
+	guigui.RequestRedraw(t)
+}
+
+func (t *TextInput) IsFindBarOpen() bool {
+	return t.findBar != nil && t.findBar.open
+}
+
+func (t *TextInput) OpenFindBar() {
+	if t.findBar == nil {
+		t.findBar = &textInputFindBar{}
+	}
+	b := t.findBar
+	b.focusQuery = true
+	if start, end := t.text.field.Selection(); start != end {
+		if str := t.text.field.Text()[start:end]; !strings.Contains(str, "\n") {
+			b.queryInput.ForceSetValue(str)
+		}
+	}
+	if b.open {
+		return
+	}
+	b.open = true
+	t.text.SetFindQuery(b.queryInput.Value(), b.options)
+	guigui.RequestRedraw(t)
+}
+
+func (t *TextInput) CloseFindBar() {
+	if !t.IsFindBarOpen() {
+		return
+	}
+	t.findBar.open = false
+	t.text.SetFindQuery("", TextFindOptions{})
+	guigui.RequestRedraw(t)
+}
+
+func (t *TextInput) SetFindQuery(query string, options TextFindOptions) {
+	t.text.SetFindQuery(query, options)
+}
+
+func (t *TextInput) FindMatchCount() int {
+	return t.text.FindMatchCount()
+}
+
+func (t *TextInput) FindNext() bool {
+	return t.text.FindNext()
+}
+
+func (t *TextInput) FindPrevious() bool {
+	return t.text.FindPrevious()
+}
+
+func (t *TextInput) Replace(replacement string) bool {
+	return t.text.Replace(replacement)
+}
+
+func (t *TextInput) ReplaceAll(replacement string) int {
+	return t.text.ReplaceAll(replacement)
+}
+
+func isFindShortcutJustPressed() bool {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		return false
+	}
+	if useEmacsKeybind() {
+		return ebiten.IsKeyPressed(ebiten.KeyMeta)
+	}
+	return ebiten.IsKeyPressed(ebiten.KeyControl)
+}
+
+type textInputFindBar struct {
+	guigui.DefaultWidget
+
+	background       textInputFindBarBackground
+	queryInput       TextInput
+	countText        Text
+	caseButton       Button
+	wordButton       Button
+	regexpButton     Button
+	prevButton       Button
+	nextButton       Button
+	closeButton      Button
+	replaceInput     TextInput
+	replaceButton    Button
+	replaceAllButton Button
+
+	textInput  *TextInput
+	open       bool
+	focusQuery bool
+	options    TextFindOptions
+
+	queryItems   []guigui.LinearLayoutItem
+	replaceItems []guigui.LinearLayoutItem
+	rowItems     []guigui.LinearLayoutItem
+}
+
+func (b *textInputFindBar) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&b.background)
+	adder.AddChild(&b.queryInput)
+	adder.AddChild(&b.countText)
+	adder.AddChild(&b.caseButton)
+	adder.AddChild(&b.wordButton)
+	adder.AddChild(&b.regexpButton)
+	adder.AddChild(&b.prevButton)
+	adder.AddChild(&b.nextButton)
+	adder.AddChild(&b.closeButton)
+	if b.textInput.IsEditable() {
+		adder.AddChild(&b.replaceInput)
+		adder.AddChild(&b.replaceButton)
+		adder.AddChild(&b.replaceAllButton)
+	}
+}
+
+func (b *textInputFindBar) Update(context *guigui.Context) error {
+	text := &b.textInput.text
+
+	b.queryInput.SetOnValueChanged(func(query string, committed bool) {
+		text.SetFindQuery(query, b.options)
+		// Select the first match from the cursor as the query is being typed.
+		start, _ := text.field.Selection()
+		text.setSelection(start, start)
+		text.FindNext()
+	})
+	b.queryInput.SetOnKeyJustPressed(func(key ebiten.Key) bool {
+		switch key {
+		case ebiten.KeyEnter, ebiten.KeyNumpadEnter:
+			if ebiten.IsKeyPressed(ebiten.KeyShift) {
+				text.FindPrevious()
+			} else {
+				text.FindNext()
+			}
+			return true
+		case ebiten.KeyEscape:
+			b.close(context)
+			return true
+		}
+		return false
+	})
+	b.replaceInput.SetOnKeyJustPressed(func(key ebiten.Key) bool {
+		switch key {
+		case ebiten.KeyEnter, ebiten.KeyNumpadEnter:
+			text.Replace(b.replaceInput.Value())
+			return true
+		case ebiten.KeyEscape:
+			b.close(context)
+			return true
+		}
+		return false
+	})
+
+	b.setOptionButton(context, &b.caseButton, "Aa", b.options.CaseSensitive, func() {
+		b.options.CaseSensitive = !b.options.CaseSensitive
+	})
+	b.setOptionButton(context, &b.wordButton, "W", b.options.WholeWord, func() {
+		b.options.WholeWord = !b.options.WholeWord
+	})
+	b.setOptionButton(context, &b.regexpButton, ".*", b.options.Regexp, func() {
+		b.options.Regexp = !b.options.Regexp
+	})
+
+	b.prevButton.SetVectorIcon(vectorIconKeyboardArrowUp)
+	b.prevButton.SetOnDown(func() {
+		text.FindPrevious()
+	})
+	b.nextButton.SetVectorIcon(vectorIconKeyboardArrowDown)
+	b.nextButton.SetOnDown(func() {
+		text.FindNext()
+	})
+	b.closeButton.SetText("×")
+	b.closeButton.SetOnUp(func() {
+		b.close(context)
+	})
+
+	b.replaceButton.SetText("Replace")
+	b.replaceButton.SetOnUp(func() {
+		text.Replace(b.replaceInput.Value())
+	})
+	b.replaceAllButton.SetText("All")
+	b.replaceAllButton.SetOnUp(func() {
+		text.ReplaceAll(b.replaceInput.Value())
+	})
+
+	if err := text.FindError(); err != nil {
+		b.countText.SetValue("Error")
+	} else if b.queryInput.Value() == "" {
+		b.countText.SetValue("")
+	} else if n := text.FindMatchCount(); n == 0 {
+		b.countText.SetValue("No results")
+	} else if idx, ok := text.CurrentFindMatchIndex(); ok {
+		b.countText.SetValue(fmt.Sprintf("%d/%d", idx+1, n))
+	} else {
+		b.countText.SetValue(fmt.Sprintf("?/%d", n))
+	}
+	b.countText.SetVerticalAlign(VerticalAlignMiddle)
+	b.countText.SetHorizontalAlign(HorizontalAlignCenter)
+	b.countText.SetTabular(true)
+	context.SetEnabled(&b.prevButton, text.FindMatchCount() > 0)
+	context.SetEnabled(&b.nextButton, text.FindMatchCount() > 0)
+
+	if b.focusQuery {
+		context.SetFocused(&b.queryInput, true)
+		b.queryInput.SelectAll()
+		b.focusQuery = false
+	}
+
+	return nil
+}
+
+func (b *textInputFindBar) setOptionButton(context *guigui.Context, button *Button, label string, on bool, toggle func()) {
+	button.SetText(label)
+	button.SetTextBold(on)
+	if on {
+		button.SetTextColor(draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5))
+	} else {
+		button.SetTextColor(nil)
+	}
+	button.SetOnUp(func() {
+		toggle()
+		b.textInput.text.SetFindQuery(b.queryInput.Value(), b.options)
+	})
+}
+
+func (b *textInputFindBar) close(context *guigui.Context) {
+	b.textInput.CloseFindBar()
+	context.SetFocused(&b.textInput.text, true)
+}
+
+func (b *textInputFindBar) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	if widget == &b.background {
+		return context.Bounds(b)
+	}
+
+	u := UnitSize(context)
+	b.queryItems = append(b.queryItems[:0],
+		guigui.LinearLayoutItem{Widget: &b.queryInput, Size: guigui.FlexibleSize(1)},
+		guigui.LinearLayoutItem{Widget: &b.countText, Size: guigui.FixedSize(3 * u)},
+		guigui.LinearLayoutItem{Widget: &b.caseButton, Size: guigui.FixedSize(u)},
+		guigui.LinearLayoutItem{Widget: &b.wordButton, Size: guigui.FixedSize(u)},
+		guigui.LinearLayoutItem{Widget: &b.regexpButton, Size: guigui.FixedSize(u)},
+		guigui.LinearLayoutItem{Widget: &b.prevButton, Size: guigui.FixedSize(u)},
+		guigui.LinearLayoutItem{Widget: &b.nextButton, Size: guigui.FixedSize(u)},
+		guigui.LinearLayoutItem{Widget: &b.closeButton, Size: guigui.FixedSize(u)},
+	)
+	b.replaceItems = append(b.replaceItems[:0],
+		guigui.LinearLayoutItem{Widget: &b.replaceInput, Size: guigui.FlexibleSize(1)},
+		guigui.LinearLayoutItem{Widget: &b.replaceButton},
+		guigui.LinearLayoutItem{Widget: &b.replaceAllButton},
+	)
+	b.rowItems = append(b.rowItems[:0], guigui.LinearLayoutItem{
+		Size: guigui.FixedSize(u),
+		Layout: guigui.LinearLayout{
+			Direction: guigui.LayoutDirectionHorizontal,
+			Items:     b.queryItems,
+			Gap:       u / 8,
+		},
+	})
+	if b.textInput.IsEditable() {
+		b.rowItems = append(b.rowItems, guigui.LinearLayoutItem{
+			Size: guigui.FixedSize(u),
+			Layout: guigui.LinearLayout{
+				Direction: guigui.LayoutDirectionHorizontal,
+				Items:     b.replaceItems,
+				Gap:       u / 8,
+			},
+		})
+	}
+	return (guigui.LinearLayout{
+		Direction: guigui.LayoutDirectionVertical,
+		Items:     b.rowItems,
+		Gap:       u / 8,
+		Padding: guigui.Padding{
+			Start:  u / 4,
+			Top:    u / 4,
+			End:    u / 4,
+			Bottom: u / 4,
+		},
+	}).WidgetBounds(context, context.Bounds(b), widget)
+}
+
+func (b *textInputFindBar) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	u := UnitSize(context)
+	h := u + u/2
+	if b.textInput.IsEditable() {
+		h += u + u/8
+	}
+	return image.Pt(16*u, h)
+}
+
+func (b *textInputFindBar) ZDelta() int {
+	return 1
+}
+
+type textInputFindBarBackground struct {
+	guigui.DefaultWidget
+}
+
+func (t *textInputFindBarBackground) Draw(context *guigui.Context, dst *ebiten.Image) {
+	bounds := context.Bounds(t)
+	clr := draw.Color(context.ColorMode(), draw.ColorTypeBase, 1)
+	draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context))
+	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset, false)
+	draw.DrawRoundedRectBorder(context, dst, bounds, clr1, clr2, RoundedCornerRadius(context), float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+}
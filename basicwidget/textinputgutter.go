@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+)
+
+// SetCodeEditorMode enables the mode to edit code.
+// In this mode, the text input uses a monospace face and shows line numbers in the gutter for a multiline text.
+// Enter keeps the indentation, Tab and Shift+Tab indent the selected lines, and the bracket pair at the cursor is highlighted.
+func (t *TextInput) SetCodeEditorMode(enabled bool) {
+	if t.codeEditorMode == enabled {
+		return
+	}
+	t.codeEditorMode = enabled
+	t.text.SetMonospace(enabled)
+	t.text.setCodeEditing(enabled)
+	guigui.RequestRedraw(t)
+}
+
+func (t *TextInput) SetMonospace(monospace bool) {
+	t.text.SetMonospace(monospace)
+}
+
+func (t *TextInput) SetTabWidth(tabWidth float64) {
+	t.text.SetTabWidth(tabWidth)
+}
+
+func (t *TextInput) isGutterVisible() bool {
+	return t.codeEditorMode && t.text.IsMultiline()
+}
+
+type textInputGutter struct {
+	guigui.DefaultWidget
+
+	textInput *TextInput
+	lineCount int
+	lineText  []byte
+}
+
+func (g *textInputGutter) update(str string) {
+	g.lineCount = strings.Count(str, "\n") + 1
+}
+
+func (t *TextInput) gutterWidth(context *guigui.Context) int {
+	digits := max(len(strconv.Itoa(t.gutter.lineCount)), 2)
+	face := t.text.face(context, false)
+	return int(float64(digits)*text.Advance("0", face)) + UnitSize(context)/2
+}
+
+func (g *textInputGutter) Draw(context *guigui.Context, dst *ebiten.Image) {
+	bounds := context.Bounds(g)
+	vector.DrawFilledRect(dst, float32(bounds.Min.X), float32(bounds.Min.Y), float32(bounds.Dx()), float32(bounds.Dy()), draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.95, 0.15), false)
+	borderX := float32(bounds.Max.X) - float32(context.Scale())
+	vector.StrokeLine(dst, borderX, float32(bounds.Min.Y), borderX, float32(bounds.Max.Y), float32(context.Scale()), draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.85, 0.25), false)
+
+	t := &g.textInput.text
+	var currentLine int
+	if context.IsFocused(t) {
+		start, _ := t.field.Selection()
+		currentLine = strings.Count(t.field.Text()[:start], "\n")
+	} else {
+		currentLine = -1
+	}
+
+	lineHeight := t.lineHeight(context)
+	op := &textutil.DrawOptions{
+		Options: textutil.Options{
+			Face:            t.face(context, false),
+			LineHeight:      lineHeight,
+			HorizontalAlign: textutil.HorizontalAlignRight,
+			VerticalAlign:   textutil.VerticalAlignTop,
+		},
+	}
+	vb := context.VisibleBounds(g)
+	r := bounds
+	r.Max.X -= UnitSize(context) / 4
+	// Only the visible lines are drawn. Search the first visible line, and stop the iteration after the visible area.
+	tops := t.logicalLineTops(context)
+	offsetY := float64(t.actualTextBounds(context).Min.Y)
+	first, _ := slices.BinarySearchFunc(tops, vb.Min.Y, func(top float64, minY int) int {
+		if int(top+offsetY+lineHeight) < minY {
+			return -1
+		}
+		return 1
+	})
+	for i := first; i < len(tops); i++ {
+		y := tops[i] + offsetY
+		if int(y) >= vb.Max.Y {
+			break
+		}
+		if i == currentLine {
+			op.TextColor = draw.TextColor(context.ColorMode(), true)
+		} else {
+			op.TextColor = draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.6, 0.5)
+		}
+		g.lineText = strconv.AppendInt(g.lineText[:0], int64(i+1), 10)
+		r.Min.Y = int(y)
+		r.Max.Y = int(y + lineHeight)
+		textutil.Draw(r, dst, string(g.lineText), op)
+	}
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+)
+
+const (
+	textEventLinkClicked = "linkClicked"
+)
+
+// TextSpan represents a styled range of a text in bytes.
+type TextSpan struct {
+	Start int
+	End   int
+
+	// Weight is the font weight. If Weight is 0, the text's weight is used.
+	Weight text.Weight
+
+	// Italic makes the span italic by the font variation.
+	// This works only with a font having an italic or a slant axis.
+	Italic bool
+
+	// Scale is the font scale relative to the text. If Scale is 0, 1 is used.
+	Scale float64
+
+	// Color is the text color. If Color is nil, the text's color is used.
+	// For a link span, the accent color is used instead.
+	Color color.Color
+
+	BackgroundColor color.Color
+	Underline       bool
+	Strikethrough   bool
+
+	// Link makes the span clickable when Link is not empty.
+	// Link is passed to the handler set by SetOnLinkClicked.
+	// A link span is always underlined.
+	Link string
+}
+
+func (t *TextSpan) scale() float64 {
+	if t.Scale == 0 {
+		return 1
+	}
+	return t.Scale
+}
+
+func (t *TextSpan) equal(other *TextSpan) bool {
+	return t.Start == other.Start &&
+		t.End == other.End &&
+		t.Weight == other.Weight &&
+		t.Italic == other.Italic &&
+		t.Scale == other.Scale &&
+		draw.EqualColor(t.Color, other.Color) &&
+		draw.EqualColor(t.BackgroundColor, other.BackgroundColor) &&
+		t.Underline == other.Underline &&
+		t.Strikethrough == other.Strikethrough &&
+		t.Link == other.Link
+}
+
+// SetSpans sets the styled ranges of the text.
+// The spans are not adjusted when the text is edited.
+// Overlapping spans are not supported.
+func (t *Text) SetSpans(spans []TextSpan) {
+	// Do not use slices.Equal, as comparing color.Color values might panic.
+	if slices.EqualFunc(t.spans, spans, func(a, b TextSpan) bool {
+		return a.equal(&b)
+	}) {
+		return
+	}
+	t.spans = adjustSliceSize(t.spans, len(spans))
+	copy(t.spans, spans)
+	slices.SortStableFunc(t.spans, func(a, b TextSpan) int {
+		return a.Start - b.Start
+	})
+	t.resetCachedTextSize()
+	guigui.RequestRedraw(t)
+}
+
+func (t *Text) SetOnLinkClicked(f func(link string)) {
+	guigui.RegisterEventHandler(t, textEventLinkClicked, f)
+}
+
+func (t *Text) spanFace(context *guigui.Context, span *TextSpan, forceBold bool) text.Face {
+	size := FontSize(context) * (t.scaleMinus1 + 1) * span.scale()
+	weight := text.WeightMedium
+	if t.bold || forceBold {
+		weight = text.WeightBold
+	}
+	if span.Weight != 0 {
+		weight = span.Weight
+	}
+	return fontFace(faceCacheKey{
+		size:      size,
+		weight:    weight,
+		liga:      !t.selectable && !t.editable,
+		tnum:      t.tabular,
+		italic:    span.Italic,
+		monospace: t.monospace,
+		lang:      t.lang(context),
+	})
+}
+
+func (t *Text) textutilSpans(context *guigui.Context, forceBold bool) []textutil.Span {
+	if len(t.spans) == 0 {
+		return nil
+	}
+	t.tmpSpans = slices.Delete(t.tmpSpans, 0, len(t.tmpSpans))
+	for i := range t.spans {
+		span := &t.spans[i]
+		clr := span.Color
+		if clr == nil && span.Link != "" {
+			clr = draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
+		}
+		bgClr := span.BackgroundColor
+		if t.transparent > 0 {
+			if clr != nil {
+				clr = draw.ScaleAlpha(clr, 1-t.transparent)
+			}
+			if bgClr != nil {
+				bgClr = draw.ScaleAlpha(bgClr, 1-t.transparent)
+			}
+		}
+		t.tmpSpans = append(t.tmpSpans, textutil.Span{
+			Start:           span.Start,
+			End:             span.End,
+			Face:            t.spanFace(context, span, forceBold),
+			Color:           clr,
+			BackgroundColor: bgClr,
+			Underline:       span.Underline || span.Link != "",
+			Strikethrough:   span.Strikethrough,
+			LineHeight:      t.lineHeight(context) * span.scale(),
+		})
+	}
+	return t.tmpSpans
+}
+
+// linkAt returns the link at the given position.
+func (t *Text) linkAt(context *guigui.Context, position image.Point) (string, bool) {
+	if !slices.ContainsFunc(t.spans, func(span TextSpan) bool {
+		return span.Link != ""
+	}) {
+		return "", false
+	}
+	if !position.In(t.actualTextBounds(context)) {
+		return "", false
+	}
+	idx := t.textIndexFromPosition(context, position, false)
+	if idx < 0 {
+		return "", false
+	}
+	for i := range t.spans {
+		span := &t.spans[i]
+		if span.Link == "" {
+			continue
+		}
+		if idx < span.Start || idx > span.End {
+			continue
+		}
+		// idx is the nearest boundary. Check the side of the position at the boundaries.
+		if idx == span.Start || idx == span.End {
+			pos, ok := t.textPosition(context, idx, false)
+			if !ok {
+				continue
+			}
+			if float64(position.Y) < pos.Top || float64(position.Y) >= pos.Bottom {
+				continue
+			}
+			if idx == span.Start && float64(position.X) < pos.X {
+				continue
+			}
+			if idx == span.End && float64(position.X) >= pos.X {
+				continue
+			}
+		}
+		return span.Link, true
+	}
+	return "", false
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"time"
+
+	"github.com/guigui-gui/guigui"
+)
+
+const (
+	timePickerEventValueChanged = "valueChanged"
+)
+
+const defaultTimePickerStep = 30 * time.Minute
+
+// TimePicker is a text input for a time of a day with a popup list of times.
+//
+// A user can type a time in the format of the app locale, or in the 24-hour format like "13:45".
+// The date part of the value is kept when the time is changed.
+type TimePicker struct {
+	guigui.DefaultWidget
+
+	comboBox ComboBox[time.Duration]
+
+	value    time.Time
+	location *time.Location
+	step     time.Duration
+	layout   string
+
+	itemsLayout string
+	itemsStep   time.Duration
+}
+
+// SetOnValueChanged sets the function called when the time is changed by a user.
+func (t *TimePicker) SetOnValueChanged(f func(value time.Time)) {
+	guigui.RegisterEventHandler(t, timePickerEventValueChanged, f)
+}
+
+// Value returns the time in the location of the time picker.
+// Value returns the zero time if no time is set.
+func (t *TimePicker) Value() time.Time {
+	if t.value.IsZero() {
+		return time.Time{}
+	}
+	return t.value.In(t.actualLocation())
+}
+
+// SetValue sets the time. If value is the zero time, the time is cleared.
+func (t *TimePicker) SetValue(value time.Time) {
+	if t.value.Equal(value) {
+		return
+	}
+	t.value = value
+	guigui.RequestRedraw(t)
+}
+
+// SetLocation sets the location to interpret the times. The default location is time.Local.
+func (t *TimePicker) SetLocation(location *time.Location) {
+	if t.location == location {
+		return
+	}
+	t.location = location
+	guigui.RequestRedraw(t)
+}
+
+// SetStep sets the interval of the times in the popup list. The default step is 30 minutes.
+func (t *TimePicker) SetStep(step time.Duration) {
+	if t.step == step {
+		return
+	}
+	t.step = step
+	guigui.RequestRedraw(t)
+}
+
+// SetLayout sets the layout to format a time as time.Time.Format does, e.g. "15:04:05".
+// The default layout follows the app locale.
+func (t *TimePicker) SetLayout(layout string) {
+	if t.layout == layout {
+		return
+	}
+	t.layout = layout
+	guigui.RequestRedraw(t)
+}
+
+func (t *TimePicker) IsOpen() bool {
+	return t.comboBox.IsOpen()
+}
+
+func (t *TimePicker) SetOpen(open bool) {
+	t.comboBox.SetOpen(open)
+}
+
+func (t *TimePicker) actualLocation() *time.Location {
+	if t.location != nil {
+		return t.location
+	}
+	return time.Local
+}
+
+func (t *TimePicker) actualStep() time.Duration {
+	if t.step > 0 {
+		return t.step
+	}
+	return defaultTimePickerStep
+}
+
+func (t *TimePicker) actualLayout(context *guigui.Context) string {
+	if t.layout != "" {
+		return t.layout
+	}
+	return timeLayout(t.comboBox.textInput.text.lang(context))
+}
+
+func (t *TimePicker) valueText(context *guigui.Context) string {
+	if t.value.IsZero() {
+		return ""
+	}
+	return t.Value().Format(t.actualLayout(context))
+}
+
+// setTimeOfDay sets the time of the day, which is the duration from midnight in the wall clock.
+// If no value is set, the date is today.
+func (t *TimePicker) setTimeOfDay(timeOfDay time.Duration) {
+	base := t.Value()
+	if base.IsZero() {
+		base = time.Now().In(t.actualLocation())
+	}
+	y, m, d := base.Date()
+	h := int(timeOfDay / time.Hour)
+	minute := int(timeOfDay % time.Hour / time.Minute)
+	sec := int(timeOfDay % time.Minute / time.Second)
+	v := time.Date(y, m, d, h, minute, sec, 0, t.actualLocation())
+	if t.value.Equal(v) {
+		return
+	}
+	t.value = v
+	guigui.RequestRedraw(t)
+	guigui.DispatchEventHandler(t, timePickerEventValueChanged, v)
+}
+
+func (t *TimePicker) updateItems(context *guigui.Context) {
+	layout := t.actualLayout(context)
+	step := t.actualStep()
+	if t.itemsLayout == layout && t.itemsStep == step {
+		return
+	}
+	t.itemsLayout = layout
+	t.itemsStep = step
+
+	var items []ComboBoxItem[time.Duration]
+	midnight := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for d := time.Duration(0); d < 24*time.Hour; d += step {
+		items = append(items, ComboBoxItem[time.Duration]{
+			Text:  midnight.Add(d).Format(layout),
+			Value: d,
+		})
+	}
+	t.comboBox.SetItems(items)
+}
+
+func (t *TimePicker) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&t.comboBox)
+}
+
+func (t *TimePicker) Update(context *guigui.Context) error {
+	t.updateItems(context)
+
+	// Set the text without filtering the items.
+	t.comboBox.textInput.SetValue(t.valueText(context))
+	t.comboBox.textInput.SetTabular(true)
+	t.comboBox.SetOnItemSelected(func(item ComboBoxItem[time.Duration]) {
+		t.setTimeOfDay(item.Value)
+	})
+	t.comboBox.SetOnValueChanged(func(text string, committed bool) {
+		if !committed {
+			return
+		}
+		if text == "" {
+			if !t.value.IsZero() {
+				t.value = time.Time{}
+				guigui.DispatchEventHandler(t, timePickerEventValueChanged, time.Time{})
+			}
+			return
+		}
+		if d, ok := parseTime(text, t.actualLayout(context)); ok {
+			t.setTimeOfDay(d)
+		}
+		t.comboBox.textInput.ForceSetValue(t.valueText(context))
+	})
+	return nil
+}
+
+func (t *TimePicker) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	switch widget {
+	case &t.comboBox:
+		return context.Bounds(t)
+	}
+	return image.Rectangle{}
+}
+
+func (t *TimePicker) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	return t.comboBox.Measure(context, constraints)
+}
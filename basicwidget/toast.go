@@ -0,0 +1,443 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+// toastZ is the z of toasts, which are above popups.
+const toastZ = 2 * popupZ
+
+const (
+	defaultToastDuration        = 4 * time.Second
+	defaultToastMaxVisibleCount = 3
+)
+
+func toastMaxOpeningCount() int {
+	return ebiten.TPS() / 5
+}
+
+type ToastSeverity int
+
+const (
+	ToastSeverityInfo ToastSeverity = iota
+	ToastSeveritySuccess
+	ToastSeverityWarning
+	ToastSeverityError
+)
+
+func (t ToastSeverity) colorType() draw.ColorType {
+	switch t {
+	case ToastSeveritySuccess:
+		return draw.ColorTypeSuccess
+	case ToastSeverityWarning:
+		return draw.ColorTypeWarning
+	case ToastSeverityError:
+		return draw.ColorTypeDanger
+	}
+	return draw.ColorTypeInfo
+}
+
+// ToastCorner is the corner of the app where toasts are stacked.
+// Start and End follow the writing direction.
+type ToastCorner int
+
+const (
+	ToastCornerBottomEnd ToastCorner = iota
+	ToastCornerBottomStart
+	ToastCornerTopEnd
+	ToastCornerTopStart
+)
+
+// Toast is a non-modal notification like "Saved".
+type Toast struct {
+	Text     string
+	Severity ToastSeverity
+
+	// ActionText is the text of the action button like "Undo".
+	// The action button is shown only when ActionText is not empty.
+	ActionText string
+
+	// OnAction is called when the action button is pressed. The toast is dismissed after OnAction is called.
+	OnAction func()
+
+	// Duration is the time to show the toast.
+	// Zero means the default duration, 4 seconds. A negative value means the toast is shown until a user closes it.
+	Duration time.Duration
+}
+
+// ToastID identifies a toast shown by ShowToast.
+type ToastID int64
+
+type toastEntry struct {
+	id    ToastID
+	toast Toast
+
+	widget toastWidget
+
+	remainingTicks int
+	openingCount   int
+	hiding         bool
+
+	// offset is the animated distance from the corner along the stack.
+	offset    float64
+	offsetSet bool
+}
+
+func (t *toastEntry) openingRate() float64 {
+	return easeOutQuad(float64(t.openingCount) / float64(toastMaxOpeningCount()))
+}
+
+// theToasts are the toasts waiting or shown, from the oldest.
+var (
+	theToasts      []*toastEntry
+	theNextToastID ToastID
+	theToaster     *Toaster
+)
+
+// ShowToast queues a toast and returns its ID.
+//
+// The toast is shown by a Toaster in the widget tree. If too many toasts are shown, the toast waits until another toast is dismissed.
+func ShowToast(context *guigui.Context, toast Toast) ToastID {
+	theNextToastID++
+	e := &toastEntry{
+		id:             theNextToastID,
+		toast:          toast,
+		remainingTicks: -1,
+	}
+	if toast.Duration >= 0 {
+		d := toast.Duration
+		if d == 0 {
+			d = defaultToastDuration
+		}
+		e.remainingTicks = int(d.Seconds() * float64(ebiten.TPS()))
+	}
+	e.widget.entry = e
+	theToasts = append(theToasts, e)
+	if theToaster != nil {
+		guigui.RequestRedraw(theToaster)
+	}
+	return e.id
+}
+
+// DismissToast hides the toast of id with an animation.
+// DismissToast does nothing if the toast is already dismissed.
+func DismissToast(context *guigui.Context, id ToastID) {
+	for _, e := range theToasts {
+		if e.id != id {
+			continue
+		}
+		if e.openingCount == 0 {
+			// The toast is not shown yet.
+			theToasts = slices.DeleteFunc(theToasts, func(e *toastEntry) bool {
+				return e.id == id
+			})
+		} else {
+			e.hiding = true
+		}
+		if theToaster != nil {
+			guigui.RequestRedraw(theToaster)
+		}
+		return
+	}
+}
+
+// Toaster shows the toasts queued by ShowToast.
+//
+// Add one Toaster to the root widget with the bounds of the app.
+// The toasts are stacked in a corner of the bounds above the other widgets including popups.
+// The timers to dismiss the toasts are paused while the cursor is on any toast.
+type Toaster struct {
+	guigui.DefaultWidget
+
+	corner          ToastCorner
+	maxVisibleCount int
+}
+
+// SetCorner sets the corner where the toasts are stacked. The default corner is ToastCornerBottomEnd.
+func (t *Toaster) SetCorner(corner ToastCorner) {
+	if t.corner == corner {
+		return
+	}
+	t.corner = corner
+	guigui.RequestRedraw(t)
+}
+
+// SetMaxVisibleCount sets the maximum number of the toasts shown at the same time. The default count is 3.
+func (t *Toaster) SetMaxVisibleCount(count int) {
+	if t.maxVisibleCount == count {
+		return
+	}
+	t.maxVisibleCount = count
+	guigui.RequestRedraw(t)
+}
+
+func (t *Toaster) actualMaxVisibleCount() int {
+	if t.maxVisibleCount > 0 {
+		return t.maxVisibleCount
+	}
+	return defaultToastMaxVisibleCount
+}
+
+func (t *Toaster) visibleToasts() []*toastEntry {
+	return theToasts[:min(len(theToasts), t.actualMaxVisibleCount())]
+}
+
+func (t *Toaster) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	for _, e := range t.visibleToasts() {
+		adder.AddChild(&e.widget)
+	}
+}
+
+func (t *Toaster) Update(context *guigui.Context) error {
+	theToaster = t
+	for _, e := range t.visibleToasts() {
+		e.widget.setEntry(context, e)
+	}
+	return nil
+}
+
+func (t *Toaster) toastWidth(context *guigui.Context) int {
+	u := UnitSize(context)
+	return max(min(14*u, context.Bounds(t).Dx()-u), 0)
+}
+
+// appendTargetOffsets appends the distances of the visible toasts from the corner.
+func (t *Toaster) appendTargetOffsets(context *guigui.Context, offsets []float64) []float64 {
+	w := t.toastWidth(context)
+	gap := UnitSize(context) / 4
+	var offset int
+	for _, e := range t.visibleToasts() {
+		offsets = append(offsets, float64(offset))
+		offset += e.widget.Measure(context, guigui.FixedWidthConstraints(w)).Y + gap
+	}
+	return offsets
+}
+
+func (t *Toaster) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	w, ok := widget.(*toastWidget)
+	if !ok {
+		return image.Rectangle{}
+	}
+	e := w.entry
+
+	b := context.Bounds(t)
+	u := UnitSize(context)
+	margin := u / 2
+	width := t.toastWidth(context)
+	height := w.Measure(context, guigui.FixedWidthConstraints(width)).Y
+
+	// Slide in from the outside.
+	slide := int(float64(u) * (1 - e.openingRate()))
+
+	var r image.Rectangle
+	switch t.corner {
+	case ToastCornerBottomEnd, ToastCornerTopEnd:
+		r.Min.X = b.Max.X - margin - width + slide
+	default:
+		r.Min.X = b.Min.X + margin - slide
+	}
+	switch t.corner {
+	case ToastCornerBottomEnd, ToastCornerBottomStart:
+		r.Min.Y = b.Max.Y - margin - height - int(e.offset)
+	default:
+		r.Min.Y = b.Min.Y + margin + int(e.offset)
+	}
+	r.Max = r.Min.Add(image.Pt(width, height))
+	if context.IsRightToLeft() {
+		r = guigui.MirrorRectangle(r, b)
+	}
+	return r
+}
+
+func (t *Toaster) Tick(context *guigui.Context) error {
+	visible := t.visibleToasts()
+	if len(visible) == 0 {
+		return nil
+	}
+
+	var hovered bool
+	for _, e := range visible {
+		if context.IsWidgetHitAtCursor(&e.widget) {
+			hovered = true
+			break
+		}
+	}
+
+	offsets := t.appendTargetOffsets(context, nil)
+	var removed bool
+	for i, e := range visible {
+		if e.hiding {
+			if e.openingCount > 0 {
+				e.openingCount--
+				guigui.RequestRedraw(&e.widget)
+			}
+			if e.openingCount == 0 {
+				removed = true
+			}
+		} else if e.openingCount < toastMaxOpeningCount() {
+			e.openingCount = min(e.openingCount+3, toastMaxOpeningCount())
+			guigui.RequestRedraw(&e.widget)
+		} else if !hovered && e.remainingTicks > 0 {
+			e.remainingTicks--
+			if e.remainingTicks == 0 {
+				e.hiding = true
+			}
+		}
+		context.SetOpacity(&e.widget, e.openingRate())
+
+		// Move the toast smoothly when the toasts before it are dismissed.
+		if !e.offsetSet {
+			e.offset = offsets[i]
+			e.offsetSet = true
+		}
+		if d := offsets[i] - e.offset; d != 0 {
+			if d > -1 && d < 1 {
+				e.offset = offsets[i]
+			} else {
+				e.offset += d / 3
+			}
+			guigui.RequestRedraw(&e.widget)
+		}
+	}
+	if removed {
+		theToasts = slices.DeleteFunc(theToasts, func(e *toastEntry) bool {
+			return e.hiding && e.openingCount == 0
+		})
+		guigui.RequestRedraw(t)
+	}
+	return nil
+}
+
+// toastWidget is a widget to show a toast.
+type toastWidget struct {
+	guigui.DefaultWidget
+
+	text         Text
+	actionButton Button
+	closeButton  Button
+
+	entry *toastEntry
+}
+
+func (t *toastWidget) setEntry(context *guigui.Context, entry *toastEntry) {
+	t.entry = entry
+	id := entry.id
+
+	t.text.SetValue(entry.toast.Text)
+	t.text.SetMultiline(true)
+	t.text.SetAutoWrap(true)
+	t.text.SetVerticalAlign(VerticalAlignMiddle)
+
+	t.actionButton.SetText(entry.toast.ActionText)
+	t.actionButton.SetTextBold(true)
+	t.actionButton.SetOnUp(func() {
+		if entry.toast.OnAction != nil {
+			entry.toast.OnAction()
+		}
+		DismissToast(context, id)
+	})
+
+	t.closeButton.SetText("×")
+	t.closeButton.SetOnUp(func() {
+		DismissToast(context, id)
+	})
+}
+
+func (t *toastWidget) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	adder.AddChild(&t.text)
+	if t.entry.toast.ActionText != "" {
+		adder.AddChild(&t.actionButton)
+	}
+	adder.AddChild(&t.closeButton)
+}
+
+func (t *toastWidget) layout(context *guigui.Context, items []guigui.LinearLayoutItem) guigui.LinearLayout {
+	u := UnitSize(context)
+	// The severity is shown at the start edge.
+	stripeWidth := u / 8
+	items = append(items, guigui.LinearLayoutItem{Widget: &t.text, Size: guigui.FlexibleSize(1)})
+	if t.entry.toast.ActionText != "" {
+		items = append(items, guigui.LinearLayoutItem{Widget: &t.actionButton})
+	}
+	items = append(items, guigui.LinearLayoutItem{Widget: &t.closeButton, Size: guigui.FixedSize(u)})
+	return guigui.LinearLayout{
+		Direction: guigui.LayoutDirectionHorizontal,
+		Items:     items,
+		Gap:       u / 4,
+		Padding: guigui.Padding{
+			Start:  stripeWidth + u/2,
+			Top:    u / 4,
+			End:    u / 4,
+			Bottom: u / 4,
+		},
+	}
+}
+
+func (t *toastWidget) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	var items [3]guigui.LinearLayoutItem
+	b := context.Bounds(t)
+	r := t.layout(context, items[:0]).WidgetBounds(context, b, widget)
+	if widget != &t.text {
+		// Center the buttons vertically for a multi-line text.
+		h := min(r.Dy(), UnitSize(context))
+		r.Min.Y = b.Min.Y + (b.Dy()-h)/2
+		r.Max.Y = r.Min.Y + h
+	}
+	return r
+}
+
+func (t *toastWidget) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	u := UnitSize(context)
+	w, ok := constraints.FixedWidth()
+	if !ok {
+		w = 14 * u
+	}
+	// Measure the text with the width left by the buttons.
+	var items [3]guigui.LinearLayoutItem
+	textW := t.layout(context, items[:0]).WidgetBounds(context, image.Rect(0, 0, w, u), &t.text).Dx()
+	h := max(t.text.Measure(context, guigui.FixedWidthConstraints(textW)).Y, u)
+	return image.Pt(w, h+u/2)
+}
+
+func (t *toastWidget) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	// Don't let the widgets below the toast handle the input.
+	if context.IsWidgetHitAtCursor(t) {
+		return guigui.AbortHandlingInputByWidget(t)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (t *toastWidget) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(t)
+	r := RoundedCornerRadius(context)
+	draw.DrawRoundedRect(context, dst, b, draw.Color(context.ColorMode(), draw.ColorTypeBase, 1), r)
+
+	stripe := b
+	stripe.Max.X = stripe.Min.X + UnitSize(context)/8
+	if context.IsRightToLeft() {
+		stripe = guigui.MirrorRectangle(stripe, b)
+	}
+	draw.FillInRoundedCornerRect(context, dst, b, r, stripe, toastSeverityColor(context, t.entry.toast.Severity))
+
+	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset, false)
+	draw.DrawRoundedRectBorder(context, dst, b, clr1, clr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+}
+
+func (t *toastWidget) ZDelta() int {
+	return toastZ
+}
+
+func toastSeverityColor(context *guigui.Context, severity ToastSeverity) color.Color {
+	return draw.Color2(context.ColorMode(), severity.colorType(), 0.5, 0.6)
+}
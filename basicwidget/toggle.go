@@ -8,7 +8,6 @@ import (
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
@@ -64,13 +63,13 @@ func (t *Toggle) Update(context *guigui.Context) error {
 }
 
 func (t *Toggle) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
-	if context.IsEnabled(t) && t.isHovered(context) && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	if context.IsEnabled(t) && t.isHovered(context) && context.IsPointerJustPressed() {
 		context.SetFocused(t, true)
 		t.pressed = true
 		t.SetValue(!t.value)
 		return guigui.HandleInputByWidget(t)
 	}
-	if !context.IsEnabled(t) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !context.IsEnabled(t) || !context.IsPointerPressed() {
 		t.pressed = false
 	}
 	return guigui.HandleInputResult{}
@@ -149,7 +148,7 @@ func (t *Toggle) Draw(context *guigui.Context, dst *ebiten.Image) {
 }
 
 func (t *Toggle) canPress(context *guigui.Context) bool {
-	return context.IsEnabled(t) && t.isHovered(context) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return context.IsEnabled(t) && t.isHovered(context) && !context.IsPointerPressed()
 }
 
 func (t *Toggle) isHovered(context *guigui.Context) bool {
@@ -157,7 +156,7 @@ func (t *Toggle) isHovered(context *guigui.Context) bool {
 }
 
 func (t *Toggle) isActive(context *guigui.Context) bool {
-	return context.IsEnabled(t) && t.isHovered(context) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && t.pressed
+	return context.IsEnabled(t) && t.isHovered(context) && context.IsPointerPressed() && t.pressed
 }
 
 func (t *Toggle) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
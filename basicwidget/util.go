@@ -6,8 +6,6 @@ package basicwidget
 import (
 	"slices"
 
-	"github.com/hajimehoshi/ebiten/v2"
-
 	"github.com/guigui-gui/guigui"
 )
 
@@ -47,10 +45,12 @@ func MoveItemsInSlice[T any](slice []T, from int, count int, to int) int {
 	return to
 }
 
-func doubleClickLimitInTicks() int {
-	return ebiten.TPS() / 2
-}
-
 func defaultIconSize(context *guigui.Context) int {
 	return int(LineHeight(context))
 }
+
+// isOnScreen reports whether widget is visible and not clipped entirely.
+// An animating widget should request redrawing only while isOnScreen is true.
+func isOnScreen(context *guigui.Context, widget guigui.Widget) bool {
+	return context.IsVisible(widget) && !context.VisibleBounds(widget).Empty()
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// VectorIcon is a resolution-independent icon filled by a vector path.
+type VectorIcon struct {
+	path        vector.Path
+	viewBoxSize float32
+
+	mirroredInRightToLeft bool
+
+	images map[int]*ebiten.Image
+}
+
+// NewVectorIcon creates a vector icon from path.
+// path is in the coordinate system from (0, 0) to (viewBoxSize, viewBoxSize), and is filled by the nonzero rule.
+func NewVectorIcon(path *vector.Path, viewBoxSize float32) *VectorIcon {
+	v := &VectorIcon{
+		viewBoxSize: viewBoxSize,
+	}
+	v.path.AddPath(path, nil)
+	return v
+}
+
+// SetMirroredInRightToLeft sets whether the icon is flipped horizontally in a right-to-left layout.
+// This is useful for a directional icon like an arrow.
+func (v *VectorIcon) SetMirroredInRightToLeft(mirrored bool) {
+	v.mirroredInRightToLeft = mirrored
+}
+
+// Image returns a white image of the icon whose width and height are size in pixels.
+// Tint the image by ebiten.ColorScale to draw it in another color.
+// The images are cached per size.
+func (v *VectorIcon) Image(size int) *ebiten.Image {
+	if img, ok := v.images[size]; ok {
+		return img
+	}
+
+	img := ebiten.NewImage(max(size, 1), max(size, 1))
+	var path vector.Path
+	op := &vector.AddPathOptions{}
+	s := float64(size) / float64(v.viewBoxSize)
+	op.GeoM.Scale(s, s)
+	path.AddPath(&v.path, op)
+	vector.FillPath(img, &path, nil, &vector.DrawPathOptions{
+		AntiAlias: true,
+	})
+
+	if v.images == nil {
+		v.images = map[int]*ebiten.Image{}
+	}
+	v.images[size] = img
+	return img
+}
+
+// appendPolygon appends a closed polygon of the points (xy[0], xy[1]), (xy[2], xy[3]), ... to path.
+func appendPolygon(path *vector.Path, xy ...float32) {
+	path.MoveTo(xy[0], xy[1])
+	for i := 2; i < len(xy); i += 2 {
+		path.LineTo(xy[i], xy[i+1])
+	}
+	path.Close()
+}
+
+func newDirectionalVectorIcon(icon *VectorIcon) *VectorIcon {
+	icon.SetMirroredInRightToLeft(true)
+	return icon
+}
+
+func newPolygonsVectorIcon(polygons ...[]float32) *VectorIcon {
+	var path vector.Path
+	for _, p := range polygons {
+		appendPolygon(&path, p...)
+	}
+	return NewVectorIcon(&path, 24)
+}
+
+// The built-in icons are in the 24x24 coordinate system, based on Material Symbols.
+var (
+	vectorIconCheck = newPolygonsVectorIcon(
+		[]float32{9, 16.17, 4.83, 12, 3.41, 13.41, 9, 19, 21, 7, 19.59, 5.59},
+	)
+	vectorIconKeyboardArrowDown = newPolygonsVectorIcon(
+		[]float32{7.41, 8.59, 12, 13.17, 16.59, 8.59, 18, 10, 12, 16, 6, 10},
+	)
+	vectorIconKeyboardArrowUp = newPolygonsVectorIcon(
+		[]float32{7.41, 15.41, 12, 10.83, 16.59, 15.41, 18, 14, 12, 8, 6, 14},
+	)
+	vectorIconKeyboardArrowRight = newDirectionalVectorIcon(newPolygonsVectorIcon(
+		[]float32{8.59, 16.59, 13.17, 12, 8.59, 7.41, 10, 6, 16, 12, 10, 18},
+	))
+	vectorIconKeyboardArrowLeft = newDirectionalVectorIcon(newPolygonsVectorIcon(
+		[]float32{15.41, 16.59, 10.83, 12, 15.41, 7.41, 14, 6, 8, 12, 14, 18},
+	))
+	vectorIconUnfoldMore = newPolygonsVectorIcon(
+		[]float32{12, 5.83, 15.17, 9, 16.58, 7.59, 12, 3, 7.41, 7.59, 8.83, 9},
+		[]float32{12, 18.17, 8.83, 15, 7.42, 16.41, 12, 21, 16.59, 16.41, 15.17, 15},
+	)
+	// The inner rectangle is counterclockwise to make a hole.
+	vectorIconCalendarToday = newPolygonsVectorIcon(
+		[]float32{5, 1, 7, 1, 7, 3, 17, 3, 17, 1, 19, 1, 19, 3, 22, 3, 22, 23, 2, 23, 2, 3, 5, 3},
+		[]float32{4, 8, 4, 21, 20, 21, 20, 8},
+		[]float32{6, 10, 11, 10, 11, 15, 6, 15},
+	)
+	// The stem, the collar and the bulb of an eyedropper.
+	vectorIconColorize = newPolygonsVectorIcon(
+		[]float32{3, 19, 12, 10, 14, 12, 5, 21, 3, 21},
+		[]float32{10, 8, 11.5, 6.5, 17.5, 12.5, 16, 14},
+		[]float32{13.5, 8.5, 18, 4, 20, 6, 15.5, 10.5},
+	)
+	vectorIconDragIndicator = newDotsVectorIcon(
+		2,
+		9, 6, 15, 6,
+		9, 12, 15, 12,
+		9, 18, 15, 18,
+	)
+)
+
+func newDotsVectorIcon(radius float32, xy ...float32) *VectorIcon {
+	var path vector.Path
+	for i := 0; i < len(xy); i += 2 {
+		path.MoveTo(xy[i]+radius, xy[i+1])
+		path.Arc(xy[i], xy[i+1], radius, 0, 2*math.Pi, vector.Clockwise)
+		path.Close()
+	}
+	return NewVectorIcon(&path, 24)
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type buildTestWidget struct {
+	guigui.DefaultWidget
+
+	children    []*buildTestWidget
+	updateCount int
+	onUpdate    func()
+}
+
+func (b *buildTestWidget) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	for _, child := range b.children {
+		adder.AddChild(child)
+	}
+}
+
+func (b *buildTestWidget) Update(context *guigui.Context) error {
+	b.updateCount++
+	if b.onUpdate != nil {
+		b.onUpdate()
+	}
+	return nil
+}
+
+func (b *buildTestWidget) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
+	return context.Bounds(b)
+}
+
+func TestBuildWidgetsWithRebuildBoundary(t *testing.T) {
+	var leaf0, leaf1 buildTestWidget
+	boundary := buildTestWidget{children: []*buildTestWidget{&leaf0}}
+	root := buildTestWidget{children: []*buildTestWidget{&boundary, &leaf1}}
+
+	a := guigui.NewTestApp(&root, 100, 100)
+	context := a.Context()
+
+	build := func() {
+		t.Helper()
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	updateCounts := func() [4]int {
+		return [...]int{root.updateCount, boundary.updateCount, leaf0.updateCount, leaf1.updateCount}
+	}
+
+	build()
+	context.SetRebuildBoundary(&boundary, true)
+	build()
+	if got, want := updateCounts(), [...]int{2, 2, 2, 2}; got != want {
+		t.Errorf("after setting a boundary: got: %v, want: %v", got, want)
+	}
+
+	// The clean boundary is skipped, but its descendants are still in the tree.
+	build()
+	if got, want := updateCounts(), [...]int{3, 2, 2, 3}; got != want {
+		t.Errorf("clean boundary: got: %v, want: %v", got, want)
+	}
+	if !context.IsMounted(&leaf0) {
+		t.Errorf("a widget in a skipped boundary must be in the tree")
+	}
+
+	// A rebuild request in the boundary rebuilds the boundary.
+	guigui.RequestRebuild(&leaf0)
+	build()
+	if got, want := updateCounts(), [...]int{4, 3, 3, 4}; got != want {
+		t.Errorf("rebuild requested: got: %v, want: %v", got, want)
+	}
+
+	// A bounds change rebuilds the boundary.
+	a.SetSize(200, 100)
+	build()
+	if got, want := updateCounts(), [...]int{5, 4, 4, 5}; got != want {
+		t.Errorf("bounds changed: got: %v, want: %v", got, want)
+	}
+
+	// A full build rebuilds all the widgets.
+	a.RequestFullBuild()
+	build()
+	if got, want := updateCounts(), [...]int{6, 5, 5, 6}; got != want {
+		t.Errorf("full build: got: %v, want: %v", got, want)
+	}
+}
+
+var buildTestEvent = &guigui.Event[int]{Name: "buildTest"}
+
+func TestBuildWidgetsEventHandlersInRebuildBoundary(t *testing.T) {
+	var leaf buildTestWidget
+	boundary := buildTestWidget{children: []*buildTestWidget{&leaf}}
+	root := buildTestWidget{children: []*buildTestWidget{&boundary}}
+
+	var handlerCount, listenerCount int
+	registers := true
+	leaf.onUpdate = func() {
+		if !registers {
+			return
+		}
+		guigui.RegisterEventHandler(&leaf, "test", func() {
+			handlerCount++
+		})
+		buildTestEvent.AddListener(&leaf, func(info *guigui.EventInfo, value int) {
+			listenerCount++
+		})
+	}
+
+	a := guigui.NewTestApp(&root, 100, 100)
+	a.Context().SetRebuildBoundary(&boundary, true)
+	build := func() {
+		t.Helper()
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dispatch := func() {
+		t.Helper()
+		handlerCount = 0
+		listenerCount = 0
+		guigui.DispatchEventHandler(&leaf, "test")
+		buildTestEvent.Dispatch(&leaf, 0)
+	}
+
+	build()
+	build()
+	dispatch()
+	if handlerCount != 1 || listenerCount != 1 {
+		t.Errorf("clean boundary: handler: %d, listener: %d, want: 1, 1", handlerCount, listenerCount)
+	}
+
+	// The boundary is rebuilt as its bounds are changed, but the handlers must not be duplicated.
+	a.SetSize(200, 100)
+	build()
+	dispatch()
+	if handlerCount != 1 || listenerCount != 1 {
+		t.Errorf("resized boundary: handler: %d, listener: %d, want: 1, 1", handlerCount, listenerCount)
+	}
+
+	// The handlers registered at an earlier build are removed when the widget is rebuilt.
+	registers = false
+	a.SetSize(300, 100)
+	build()
+	dispatch()
+	if handlerCount != 0 || listenerCount != 0 {
+		t.Errorf("not registered: handler: %d, listener: %d, want: 0, 0", handlerCount, listenerCount)
+	}
+}
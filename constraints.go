@@ -2,43 +2,147 @@
 
 package guigui
 
-type constraintsType int
-
-const (
-	constraintsTypeNone constraintsType = iota
-	constraintsTypeFixedWidth
-	constraintsTypeFixedHeight
+import (
+	"image"
 )
 
+// Constraints represents the minimum and maximum sizes that a widget's Measure should respect.
+//
+// The zero value is unbounded, i.e. a widget can have any size.
+// Constraints are tight in a dimension when the minimum and the maximum are the same,
+// and loose when the minimum is 0.
 type Constraints struct {
-	typ  constraintsType
-	size int
+	minSize image.Point
+
+	// maxSizePlus1 is the maximum size plus 1. 0 means unbounded.
+	maxSizePlus1 image.Point
+}
+
+// FixedWidthConstraints returns constraints with a tight width and an unbounded height.
+func FixedWidthConstraints(w int) Constraints {
+	return Constraints{}.WithFixedWidth(w)
+}
+
+// FixedHeightConstraints returns constraints with an unbounded width and a tight height.
+func FixedHeightConstraints(h int) Constraints {
+	return Constraints{}.WithFixedHeight(h)
+}
+
+// TightConstraints returns constraints that allow only size.
+func TightConstraints(size image.Point) Constraints {
+	return Constraints{}.WithFixedWidth(size.X).WithFixedHeight(size.Y)
+}
+
+// LooseConstraints returns constraints that allow any size up to maxSize.
+func LooseConstraints(maxSize image.Point) Constraints {
+	return Constraints{}.WithMaxWidth(maxSize.X).WithMaxHeight(maxSize.Y)
+}
+
+// WithMinWidth returns a copy of c with the minimum width w.
+// The maximum width is enlarged if it is less than w.
+func (c Constraints) WithMinWidth(w int) Constraints {
+	w = max(w, 0)
+	c.minSize.X = w
+	if c.maxSizePlus1.X > 0 && c.maxSizePlus1.X-1 < w {
+		c.maxSizePlus1.X = w + 1
+	}
+	return c
+}
+
+// WithMaxWidth returns a copy of c with the maximum width w.
+// The minimum width is shrunk if it is greater than w.
+func (c Constraints) WithMaxWidth(w int) Constraints {
+	w = max(w, 0)
+	c.maxSizePlus1.X = w + 1
+	c.minSize.X = min(c.minSize.X, w)
+	return c
+}
+
+// WithMinHeight returns a copy of c with the minimum height h.
+// The maximum height is enlarged if it is less than h.
+func (c Constraints) WithMinHeight(h int) Constraints {
+	h = max(h, 0)
+	c.minSize.Y = h
+	if c.maxSizePlus1.Y > 0 && c.maxSizePlus1.Y-1 < h {
+		c.maxSizePlus1.Y = h + 1
+	}
+	return c
 }
 
-func (c *Constraints) FixedWidth() (int, bool) {
-	if c.typ != constraintsTypeFixedWidth {
+// WithMaxHeight returns a copy of c with the maximum height h.
+// The minimum height is shrunk if it is greater than h.
+func (c Constraints) WithMaxHeight(h int) Constraints {
+	h = max(h, 0)
+	c.maxSizePlus1.Y = h + 1
+	c.minSize.Y = min(c.minSize.Y, h)
+	return c
+}
+
+// WithFixedWidth returns a copy of c with the tight width w.
+func (c Constraints) WithFixedWidth(w int) Constraints {
+	return c.WithMaxWidth(w).WithMinWidth(w)
+}
+
+// WithFixedHeight returns a copy of c with the tight height h.
+func (c Constraints) WithFixedHeight(h int) Constraints {
+	return c.WithMaxHeight(h).WithMinHeight(h)
+}
+
+// WithoutMinSize returns a copy of c whose minimum sizes are 0.
+func (c Constraints) WithoutMinSize() Constraints {
+	c.minSize = image.Point{}
+	return c
+}
+
+// FixedWidth returns the width if the width is tight.
+func (c Constraints) FixedWidth() (int, bool) {
+	if c.maxSizePlus1.X == 0 || c.maxSizePlus1.X-1 != c.minSize.X {
 		return 0, false
 	}
-	return c.size, true
+	return c.minSize.X, true
 }
 
-func (c *Constraints) FixedHeight() (int, bool) {
-	if c.typ != constraintsTypeFixedHeight {
+// FixedHeight returns the height if the height is tight.
+func (c Constraints) FixedHeight() (int, bool) {
+	if c.maxSizePlus1.Y == 0 || c.maxSizePlus1.Y-1 != c.minSize.Y {
 		return 0, false
 	}
-	return c.size, true
+	return c.minSize.Y, true
 }
 
-func FixedWidthConstraints(w int) Constraints {
-	return Constraints{
-		typ:  constraintsTypeFixedWidth,
-		size: w,
+func (c Constraints) MinWidth() int {
+	return c.minSize.X
+}
+
+func (c Constraints) MinHeight() int {
+	return c.minSize.Y
+}
+
+// MaxWidth returns the maximum width if the width is bounded.
+func (c Constraints) MaxWidth() (int, bool) {
+	if c.maxSizePlus1.X == 0 {
+		return 0, false
 	}
+	return c.maxSizePlus1.X - 1, true
 }
 
-func FixedHeightConstraints(h int) Constraints {
-	return Constraints{
-		typ:  constraintsTypeFixedHeight,
-		size: h,
+// MaxHeight returns the maximum height if the height is bounded.
+func (c Constraints) MaxHeight() (int, bool) {
+	if c.maxSizePlus1.Y == 0 {
+		return 0, false
+	}
+	return c.maxSizePlus1.Y - 1, true
+}
+
+// Constrain returns size clamped into c.
+func (c Constraints) Constrain(size image.Point) image.Point {
+	size.X = max(size.X, c.minSize.X)
+	size.Y = max(size.Y, c.minSize.Y)
+	if w, ok := c.MaxWidth(); ok {
+		size.X = min(size.X, w)
+	}
+	if h, ok := c.MaxHeight(); ok {
+		size.Y = min(size.Y, h)
 	}
+	return size
 }
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestConstraints(t *testing.T) {
+	testCases := []struct {
+		name        string
+		constraints guigui.Constraints
+		in          image.Point
+		want        image.Point
+		fixedWidth  bool
+		fixedHeight bool
+	}{
+		{"unbounded", guigui.Constraints{}, image.Pt(100, 200), image.Pt(100, 200), false, false},
+		{"fixed width", guigui.FixedWidthConstraints(50), image.Pt(100, 200), image.Pt(50, 200), true, false},
+		{"fixed height", guigui.FixedHeightConstraints(50), image.Pt(100, 200), image.Pt(100, 50), false, true},
+		{"tight", guigui.TightConstraints(image.Pt(30, 40)), image.Pt(100, 200), image.Pt(30, 40), true, true},
+		{"loose", guigui.LooseConstraints(image.Pt(150, 150)), image.Pt(100, 200), image.Pt(100, 150), false, false},
+		{"min", guigui.Constraints{}.WithMinWidth(120).WithMinHeight(10), image.Pt(100, 200), image.Pt(120, 200), false, false},
+		{"min enlarges max", guigui.Constraints{}.WithMaxWidth(50).WithMinWidth(80), image.Pt(100, 200), image.Pt(80, 200), true, false},
+		{"max shrinks min", guigui.Constraints{}.WithMinWidth(80).WithMaxWidth(50), image.Pt(100, 200), image.Pt(50, 200), true, false},
+		{"without min", guigui.TightConstraints(image.Pt(30, 40)).WithoutMinSize(), image.Pt(10, 200), image.Pt(10, 40), false, false},
+		{"negative", guigui.FixedWidthConstraints(-10), image.Pt(100, 200), image.Pt(0, 200), true, false},
+	}
+	for _, tc := range testCases {
+		if got := tc.constraints.Constrain(tc.in); got != tc.want {
+			t.Errorf("%s: Constrain(%v): got: %v, want: %v", tc.name, tc.in, got, tc.want)
+		}
+		if _, ok := tc.constraints.FixedWidth(); ok != tc.fixedWidth {
+			t.Errorf("%s: FixedWidth: got: %v, want: %v", tc.name, ok, tc.fixedWidth)
+		}
+		if _, ok := tc.constraints.FixedHeight(); ok != tc.fixedHeight {
+			t.Errorf("%s: FixedHeight: got: %v, want: %v", tc.name, ok, tc.fixedHeight)
+		}
+	}
+}
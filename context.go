@@ -6,6 +6,7 @@ package guigui
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"log/slog"
 	"os"
 	"slices"
@@ -52,6 +53,31 @@ const (
 	ColorModeDark
 )
 
+// WritingDirection represents the direction of texts and layouts.
+type WritingDirection int
+
+const (
+	WritingDirectionLeftToRight WritingDirection = iota
+	WritingDirectionRightToLeft
+)
+
+var rightToLeftScripts = []language.Script{
+	language.MustParseScript("Adlm"),
+	language.MustParseScript("Arab"),
+	language.MustParseScript("Hebr"),
+	language.MustParseScript("Mand"),
+	language.MustParseScript("Nkoo"),
+	language.MustParseScript("Rohg"),
+	language.MustParseScript("Samr"),
+	language.MustParseScript("Syrc"),
+	language.MustParseScript("Thaa"),
+}
+
+func isRightToLeftLocale(locale language.Tag) bool {
+	script, _ := locale.Script()
+	return slices.Contains(rightToLeftScripts, script)
+}
+
 type Context struct {
 	app     *app
 	inBuild bool
@@ -64,6 +90,8 @@ type Context struct {
 	defaultColorWarnOnce       sync.Once
 	locales                    []language.Tag
 	allLocales                 []language.Tag
+	writingDirection           WritingDirection
+	writingDirectionSet        bool
 
 	tmpWidgetStates []*widgetState
 }
@@ -85,7 +113,7 @@ func (c *Context) SetAppScale(scale float64) {
 		return
 	}
 	c.appScaleMinus1 = scale - 1
-	c.app.requestRedraw(c.app.bounds())
+	c.app.requestRedrawAll()
 }
 
 func (c *Context) ColorMode() ColorMode {
@@ -102,7 +130,7 @@ func (c *Context) SetColorMode(mode ColorMode) {
 
 	c.colorMode = mode
 	c.colorModeSet = true
-	c.app.requestRedraw(c.app.bounds())
+	c.app.requestRedrawAll()
 }
 
 func (c *Context) UseAutoColorMode() {
@@ -110,7 +138,7 @@ func (c *Context) UseAutoColorMode() {
 		return
 	}
 	c.colorModeSet = false
-	c.app.requestRedraw(c.app.bounds())
+	c.app.requestRedrawAll()
 }
 
 func (c *Context) IsAutoColorModeUsed() bool {
@@ -128,7 +156,7 @@ func (c *Context) autoColorMode() ColorMode {
 		if time.Since(c.cachedDefaultColorModeTime) >= time.Second {
 			m := colormode.SystemColorMode()
 			if c.cachedDefaultColorMode != m {
-				c.app.requestRedraw(c.app.bounds())
+				c.app.requestRedrawAll()
 			}
 			c.cachedDefaultColorMode = m
 			c.cachedDefaultColorModeTime = time.Now()
@@ -195,7 +223,44 @@ func (c *Context) SetAppLocales(locales []language.Tag) {
 	c.locales = append(c.locales, locales...)
 	c.allLocales = slices.Delete(c.allLocales, 0, len(c.allLocales))
 
-	c.app.requestRedraw(c.app.bounds())
+	c.app.requestRedrawAll()
+}
+
+// WritingDirection returns the writing direction of the app.
+// By default, the direction is determined by the first locale of AppendLocales.
+func (c *Context) WritingDirection() WritingDirection {
+	if c.writingDirectionSet {
+		return c.writingDirection
+	}
+	// AppendLocales caches the locales. Use the cache directly to avoid allocations.
+	if len(c.allLocales) == 0 {
+		_ = c.AppendLocales(nil)
+	}
+	if len(c.allLocales) > 0 && isRightToLeftLocale(c.allLocales[0]) {
+		return WritingDirectionRightToLeft
+	}
+	return WritingDirectionLeftToRight
+}
+
+func (c *Context) SetWritingDirection(direction WritingDirection) {
+	if c.writingDirectionSet && c.writingDirection == direction {
+		return
+	}
+	c.writingDirection = direction
+	c.writingDirectionSet = true
+	c.app.requestRedrawAll()
+}
+
+func (c *Context) UseAutoWritingDirection() {
+	if !c.writingDirectionSet {
+		return
+	}
+	c.writingDirectionSet = false
+	c.app.requestRedrawAll()
+}
+
+func (c *Context) IsRightToLeft() bool {
+	return c.WritingDirection() == WritingDirectionRightToLeft
 }
 
 func (c *Context) AppSize() image.Point {
@@ -272,6 +337,28 @@ func (c *Context) IsEnabled(widget Widget) bool {
 	return widget.widgetState().isEnabled()
 }
 
+// SetRebuildBoundary sets whether widget is a rebuild boundary.
+//
+// By default, all the widgets are rebuilt, i.e. AddChildren, Update and Layout are called, whenever the tree is rebuilt.
+// A rebuild boundary and its descendants are skipped and kept as they are when none of them requests a redraw or a rebuild,
+// and the boundary's bounds and visible bounds are not changed.
+// All the widgets are still rebuilt after an event is dispatched, an input is handled,
+// or an app-wide state like the scale or the color mode is changed.
+//
+// A widget in a rebuild boundary must not read a model changed outside the boundary without RequestRebuild.
+func (c *Context) SetRebuildBoundary(widget Widget, boundary bool) {
+	widgetState := widget.widgetState()
+	if widgetState.rebuildBoundary == boundary {
+		return
+	}
+	widgetState.rebuildBoundary = boundary
+	requestRebuild(widgetState)
+}
+
+func (c *Context) IsRebuildBoundary(widget Widget) bool {
+	return widget.widgetState().rebuildBoundary
+}
+
 func (c *Context) SetFocused(widget Widget, focused bool) {
 	if focused {
 		c.focus(widget)
@@ -300,7 +387,7 @@ func (c *Context) focus(widget Widget) {
 
 	// Rerender everything when a focus changes.
 	// A widget including a focused widget might be affected.
-	c.app.requestRedraw(c.app.bounds())
+	c.app.requestRedrawAll()
 }
 
 func (c *Context) blur(widget Widget) {
@@ -320,7 +407,7 @@ func (c *Context) blur(widget Widget) {
 	if unfocused {
 		// Rerender everything when a focus changes.
 		// A widget including a focused widget might be affected.
-		c.app.requestRedraw(c.app.bounds())
+		c.app.requestRedrawAll()
 	}
 }
 
@@ -373,6 +460,15 @@ func (c *Context) IsWidgetHitAtCursor(widget Widget) bool {
 	return c.app.isWidgetHit(widget)
 }
 
+// ReadScreenColor reads the color at point on the screen rendered by the app, e.g. for an eyedropper.
+// f is called with the color at the next update after the screen is rendered.
+func (c *Context) ReadScreenColor(point image.Point, f func(clr color.Color)) {
+	c.app.screenColorReads = append(c.app.screenColorReads, screenColorRead{
+		point: point,
+		f:     f,
+	})
+}
+
 func (c *Context) SetCustomDraw(widget Widget, customDraw CustomDrawFunc) {
 	widget.widgetState().customDraw = customDraw
 }
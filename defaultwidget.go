@@ -61,13 +61,7 @@ func (d *DefaultWidget) Measure(context *Context, constraints Constraints) image
 	} else {
 		s = image.Pt(int(144*context.Scale()), int(144*context.Scale()))
 	}
-	if w, ok := constraints.FixedWidth(); ok {
-		s.X = w
-	}
-	if h, ok := constraints.FixedHeight(); ok {
-		s.Y = h
-	}
-	return s
+	return constraints.Constrain(s)
 }
 
 func (*DefaultWidget) PassThrough() bool {
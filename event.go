@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"slices"
+)
+
+// Event is a typed event whose value is of type T.
+//
+// An Event is identified by its address, so declare it as a package-level variable and don't copy it:
+//
+//	var ButtonEventClicked = &guigui.Event[struct{}]{Name: "clicked"}
+//
+// Unlike RegisterEventHandler and DispatchEventHandler, handlers' signatures are checked at compile time,
+// and a widget can have multiple listeners for the same event.
+type Event[T any] struct {
+	// Name is the event's name for debugging.
+	Name string
+
+	// Propagates reports whether the event propagates through the target's ancestors.
+	//
+	// A propagating event is dispatched to the capturing listeners from the root to the target's parent first (capturing phase),
+	// then to the listeners of the target (target phase),
+	// and then to the bubbling listeners from the target's parent to the root (bubbling phase).
+	// A non-propagating event is dispatched only to the listeners of the target.
+	Propagates bool
+}
+
+// EventPhase represents the phase of a dispatched event.
+type EventPhase int
+
+const (
+	EventPhaseCapturing EventPhase = iota
+	EventPhaseTarget
+	EventPhaseBubbling
+)
+
+// EventInfo is information about a dispatched event, given to listeners.
+type EventInfo struct {
+	target  Widget
+	current Widget
+	phase   EventPhase
+	stopped bool
+}
+
+// Target returns the widget to which the event is dispatched.
+func (e *EventInfo) Target() Widget {
+	return e.target
+}
+
+// CurrentWidget returns the widget whose listener is called.
+func (e *EventInfo) CurrentWidget() Widget {
+	return e.current
+}
+
+func (e *EventInfo) Phase() EventPhase {
+	return e.phase
+}
+
+// StopPropagation stops dispatching the event to the other widgets.
+// The rest of the listeners of the current widget are still called.
+func (e *EventInfo) StopPropagation() {
+	e.stopped = true
+}
+
+type eventListener struct {
+	f       any
+	capture bool
+
+	// owner is the widget that was being built when the listener was added.
+	owner        *widgetState
+	registeredAt int64
+}
+
+// AddListener adds a listener of the event to widget.
+// The listener is called at the target phase and the bubbling phase.
+//
+// Listeners are removed when widget is rebuilt like handlers registered by RegisterEventHandler,
+// so add listeners in Update.
+func (e *Event[T]) AddListener(widget Widget, f func(info *EventInfo, value T)) {
+	e.addListener(widget, f, false)
+}
+
+// AddCaptureListener adds a listener of the event to widget.
+// The listener is called at the capturing phase and the target phase.
+//
+// Listeners are removed when widget is rebuilt like handlers registered by RegisterEventHandler,
+// so add listeners in Update.
+func (e *Event[T]) AddCaptureListener(widget Widget, f func(info *EventInfo, value T)) {
+	e.addListener(widget, f, true)
+}
+
+func (e *Event[T]) addListener(widget Widget, f func(info *EventInfo, value T), capture bool) {
+	widgetState := widget.widgetState()
+	if widgetState.eventListeners == nil {
+		widgetState.eventListeners = map[any][]eventListener{}
+	}
+	ls := widgetState.eventListeners[e]
+	// Remove the listeners added by the same widget at earlier builds.
+	// They remain when widget is in a rebuild boundary skipped at this build.
+	if owner := theEventRegistration.owner; owner != nil {
+		ls = slices.DeleteFunc(ls, func(l eventListener) bool {
+			return l.owner == owner && l.registeredAt < theEventRegistration.buildCount
+		})
+	}
+	widgetState.eventListeners[e] = append(ls, eventListener{
+		f:            f,
+		capture:      capture,
+		owner:        theEventRegistration.owner,
+		registeredAt: theEventRegistration.buildCount,
+	})
+}
+
+// HasListener reports whether widget has a listener of the event.
+func (e *Event[T]) HasListener(widget Widget) bool {
+	return len(widget.widgetState().eventListeners[e]) > 0
+}
+
+// Dispatch dispatches the event with value to widget, and reports whether any listener is called.
+func (e *Event[T]) Dispatch(widget Widget, value T) bool {
+	info := EventInfo{
+		target: widget,
+	}
+	var called bool
+
+	var ancestors []Widget
+	if e.Propagates {
+		for p := widget.widgetState().parent; p != nil; p = p.widgetState().parent {
+			ancestors = append(ancestors, p)
+		}
+		info.phase = EventPhaseCapturing
+		for _, a := range slices.Backward(ancestors) {
+			if e.callListeners(&info, a, value) {
+				called = true
+			}
+			if info.stopped {
+				return called
+			}
+		}
+	}
+
+	info.phase = EventPhaseTarget
+	if e.callListeners(&info, widget, value) {
+		called = true
+	}
+	if info.stopped {
+		return called
+	}
+
+	info.phase = EventPhaseBubbling
+	for _, a := range ancestors {
+		if e.callListeners(&info, a, value) {
+			called = true
+		}
+		if info.stopped {
+			return called
+		}
+	}
+	return called
+}
+
+// callListeners calls the listeners of widget for the current phase, and reports whether any listener is called.
+func (e *Event[T]) callListeners(info *EventInfo, widget Widget, value T) bool {
+	widgetState := widget.widgetState()
+	info.current = widget
+	var called bool
+	// Listeners added during the dispatch are not called.
+	n := len(widgetState.eventListeners[e])
+	for i := range n {
+		l := widgetState.eventListeners[e][i]
+		switch info.phase {
+		case EventPhaseCapturing:
+			if !l.capture {
+				continue
+			}
+		case EventPhaseBubbling:
+			if l.capture {
+				continue
+			}
+		}
+		l.f.(func(info *EventInfo, value T))(info, value)
+		called = true
+	}
+	if called {
+		widgetState.eventDispatched = true
+	}
+	return called
+}
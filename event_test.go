@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestEventPropagation(t *testing.T) {
+	propagating := &guigui.Event[int]{Name: "propagating", Propagates: true}
+	nonPropagating := &guigui.Event[int]{Name: "nonPropagating"}
+
+	var target buildTestWidget
+	parent := buildTestWidget{children: []*buildTestWidget{&target}}
+	root := buildTestWidget{children: []*buildTestWidget{&parent}}
+	if err := guigui.NewTestApp(&root, 100, 100).Build(); err != nil {
+		t.Fatal(err)
+	}
+	names := map[guigui.Widget]string{
+		&root:   "root",
+		&parent: "parent",
+		&target: "target",
+	}
+
+	var got []string
+	listener := func(tag string) func(info *guigui.EventInfo, value int) {
+		return func(info *guigui.EventInfo, value int) {
+			got = append(got, fmt.Sprintf("%s:%s:%d", names[info.CurrentWidget()], tag, value))
+		}
+	}
+	for _, e := range []*guigui.Event[int]{propagating, nonPropagating} {
+		for _, w := range []guigui.Widget{&root, &parent, &target} {
+			e.AddCaptureListener(w, listener("capture"))
+			e.AddListener(w, listener("bubble0"))
+			e.AddListener(w, listener("bubble1"))
+		}
+	}
+
+	if !propagating.Dispatch(&target, 1) {
+		t.Errorf("Dispatch must report that listeners are called")
+	}
+	want := []string{
+		"root:capture:1",
+		"parent:capture:1",
+		"target:capture:1",
+		"target:bubble0:1",
+		"target:bubble1:1",
+		"parent:bubble0:1",
+		"parent:bubble1:1",
+		"root:bubble0:1",
+		"root:bubble1:1",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("propagating: got: %v, want: %v", got, want)
+	}
+
+	got = nil
+	nonPropagating.Dispatch(&target, 2)
+	want = []string{
+		"target:capture:2",
+		"target:bubble0:2",
+		"target:bubble1:2",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("non-propagating: got: %v, want: %v", got, want)
+	}
+
+	// StopPropagation stops the other widgets' listeners, but not the current widget's.
+	got = nil
+	propagating.AddListener(&parent, func(info *guigui.EventInfo, value int) {
+		info.StopPropagation()
+	})
+	propagating.AddListener(&parent, listener("bubble2"))
+	propagating.Dispatch(&target, 3)
+	want = []string{
+		"root:capture:3",
+		"parent:capture:3",
+		"target:capture:3",
+		"target:bubble0:3",
+		"target:bubble1:3",
+		"parent:bubble0:3",
+		"parent:bubble1:3",
+		"parent:bubble2:3",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("stopped: got: %v, want: %v", got, want)
+	}
+
+	var other guigui.DefaultWidget
+	if propagating.HasListener(&other) {
+		t.Errorf("HasListener must be false for a widget without listeners")
+	}
+	if propagating.Dispatch(&other, 4) {
+		t.Errorf("Dispatch must report that no listener is called")
+	}
+}
+
+func TestEventListenersFromOutsideRebuildBoundary(t *testing.T) {
+	e := &guigui.Event[int]{Name: "test"}
+
+	var leaf buildTestWidget
+	boundary := buildTestWidget{children: []*buildTestWidget{&leaf}}
+	root := buildTestWidget{children: []*buildTestWidget{&boundary}}
+
+	var count int
+	// The root is rebuilt at every build, but the boundary is skipped.
+	root.onUpdate = func() {
+		e.AddListener(&leaf, func(info *guigui.EventInfo, value int) {
+			count++
+		})
+	}
+
+	a := guigui.NewTestApp(&root, 100, 100)
+	a.Context().SetRebuildBoundary(&boundary, true)
+	for range 3 {
+		a.RequestFullBuild()
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 3 {
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if boundary.updateCount != 3 {
+		t.Errorf("the boundary's update count: got: %d, want: 3", boundary.updateCount)
+	}
+
+	e.Dispatch(&leaf, 0)
+	if count != 1 {
+		t.Errorf("listener calls: got: %d, want: 1", count)
+	}
+}
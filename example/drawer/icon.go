@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package main
+
+import (
+	"embed"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+//go:embed resource/*.png
+var pngImages embed.FS
+
+func init() {
+	for _, name := range []string{
+		"bottom_panel_close",
+		"bottom_panel_open",
+		"left_panel_close",
+		"left_panel_open",
+		"right_panel_close",
+		"right_panel_open",
+	} {
+		if err := basicwidget.RegisterIcon(name, pngImages, "resource/"+name+".png"); err != nil {
+			panic(err)
+		}
+	}
+}
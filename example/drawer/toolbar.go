@@ -61,30 +61,14 @@ func (t *toolbarContent) Update(context *guigui.Context) error {
 	model := context.Model(t, modelKeyModel).(*Model)
 
 	if model.IsLeftPanelOpen() {
-		img, err := theImageCache.GetMonochrome("left_panel_close", context.ColorMode())
-		if err != nil {
-			return err
-		}
-		t.leftPanelButton.SetIcon(img)
+		t.leftPanelButton.SetIconName("left_panel_close")
 	} else {
-		img, err := theImageCache.GetMonochrome("left_panel_open", context.ColorMode())
-		if err != nil {
-			return err
-		}
-		t.leftPanelButton.SetIcon(img)
+		t.leftPanelButton.SetIconName("left_panel_open")
 	}
 	if model.IsRightPanelOpen() {
-		img, err := theImageCache.GetMonochrome("right_panel_close", context.ColorMode())
-		if err != nil {
-			return err
-		}
-		t.rightPanelButton.SetIcon(img)
+		t.rightPanelButton.SetIconName("right_panel_close")
 	} else {
-		img, err := theImageCache.GetMonochrome("right_panel_open", context.ColorMode())
-		if err != nil {
-			return err
-		}
-		t.rightPanelButton.SetIcon(img)
+		t.rightPanelButton.SetIconName("right_panel_open")
 	}
 	t.leftPanelButton.SetOnDown(func() {
 		model.SetLeftPanelOpen(!model.IsLeftPanelOpen())
@@ -28,6 +28,8 @@ type Buttons struct {
 	segmentedControlV     basicwidget.SegmentedControl[int]
 	toggleText            basicwidget.Text
 	toggle                basicwidget.Toggle
+	colorWellText         basicwidget.Text
+	colorWell             basicwidget.ColorWell
 
 	configForm    basicwidget.Form
 	enabledText   basicwidget.Text
@@ -50,23 +52,19 @@ func (b *Buttons) Update(context *guigui.Context) error {
 
 	b.textIconButton1Text.SetValue("Button w/ text and icon (1)")
 	b.textIconButton1.Widget().SetText("Button")
-	img, err := theImageCache.GetMonochrome("check", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	b.textIconButton1.Widget().SetIcon(img)
+	b.textIconButton1.Widget().SetIconName("check")
 	context.SetEnabled(&b.textIconButton1, model.Buttons().Enabled())
 	b.textIconButton1.SetFixedWidth(6 * u)
 
 	b.textIconButton2Text.SetValue("Button w/ text and icon (2)")
 	b.textIconButton2.Widget().SetText("Button")
-	b.textIconButton2.Widget().SetIcon(img)
+	b.textIconButton2.Widget().SetIconName("check")
 	b.textIconButton2.Widget().SetIconAlign(basicwidget.IconAlignEnd)
 	context.SetEnabled(&b.textIconButton2, model.Buttons().Enabled())
 	b.textIconButton2.SetFixedWidth(6 * u)
 
 	b.imageButtonText.SetValue("Image button")
-	img, err = theImageCache.Get("gopher")
+	img, err := theImageCache.Get("gopher")
 	if err != nil {
 		return err
 	}
@@ -107,6 +105,9 @@ func (b *Buttons) Update(context *guigui.Context) error {
 	b.toggleText.SetValue("Toggle")
 	context.SetEnabled(&b.toggle, model.Buttons().Enabled())
 
+	b.colorWellText.SetValue("Color well")
+	context.SetEnabled(&b.colorWell, model.Buttons().Enabled())
+
 	b.buttonsForm.SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &b.buttonText,
@@ -136,6 +137,10 @@ func (b *Buttons) Update(context *guigui.Context) error {
 			PrimaryWidget:   &b.toggleText,
 			SecondaryWidget: &b.toggle,
 		},
+		{
+			PrimaryWidget:   &b.colorWellText,
+			SecondaryWidget: &b.colorWell,
+		},
 	})
 
 	b.enabledText.SetValue("Enabled")
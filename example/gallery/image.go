@@ -9,45 +9,42 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 
-	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget"
 )
 
 //go:embed resource/*.png
 var pngImages embed.FS
 
-type imageCacheKey struct {
-	name       string
-	colorMode  guigui.ColorMode
-	monochrome bool
+func init() {
+	for _, name := range []string{
+		"dark_mode",
+		"format_align_center",
+		"format_align_left",
+		"format_align_right",
+		"light_mode",
+		"search",
+		"vertical_align_bottom",
+		"vertical_align_center",
+		"vertical_align_top",
+	} {
+		if err := basicwidget.RegisterIcon(name, pngImages, "resource/"+name+".png"); err != nil {
+			panic(err)
+		}
+	}
 }
 
 type imageCache struct {
-	m map[imageCacheKey]*ebiten.Image
+	m map[string]*ebiten.Image
 }
 
 var theImageCache = &imageCache{}
 
-func (i *imageCache) GetMonochrome(name string, colorMode guigui.ColorMode) (*ebiten.Image, error) {
-	return i.get(imageCacheKey{
-		name:       name,
-		colorMode:  colorMode,
-		monochrome: true,
-	})
-}
-
 func (i *imageCache) Get(name string) (*ebiten.Image, error) {
-	return i.get(imageCacheKey{
-		name: name,
-	})
-}
-
-func (i *imageCache) get(key imageCacheKey) (*ebiten.Image, error) {
-	if img, ok := i.m[key]; ok {
+	if img, ok := i.m[name]; ok {
 		return img, nil
 	}
 
-	f, err := pngImages.Open("resource/" + key.name + ".png")
+	f, err := pngImages.Open("resource/" + name + ".png")
 	if err != nil {
 		return nil, err
 	}
@@ -60,14 +57,10 @@ func (i *imageCache) get(key imageCacheKey) (*ebiten.Image, error) {
 		return nil, err
 	}
 
-	if key.monochrome {
-		pImg = basicwidget.CreateMonochromeImage(key.colorMode, pImg)
-	}
-
 	img := ebiten.NewImageFromImage(pImg)
 	if i.m == nil {
-		i.m = map[imageCacheKey]*ebiten.Image{}
+		i.m = map[string]*ebiten.Image{}
 	}
-	i.m[key] = img
+	i.m[name] = img
 	return img, nil
 }
@@ -37,6 +37,7 @@ type Root struct {
 	lists        Lists
 	tables       Tables
 	popups       Popups
+	toaster      basicwidget.Toaster
 
 	model Model
 
@@ -85,6 +86,7 @@ func (r *Root) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
 	case "popups":
 		adder.AddChild(&r.popups)
 	}
+	adder.AddChild(&r.toaster)
 }
 
 func (r *Root) Update(context *guigui.Context) error {
@@ -94,7 +96,7 @@ func (r *Root) Update(context *guigui.Context) error {
 
 func (r *Root) Layout(context *guigui.Context, widget guigui.Widget) image.Rectangle {
 	switch widget {
-	case &r.background:
+	case &r.background, &r.toaster:
 		return context.Bounds(r)
 	}
 
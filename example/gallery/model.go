@@ -236,6 +236,10 @@ type NumberInputsModel struct {
 	numberInputValue1 big.Int
 	numberInputValue2 uint64
 	numberInputValue3 int
+	numberInputValue4 float64
+	rangeSliderLower  int
+	rangeSliderUpper  int
+	rangeSliderSet    bool
 
 	uneditable bool
 	disabled   bool
@@ -289,6 +293,27 @@ func (n *NumberInputsModel) SetNumberInputValue3(value int) {
 	n.numberInputValue3 = value
 }
 
+func (n *NumberInputsModel) NumberInputValue4() float64 {
+	return n.numberInputValue4
+}
+
+func (n *NumberInputsModel) SetNumberInputValue4(value float64) {
+	n.numberInputValue4 = value
+}
+
+func (n *NumberInputsModel) RangeSliderValues() (int, int) {
+	if !n.rangeSliderSet {
+		return 25, 75
+	}
+	return n.rangeSliderLower, n.rangeSliderUpper
+}
+
+func (n *NumberInputsModel) SetRangeSliderValues(lower, upper int) {
+	n.rangeSliderLower = lower
+	n.rangeSliderUpper = upper
+	n.rangeSliderSet = true
+}
+
 type ListsModel struct {
 	listItems         []basicwidget.ListItem[int]
 	treeItems         []basicwidget.ListItem[int]
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"fmt"
 	"image"
 	"math"
 	"math/big"
@@ -22,10 +23,20 @@ type NumberInputs struct {
 	numberInput2          guigui.WidgetWithSize[*basicwidget.NumberInput]
 	numberInput3Text      basicwidget.Text
 	numberInput3          guigui.WidgetWithSize[*basicwidget.NumberInput]
+	numberInput4Text      basicwidget.Text
+	numberInput4          guigui.WidgetWithSize[*basicwidget.NumberInput]
 	sliderText            basicwidget.Text
 	slider                guigui.WidgetWithSize[*basicwidget.Slider]
+	rangeSliderText       basicwidget.Text
+	rangeSlider           guigui.WidgetWithSize[*basicwidget.Slider]
 	slierWithoutRangeText basicwidget.Text
 	sliderWithoutRange    guigui.WidgetWithSize[*basicwidget.Slider]
+	progressBarText       basicwidget.Text
+	progressBar           guigui.WidgetWithSize[*basicwidget.ProgressBar]
+	circularProgressText  basicwidget.Text
+	circularProgress      basicwidget.ProgressBar
+	activityIndicatorText basicwidget.Text
+	activityIndicator     basicwidget.ActivityIndicator
 
 	configForm     basicwidget.Form
 	editableText   basicwidget.Text
@@ -88,6 +99,20 @@ func (n *NumberInputs) Update(context *guigui.Context) error {
 	context.SetEnabled(&n.numberInput3, model.NumberInputs().Enabled())
 	n.numberInput3.SetFixedWidth(width)
 
+	n.numberInput4Text.SetValue("Number input (float64, Precision: 2, Step: 0.25)")
+	n.numberInput4.Widget().SetOnValueChangedFloat64(func(value float64, committed bool) {
+		if !committed {
+			return
+		}
+		model.NumberInputs().SetNumberInputValue4(value)
+	})
+	n.numberInput4.Widget().SetPrecision(2)
+	n.numberInput4.Widget().SetStepFloat64(0.25)
+	n.numberInput4.Widget().SetValueFloat64(model.NumberInputs().NumberInputValue4())
+	n.numberInput4.Widget().SetEditable(model.NumberInputs().Editable())
+	context.SetEnabled(&n.numberInput4, model.NumberInputs().Enabled())
+	n.numberInput4.SetFixedWidth(width)
+
 	n.sliderText.SetValue("Slider (Range: [-100, 100])")
 	n.slider.Widget().SetOnValueChanged(func(value int) {
 		model.NumberInputs().SetNumberInputValue3(value)
@@ -98,10 +123,44 @@ func (n *NumberInputs) Update(context *guigui.Context) error {
 	context.SetEnabled(&n.slider, model.NumberInputs().Enabled())
 	n.slider.SetFixedWidth(width)
 
+	n.rangeSliderText.SetValue("Range slider (Range: [0, 100], Ticks: 25)")
+	n.rangeSlider.Widget().SetRangeMode(true)
+	n.rangeSlider.Widget().SetOnRangeChanged(func(lower, upper int) {
+		model.NumberInputs().SetRangeSliderValues(lower, upper)
+	})
+	n.rangeSlider.Widget().SetMinimumValue(0)
+	n.rangeSlider.Widget().SetMaximumValue(100)
+	n.rangeSlider.Widget().SetTicks([]basicwidget.SliderTick{
+		{Value: 0, Label: "0"},
+		{Value: 25, Label: "25"},
+		{Value: 50, Label: "50"},
+		{Value: 75, Label: "75"},
+		{Value: 100, Label: "100"},
+	})
+	n.rangeSlider.Widget().SetValueTooltipEnabled(true)
+	n.rangeSlider.Widget().SetRangeValues(model.NumberInputs().RangeSliderValues())
+	context.SetEnabled(&n.rangeSlider, model.NumberInputs().Enabled())
+	n.rangeSlider.SetFixedWidth(width)
+
 	n.slierWithoutRangeText.SetValue("Slider w/o range")
 	context.SetEnabled(&n.sliderWithoutRange, model.NumberInputs().Enabled())
 	n.sliderWithoutRange.SetFixedWidth(width)
 
+	n.progressBarText.SetValue("Progress bar (Value: the slider above)")
+	n.progressBar.Widget().SetMaximum(200)
+	n.progressBar.Widget().SetValue(float64(model.NumberInputs().NumberInputValue3() + 100))
+	n.progressBar.Widget().SetLabel(fmt.Sprintf("%d%%", int(n.progressBar.Widget().Rate()*100)))
+	context.SetEnabled(&n.progressBar, model.NumberInputs().Enabled())
+	n.progressBar.SetFixedWidth(width)
+
+	n.circularProgressText.SetValue("Progress bar (Circular, Indeterminate)")
+	n.circularProgress.SetStyle(basicwidget.ProgressBarStyleCircular)
+	n.circularProgress.SetIndeterminate(true)
+	context.SetEnabled(&n.circularProgress, model.NumberInputs().Enabled())
+
+	n.activityIndicatorText.SetValue("Activity indicator")
+	n.activityIndicator.SetAnimating(model.NumberInputs().Enabled())
+
 	n.numberInputForm.SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &n.numberInput1Text,
@@ -115,14 +174,34 @@ func (n *NumberInputs) Update(context *guigui.Context) error {
 			PrimaryWidget:   &n.numberInput3Text,
 			SecondaryWidget: &n.numberInput3,
 		},
+		{
+			PrimaryWidget:   &n.numberInput4Text,
+			SecondaryWidget: &n.numberInput4,
+		},
 		{
 			PrimaryWidget:   &n.sliderText,
 			SecondaryWidget: &n.slider,
 		},
+		{
+			PrimaryWidget:   &n.rangeSliderText,
+			SecondaryWidget: &n.rangeSlider,
+		},
 		{
 			PrimaryWidget:   &n.slierWithoutRangeText,
 			SecondaryWidget: &n.sliderWithoutRange,
 		},
+		{
+			PrimaryWidget:   &n.progressBarText,
+			SecondaryWidget: &n.progressBar,
+		},
+		{
+			PrimaryWidget:   &n.circularProgressText,
+			SecondaryWidget: &n.circularProgress,
+		},
+		{
+			PrimaryWidget:   &n.activityIndicatorText,
+			SecondaryWidget: &n.activityIndicator,
+		},
 	})
 
 	// Configurations
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"fmt"
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -16,7 +17,7 @@ import (
 type Popups struct {
 	guigui.DefaultWidget
 
-	forms                        [2]basicwidget.Form
+	forms                        [3]basicwidget.Form
 	blurBackgroundText           basicwidget.Text
 	blurBackgroundToggle         basicwidget.Toggle
 	closeByClickingOutsideText   basicwidget.Text
@@ -26,12 +27,17 @@ type Popups struct {
 	contextMenuPopupText          basicwidget.Text
 	contextMenuPopupClickHereText basicwidget.Text
 
+	toastText   basicwidget.Text
+	toastButton basicwidget.Button
+	toastCount  int
+
 	simplePopup        basicwidget.Popup
 	simplePopupContent guigui.WidgetWithSize[*simplePopupContent]
 
 	contextMenuPopup basicwidget.PopupMenu[int]
 
-	contextMenuPopupPosition image.Point
+	contextMenuPopupPosition  image.Point
+	contextMenuPopupLongPress guigui.LongPressRecognizer
 }
 
 func (p *Popups) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
@@ -65,7 +71,7 @@ func (p *Popups) Update(context *guigui.Context) error {
 	})
 
 	p.contextMenuPopupText.SetValue("Context menu")
-	p.contextMenuPopupClickHereText.SetValue("Click here by the right button")
+	p.contextMenuPopupClickHereText.SetValue("Click here by the right button or long-press")
 
 	p.forms[1].SetItems([]basicwidget.FormItem{
 		{
@@ -74,6 +80,32 @@ func (p *Popups) Update(context *guigui.Context) error {
 		},
 	})
 
+	p.toastText.SetValue("Toast")
+	p.toastButton.SetText("Show")
+	p.toastButton.SetOnUp(func() {
+		p.toastCount++
+		toast := basicwidget.Toast{
+			Text:     fmt.Sprintf("Notification #%d", p.toastCount),
+			Severity: basicwidget.ToastSeverity((p.toastCount - 1) % 4),
+		}
+		if p.toastCount%2 == 0 {
+			toast.ActionText = "Undo"
+			toast.OnAction = func() {
+				basicwidget.ShowToast(context, basicwidget.Toast{
+					Text: "Undone",
+				})
+			}
+		}
+		basicwidget.ShowToast(context, toast)
+	})
+
+	p.forms[2].SetItems([]basicwidget.FormItem{
+		{
+			PrimaryWidget:   &p.toastText,
+			SecondaryWidget: &p.toastButton,
+		},
+	})
+
 	p.simplePopupContent.Widget().SetPopup(&p.simplePopup)
 	p.simplePopup.SetContent(&p.simplePopupContent)
 	p.simplePopup.SetBackgroundBlurred(p.blurBackgroundToggle.Value())
@@ -142,6 +174,9 @@ func (p *Popups) Layout(context *guigui.Context, widget guigui.Widget) image.Rec
 			{
 				Widget: &p.forms[1],
 			},
+			{
+				Widget: &p.forms[2],
+			},
 		},
 		Gap: u / 2,
 	}).WidgetBounds(context, context.Bounds(p).Inset(u/2), widget)
@@ -149,10 +184,18 @@ func (p *Popups) Layout(context *guigui.Context, widget guigui.Widget) image.Rec
 }
 
 func (p *Popups) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	// A long press opens the context menu on touchscreens.
+	p.contextMenuPopupLongPress.Update(context, &p.contextMenuPopupClickHereText)
+	if p.contextMenuPopupLongPress.JustLongPressed() {
+		p.contextMenuPopupPosition = context.PointerPosition()
+		p.contextMenuPopup.SetOpen(true)
+		return guigui.HandleInputByWidget(p)
+	}
+
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
 		// Use IsWidgetOrBackgroundHitAtCursor. context.IsWidgetHitAtCursor doesn't work when a popup's transparent background exists.
 		if p.contextMenuPopup.IsWidgetOrBackgroundHitAtCursor(context, &p.contextMenuPopupClickHereText) {
-			p.contextMenuPopupPosition = image.Pt(ebiten.CursorPosition())
+			p.contextMenuPopupPosition = context.PointerPosition()
 			p.contextMenuPopup.SetOpen(true)
 			return guigui.HandleInputByWidget(p)
 		}
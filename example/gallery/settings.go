@@ -31,15 +31,6 @@ func (s *Settings) AddChildren(context *guigui.Context, adder *guigui.ChildAdder
 }
 
 func (s *Settings) Update(context *guigui.Context) error {
-	lightModeImg, err := theImageCache.GetMonochrome("light_mode", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	darkModeImg, err := theImageCache.GetMonochrome("dark_mode", context.ColorMode())
-	if err != nil {
-		return err
-	}
-
 	s.colorModeText.SetValue("Color mode")
 	s.colorModeSegmentedControl.SetItems([]basicwidget.SegmentedControlItem[string]{
 		{
@@ -47,12 +38,12 @@ func (s *Settings) Update(context *guigui.Context) error {
 			Value: "",
 		},
 		{
-			Icon:  lightModeImg,
-			Value: "light",
+			IconName: "light_mode",
+			Value:    "light",
 		},
 		{
-			Icon:  darkModeImg,
-			Value: "dark",
+			IconName: "dark_mode",
+			Value:    "dark",
 		},
 	})
 	s.colorModeSegmentedControl.SetOnItemSelected(func(index int) {
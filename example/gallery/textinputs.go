@@ -5,6 +5,7 @@ package main
 
 import (
 	"image"
+	"time"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget"
@@ -22,6 +23,12 @@ type TextInputs struct {
 	multilineTextInput          guigui.WidgetWithSize[*basicwidget.TextInput]
 	inlineText                  basicwidget.Text
 	inlineTextInput             guigui.WidgetWithSize[*inlineTextInputContainer]
+	dateText                    basicwidget.Text
+	datePicker                  guigui.WidgetWithSize[*basicwidget.DatePicker]
+	timeText                    basicwidget.Text
+	timePicker                  guigui.WidgetWithSize[*basicwidget.TimePicker]
+	calendarText                basicwidget.Text
+	calendar                    basicwidget.Calendar
 
 	configForm                      basicwidget.Form
 	horizontalAlignText             basicwidget.Text
@@ -44,35 +51,6 @@ func (t *TextInputs) AddChildren(context *guigui.Context, adder *guigui.ChildAdd
 func (t *TextInputs) Update(context *guigui.Context) error {
 	model := context.Model(t, modelKeyModel).(*Model)
 
-	imgAlignStart, err := theImageCache.GetMonochrome("format_align_left", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignCenter, err := theImageCache.GetMonochrome("format_align_center", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignEnd, err := theImageCache.GetMonochrome("format_align_right", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignTop, err := theImageCache.GetMonochrome("vertical_align_top", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignMiddle, err := theImageCache.GetMonochrome("vertical_align_center", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignBottom, err := theImageCache.GetMonochrome("vertical_align_bottom", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgSearch, err := theImageCache.GetMonochrome("search", context.ColorMode())
-	if err != nil {
-		return err
-	}
-
 	u := basicwidget.UnitSize(context)
 
 	// Text Inputs
@@ -95,7 +73,7 @@ func (t *TextInputs) Update(context *guigui.Context) error {
 	t.singleLineWithIconTextInput.Widget().SetHorizontalAlign(model.TextInputs().HorizontalAlign())
 	t.singleLineWithIconTextInput.Widget().SetVerticalAlign(model.TextInputs().VerticalAlign())
 	t.singleLineWithIconTextInput.Widget().SetEditable(model.TextInputs().Editable())
-	t.singleLineWithIconTextInput.Widget().SetIcon(imgSearch)
+	t.singleLineWithIconTextInput.Widget().SetIconName("search")
 	context.SetEnabled(&t.singleLineWithIconTextInput, model.TextInputs().Enabled())
 	t.singleLineWithIconTextInput.SetFixedWidth(width)
 
@@ -121,6 +99,25 @@ func (t *TextInputs) Update(context *guigui.Context) error {
 	context.SetEnabled(&t.inlineTextInput, model.TextInputs().Enabled())
 	t.inlineTextInput.SetFixedWidth(width)
 
+	t.dateText.SetValue("Date (Weekends disabled)")
+	t.datePicker.Widget().SetDisabledDateFunc(func(date time.Time) bool {
+		return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+	})
+	context.SetEnabled(&t.datePicker, model.TextInputs().Enabled())
+	t.datePicker.SetFixedWidth(6 * u)
+
+	t.timeText.SetValue("Time")
+	t.timePicker.Widget().SetStep(15 * time.Minute)
+	context.SetEnabled(&t.timePicker, model.TextInputs().Enabled())
+	t.timePicker.SetFixedWidth(6 * u)
+
+	t.calendarText.SetValue("Calendar (Next 90 days)")
+	y, m, d := time.Now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	t.calendar.SetMinimumDate(today)
+	t.calendar.SetMaximumDate(today.AddDate(0, 0, 90))
+	context.SetEnabled(&t.calendar, model.TextInputs().Enabled())
+
 	t.textInputForm.SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &t.singleLineText,
@@ -138,22 +135,34 @@ func (t *TextInputs) Update(context *guigui.Context) error {
 			PrimaryWidget:   &t.inlineText,
 			SecondaryWidget: &t.inlineTextInput,
 		},
+		{
+			PrimaryWidget:   &t.dateText,
+			SecondaryWidget: &t.datePicker,
+		},
+		{
+			PrimaryWidget:   &t.timeText,
+			SecondaryWidget: &t.timePicker,
+		},
+		{
+			PrimaryWidget:   &t.calendarText,
+			SecondaryWidget: &t.calendar,
+		},
 	})
 
 	// Configurations
 	t.horizontalAlignText.SetValue("Horizontal align")
 	t.horizontalAlignSegmentedControl.SetItems([]basicwidget.SegmentedControlItem[basicwidget.HorizontalAlign]{
 		{
-			Icon:  imgAlignStart,
-			Value: basicwidget.HorizontalAlignStart,
+			IconName: "format_align_left",
+			Value:    basicwidget.HorizontalAlignStart,
 		},
 		{
-			Icon:  imgAlignCenter,
-			Value: basicwidget.HorizontalAlignCenter,
+			IconName: "format_align_center",
+			Value:    basicwidget.HorizontalAlignCenter,
 		},
 		{
-			Icon:  imgAlignEnd,
-			Value: basicwidget.HorizontalAlignEnd,
+			IconName: "format_align_right",
+			Value:    basicwidget.HorizontalAlignEnd,
 		},
 	})
 	t.horizontalAlignSegmentedControl.SetOnItemSelected(func(index int) {
@@ -169,16 +178,16 @@ func (t *TextInputs) Update(context *guigui.Context) error {
 	t.verticalAlignText.SetValue("Vertical align")
 	t.verticalAlignSegmentedControl.SetItems([]basicwidget.SegmentedControlItem[basicwidget.VerticalAlign]{
 		{
-			Icon:  imgAlignTop,
-			Value: basicwidget.VerticalAlignTop,
+			IconName: "vertical_align_top",
+			Value:    basicwidget.VerticalAlignTop,
 		},
 		{
-			Icon:  imgAlignMiddle,
-			Value: basicwidget.VerticalAlignMiddle,
+			IconName: "vertical_align_center",
+			Value:    basicwidget.VerticalAlignMiddle,
 		},
 		{
-			Icon:  imgAlignBottom,
-			Value: basicwidget.VerticalAlignBottom,
+			IconName: "vertical_align_bottom",
+			Value:    basicwidget.VerticalAlignBottom,
 		},
 	})
 	t.verticalAlignSegmentedControl.SetOnItemSelected(func(index int) {
@@ -39,44 +39,19 @@ func (t *Texts) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
 func (t *Texts) Update(context *guigui.Context) error {
 	model := context.Model(t, modelKeyModel).(*Model)
 
-	imgAlignStart, err := theImageCache.GetMonochrome("format_align_left", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignCenter, err := theImageCache.GetMonochrome("format_align_center", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignEnd, err := theImageCache.GetMonochrome("format_align_right", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignTop, err := theImageCache.GetMonochrome("vertical_align_top", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignMiddle, err := theImageCache.GetMonochrome("vertical_align_center", context.ColorMode())
-	if err != nil {
-		return err
-	}
-	imgAlignBottom, err := theImageCache.GetMonochrome("vertical_align_bottom", context.ColorMode())
-	if err != nil {
-		return err
-	}
-
 	t.horizontalAlignText.SetValue("Horizontal align")
 	t.horizontalAlignSegmentedControl.SetItems([]basicwidget.SegmentedControlItem[basicwidget.HorizontalAlign]{
 		{
-			Icon:  imgAlignStart,
-			Value: basicwidget.HorizontalAlignStart,
+			IconName: "format_align_left",
+			Value:    basicwidget.HorizontalAlignStart,
 		},
 		{
-			Icon:  imgAlignCenter,
-			Value: basicwidget.HorizontalAlignCenter,
+			IconName: "format_align_center",
+			Value:    basicwidget.HorizontalAlignCenter,
 		},
 		{
-			Icon:  imgAlignEnd,
-			Value: basicwidget.HorizontalAlignEnd,
+			IconName: "format_align_right",
+			Value:    basicwidget.HorizontalAlignEnd,
 		},
 	})
 	t.horizontalAlignSegmentedControl.SetOnItemSelected(func(index int) {
@@ -92,16 +67,16 @@ func (t *Texts) Update(context *guigui.Context) error {
 	t.verticalAlignText.SetValue("Vertical align")
 	t.verticalAlignSegmentedControl.SetItems([]basicwidget.SegmentedControlItem[basicwidget.VerticalAlign]{
 		{
-			Icon:  imgAlignTop,
-			Value: basicwidget.VerticalAlignTop,
+			IconName: "vertical_align_top",
+			Value:    basicwidget.VerticalAlignTop,
 		},
 		{
-			Icon:  imgAlignMiddle,
-			Value: basicwidget.VerticalAlignMiddle,
+			IconName: "vertical_align_center",
+			Value:    basicwidget.VerticalAlignMiddle,
 		},
 		{
-			Icon:  imgAlignBottom,
-			Value: basicwidget.VerticalAlignBottom,
+			IconName: "vertical_align_bottom",
+			Value:    basicwidget.VerticalAlignBottom,
 		},
 	})
 	t.verticalAlignSegmentedControl.SetOnItemSelected(func(index int) {
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const MaxDirtyRegions = maxDirtyRegions
+
+func RunPostedFuncs() bool {
+	return thePostedFuncs.run()
+}
+
+func AddDirtyRegions(rects []image.Rectangle) []image.Rectangle {
+	var d dirtyRegions
+	for _, r := range rects {
+		d.add(r)
+	}
+	return d.rects
+}
+
+// TestApp builds a widget tree without running a game.
+type TestApp struct {
+	app app
+}
+
+func NewTestApp(root Widget, width, height int) *TestApp {
+	a := &TestApp{}
+	a.app.root = root
+	a.app.context.app = &a.app
+	a.SetSize(width, height)
+	return a
+}
+
+func (a *TestApp) Context() *Context {
+	return &a.app.context
+}
+
+func (a *TestApp) SetSize(width, height int) {
+	a.app.screenWidth = float64(width)
+	a.app.screenHeight = float64(height)
+	a.app.root.widgetState().bounds = a.app.bounds()
+}
+
+// Build builds the widget tree like an update does.
+func (a *TestApp) Build() error {
+	a.app.skipBuild = false
+	a.app.context.inBuild = true
+	if err := a.app.buildWidgets(); err != nil {
+		return err
+	}
+	a.app.context.inBuild = false
+	a.app.updateMountedWidgets()
+	return nil
+}
+
+func (a *TestApp) RequestFullBuild() {
+	a.app.requestRedrawAll()
+}
+
+func (a *TestApp) TickTimers() bool {
+	return a.app.timers.tick(a.app.buildCount)
+}
+
+type PointerState struct {
+	pointerState pointerState
+}
+
+func (p *PointerState) Update(cursorPosition image.Point, touchIDs []ebiten.TouchID, touchPosition func(id ebiten.TouchID) image.Point) {
+	p.pointerState.update(cursorPosition, touchIDs, touchPosition)
+}
+
+func (p *PointerState) Position() image.Point {
+	return p.pointerState.position
+}
+
+func (p *PointerState) IsTouch() bool {
+	return p.pointerState.touch
+}
+
+func (p *PointerState) PrimaryTouchID() (ebiten.TouchID, bool) {
+	return p.pointerState.primaryTouchID, p.pointerState.hasPrimaryTouch
+}
+
+func (p *PointerState) IsPrimaryTouchJustPressed() bool {
+	return p.pointerState.primaryTouchJustPressed
+}
+
+func (p *PointerState) IsPrimaryTouchJustReleased() bool {
+	return p.pointerState.primaryTouchJustReleased
+}
+
+func (p *PointerState) AppendTouches(touches []Touch) []Touch {
+	return append(touches, p.pointerState.touches...)
+}
+
+// PointerInput is a snapshot of the pointer state for a recognizer.
+type PointerInput struct {
+	Position     image.Point
+	Pressed      bool
+	JustPressed  bool
+	JustReleased bool
+	Hit          bool
+	Tick         int64
+}
+
+func (p PointerInput) pointerInput() pointerInput {
+	return pointerInput{
+		position:     p.Position,
+		pressed:      p.Pressed,
+		justPressed:  p.JustPressed,
+		justReleased: p.JustReleased,
+		hit:          p.Hit,
+		tick:         p.Tick,
+	}
+}
+
+func UpdateClickRecognizer(c *ClickRecognizer, input PointerInput, intervalInTicks int, slop int) {
+	c.update(input.pointerInput(), intervalInTicks, slop)
+}
+
+func UpdateLongPressRecognizer(l *LongPressRecognizer, input PointerInput, durationInTicks int, slop int) {
+	l.update(input.pointerInput(), durationInTicks, slop)
+}
+
+func UpdateDragRecognizer(d *DragRecognizer, input PointerInput, threshold int) {
+	d.update(input.pointerInput(), threshold)
+}
+
+func UpdateHoverRecognizer(h *HoverRecognizer, hit bool) {
+	h.update(hit)
+}
@@ -0,0 +1,445 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// The recognizers in this file recognize gestures of the pointer, i.e. the left mouse button or the primary touch, on a widget.
+// Embed a recognizer in a widget, and call its Update once per tick, typically at the start of the widget's HandlePointingInput.
+//
+// HandlePointingInput is not called when another widget handles the input first.
+// A recognizer not updated at the previous tick discards the press in progress, as the pointer might be released meanwhile.
+
+const defaultMultiClickInterval = 500 * time.Millisecond
+
+const defaultLongPressDuration = 500 * time.Millisecond
+
+// pointerInput is a snapshot of the pointer state for a widget.
+type pointerInput struct {
+	position     image.Point
+	pressed      bool
+	justPressed  bool
+	justReleased bool
+	hit          bool
+	tick         int64
+}
+
+// isSuccessiveTick reports whether tick is the next tick of *lastTick, and updates *lastTick with tick.
+func isSuccessiveTick(lastTick *int64, tick int64) bool {
+	successive := *lastTick+1 == tick
+	*lastTick = tick
+	return successive
+}
+
+func currentPointerInput(context *Context, widget Widget) pointerInput {
+	return pointerInput{
+		position:     context.PointerPosition(),
+		pressed:      context.IsPointerPressed(),
+		justPressed:  context.IsPointerJustPressed(),
+		justReleased: context.IsPointerJustReleased(),
+		hit:          context.IsWidgetHitAtCursor(widget),
+		tick:         ebiten.Tick(),
+	}
+}
+
+// pointerSlop returns the distance in pixels that the pointer can move without being regarded as moved.
+// The distance is larger for touches to distinguish a tap from a drag, as fingers are less precise.
+func pointerSlop(context *Context) int {
+	if context.IsPointerTouch() {
+		return max(int(12*context.Scale()), 1)
+	}
+	return max(int(4*context.Scale()), 1)
+}
+
+func isWithinDistance(p0, p1 image.Point, distance int) bool {
+	d := p1.Sub(p0)
+	return d.X*d.X+d.Y*d.Y <= distance*distance
+}
+
+// ClickRecognizer recognizes clicks and multi-clicks like double-clicks.
+type ClickRecognizer struct {
+	// MultiClickInterval is the maximum interval between presses to count them as a multi-click.
+	// The default value is 500 milliseconds.
+	MultiClickInterval time.Duration
+
+	pressed           bool
+	justPressed       bool
+	justClicked       bool
+	count             int
+	lastPressTick     int64
+	lastPressPosition image.Point
+	lastTick          int64
+}
+
+func (c *ClickRecognizer) Update(context *Context, widget Widget) {
+	interval := c.MultiClickInterval
+	if interval == 0 {
+		interval = defaultMultiClickInterval
+	}
+	c.update(currentPointerInput(context, widget), durationToTicks(interval), pointerSlop(context))
+}
+
+func (c *ClickRecognizer) update(input pointerInput, intervalInTicks int, slop int) {
+	c.justPressed = false
+	c.justClicked = false
+	if !isSuccessiveTick(&c.lastTick, input.tick) {
+		c.pressed = false
+	}
+
+	if input.justPressed && input.hit {
+		if c.count > 0 && input.tick-c.lastPressTick <= int64(intervalInTicks) && isWithinDistance(c.lastPressPosition, input.position, slop) {
+			c.count++
+		} else {
+			c.count = 1
+		}
+		c.lastPressTick = input.tick
+		c.lastPressPosition = input.position
+		c.pressed = true
+		c.justPressed = true
+		return
+	}
+
+	if c.pressed && (input.justReleased || !input.pressed) {
+		c.pressed = false
+		c.justClicked = input.hit
+	}
+}
+
+// JustPressed reports whether the widget is pressed at this tick.
+func (c *ClickRecognizer) JustPressed() bool {
+	return c.justPressed
+}
+
+// JustClicked reports whether the button is released on the widget after being pressed on the widget at this tick.
+func (c *ClickRecognizer) JustClicked() bool {
+	return c.justClicked
+}
+
+// Count returns the number of successive presses, e.g. 2 for a double-click.
+// Count is valid when JustPressed or JustClicked is true.
+func (c *ClickRecognizer) Count() int {
+	return c.count
+}
+
+// LongPressRecognizer recognizes a long press, i.e. pressing the widget for a while without moving the pointer.
+type LongPressRecognizer struct {
+	// Duration is the duration to regard a press as a long press.
+	// The default value is 500 milliseconds.
+	Duration time.Duration
+
+	pressed         bool
+	pressTick       int64
+	pressPosition   image.Point
+	fired           bool
+	justLongPressed bool
+	lastTick        int64
+}
+
+func (l *LongPressRecognizer) Update(context *Context, widget Widget) {
+	d := l.Duration
+	if d == 0 {
+		d = defaultLongPressDuration
+	}
+	l.update(currentPointerInput(context, widget), durationToTicks(d), pointerSlop(context))
+}
+
+func (l *LongPressRecognizer) update(input pointerInput, durationInTicks int, slop int) {
+	l.justLongPressed = false
+	if !isSuccessiveTick(&l.lastTick, input.tick) {
+		l.pressed = false
+	}
+
+	if input.justPressed && input.hit {
+		l.pressed = true
+		l.pressTick = input.tick
+		l.pressPosition = input.position
+		l.fired = false
+		return
+	}
+	if !l.pressed {
+		return
+	}
+	if !input.pressed || !isWithinDistance(l.pressPosition, input.position, slop) {
+		l.pressed = false
+		return
+	}
+	if !l.fired && input.tick-l.pressTick >= int64(durationInTicks) {
+		l.fired = true
+		l.justLongPressed = true
+	}
+}
+
+// JustLongPressed reports whether a long press is recognized at this tick.
+func (l *LongPressRecognizer) JustLongPressed() bool {
+	return l.justLongPressed
+}
+
+// DragRecognizer recognizes a drag started on the widget.
+//
+// A drag starts when the pointer moves farther than the threshold while the button is pressed.
+// DragRecognizer captures the pointer while the button is pressed, so the drag continues outside of the widget.
+type DragRecognizer struct {
+	// Threshold is the distance in pixels to start a drag.
+	// The default value depends on the scale.
+	Threshold int
+
+	// CaptureAfterStart makes DragRecognizer capture the pointer after a drag starts instead of after a press.
+	// This is useful to let the other widgets handle a press not becoming a drag, e.g. a tap on an item of a list.
+	CaptureAfterStart bool
+
+	pressed     bool
+	dragging    bool
+	justPressed bool
+	justStarted bool
+	justEnded   bool
+	start       image.Point
+	position    image.Point
+	lastTick    int64
+	capturing   bool
+}
+
+func (d *DragRecognizer) Update(context *Context, widget Widget) {
+	threshold := d.Threshold
+	if threshold == 0 {
+		threshold = pointerSlop(context)
+	}
+	d.update(currentPointerInput(context, widget), threshold)
+	d.updatePointerCapture(context, widget)
+}
+
+func (d *DragRecognizer) updatePointerCapture(context *Context, widget Widget) {
+	capturing := d.pressed && (d.dragging || !d.CaptureAfterStart)
+	if capturing {
+		context.SetPointerCapture(widget)
+	} else if d.capturing {
+		context.ReleasePointerCapture(widget)
+	}
+	d.capturing = capturing
+}
+
+func (d *DragRecognizer) update(input pointerInput, threshold int) {
+	d.justPressed = false
+	d.justStarted = false
+	d.justEnded = false
+	if !isSuccessiveTick(&d.lastTick, input.tick) {
+		d.pressed = false
+		d.dragging = false
+	}
+
+	if input.justPressed && input.hit {
+		d.pressed = true
+		d.dragging = false
+		d.justPressed = true
+		d.start = input.position
+		d.position = input.position
+		return
+	}
+	if !d.pressed {
+		return
+	}
+
+	d.position = input.position
+	if !input.pressed {
+		d.pressed = false
+		if d.dragging {
+			d.dragging = false
+			d.justEnded = true
+		}
+		return
+	}
+	if !d.dragging && !isWithinDistance(d.start, input.position, threshold) {
+		d.dragging = true
+		d.justStarted = true
+	}
+}
+
+// Cancel cancels the press or the drag in progress, e.g. when the press is not for the widget.
+// Cancel releases the pointer captured by d.
+func (d *DragRecognizer) Cancel(context *Context, widget Widget) {
+	d.pressed = false
+	d.dragging = false
+	d.updatePointerCapture(context, widget)
+}
+
+// IsPressed reports whether the button pressed on the widget is still pressed, whether a drag starts or not.
+func (d *DragRecognizer) IsPressed() bool {
+	return d.pressed
+}
+
+func (d *DragRecognizer) IsDragging() bool {
+	return d.dragging
+}
+
+// JustPressed reports whether the widget is pressed at this tick.
+func (d *DragRecognizer) JustPressed() bool {
+	return d.justPressed
+}
+
+// JustStarted reports whether a drag starts at this tick.
+func (d *DragRecognizer) JustStarted() bool {
+	return d.justStarted
+}
+
+// JustEnded reports whether a drag ends at this tick.
+func (d *DragRecognizer) JustEnded() bool {
+	return d.justEnded
+}
+
+// StartPosition returns the position where the button was pressed.
+func (d *DragRecognizer) StartPosition() image.Point {
+	return d.start
+}
+
+// Position returns the current position of the pointer during a drag.
+func (d *DragRecognizer) Position() image.Point {
+	return d.position
+}
+
+// Delta returns the distance from the start position to the current position.
+func (d *DragRecognizer) Delta() image.Point {
+	return d.position.Sub(d.start)
+}
+
+// HoverRecognizer recognizes the pointer entering and leaving the widget.
+type HoverRecognizer struct {
+	hovered     bool
+	justEntered bool
+	justLeft    bool
+}
+
+func (h *HoverRecognizer) Update(context *Context, widget Widget) {
+	h.update(context.IsWidgetHitAtCursor(widget))
+}
+
+func (h *HoverRecognizer) update(hit bool) {
+	h.justEntered = hit && !h.hovered
+	h.justLeft = !hit && h.hovered
+	h.hovered = hit
+}
+
+func (h *HoverRecognizer) IsHovered() bool {
+	return h.hovered
+}
+
+// JustEntered reports whether the pointer enters the widget at this tick.
+func (h *HoverRecognizer) JustEntered() bool {
+	return h.justEntered
+}
+
+// JustLeft reports whether the pointer leaves the widget at this tick.
+func (h *HoverRecognizer) JustLeft() bool {
+	return h.justLeft
+}
+
+// PinchRecognizer recognizes a pinch with two touches on the widget, e.g. to zoom a canvas.
+//
+// PinchRecognizer captures the pointer during a pinch.
+type PinchRecognizer struct {
+	touchIDs      [2]ebiten.TouchID
+	pinching      bool
+	justStarted   bool
+	justEnded     bool
+	startDistance float64
+	distance      float64
+	startCenter   image.Point
+	center        image.Point
+
+	tmpTouches []Touch
+}
+
+func (p *PinchRecognizer) Update(context *Context, widget Widget) {
+	p.tmpTouches = context.AppendTouches(p.tmpTouches[:0])
+	p.update(p.tmpTouches, func(point image.Point) bool {
+		return context.IsWidgetHitAt(widget, point)
+	})
+	if p.pinching {
+		context.SetPointerCapture(widget)
+	} else if p.justEnded {
+		context.ReleasePointerCapture(widget)
+	}
+}
+
+func (p *PinchRecognizer) update(touches []Touch, isHitAt func(point image.Point) bool) {
+	p.justStarted = false
+	p.justEnded = false
+
+	if p.pinching {
+		i0 := slices.IndexFunc(touches, func(t Touch) bool { return t.ID == p.touchIDs[0] })
+		i1 := slices.IndexFunc(touches, func(t Touch) bool { return t.ID == p.touchIDs[1] })
+		if i0 < 0 || i1 < 0 {
+			p.pinching = false
+			p.justEnded = true
+			return
+		}
+		p.distance, p.center = pinchDistanceAndCenter(touches[i0].Position, touches[i1].Position)
+		return
+	}
+
+	// Start a pinch with the first two touches started on the widget.
+	var ts [2]Touch
+	var n int
+	for _, t := range touches {
+		if !isHitAt(t.StartPosition) {
+			continue
+		}
+		ts[n] = t
+		n++
+		if n == len(ts) {
+			break
+		}
+	}
+	if n < len(ts) {
+		return
+	}
+	p.touchIDs = [...]ebiten.TouchID{ts[0].ID, ts[1].ID}
+	p.pinching = true
+	p.justStarted = true
+	p.distance, p.center = pinchDistanceAndCenter(ts[0].Position, ts[1].Position)
+	p.startDistance = p.distance
+	p.startCenter = p.center
+}
+
+func pinchDistanceAndCenter(p0, p1 image.Point) (float64, image.Point) {
+	d := p1.Sub(p0)
+	return math.Hypot(float64(d.X), float64(d.Y)), p0.Add(p1).Div(2)
+}
+
+func (p *PinchRecognizer) IsPinching() bool {
+	return p.pinching
+}
+
+// JustStarted reports whether a pinch starts at this tick.
+func (p *PinchRecognizer) JustStarted() bool {
+	return p.justStarted
+}
+
+// JustEnded reports whether a pinch ends at this tick.
+func (p *PinchRecognizer) JustEnded() bool {
+	return p.justEnded
+}
+
+// Scale returns the ratio of the current distance between the touches to the distance at the start.
+func (p *PinchRecognizer) Scale() float64 {
+	if p.startDistance == 0 {
+		return 1
+	}
+	return p.distance / p.startDistance
+}
+
+// Center returns the current center of the touches.
+func (p *PinchRecognizer) Center() image.Point {
+	return p.center
+}
+
+// StartCenter returns the center of the touches at the start.
+func (p *PinchRecognizer) StartCenter() image.Point {
+	return p.startCenter
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func pressInput(tick int64, x, y int) guigui.PointerInput {
+	return guigui.PointerInput{Position: image.Pt(x, y), Pressed: true, JustPressed: true, Hit: true, Tick: tick}
+}
+
+func holdInput(tick int64, x, y int) guigui.PointerInput {
+	return guigui.PointerInput{Position: image.Pt(x, y), Pressed: true, Hit: true, Tick: tick}
+}
+
+func releaseInput(tick int64, x, y int, hit bool) guigui.PointerInput {
+	return guigui.PointerInput{Position: image.Pt(x, y), JustReleased: true, Hit: hit, Tick: tick}
+}
+
+func idleInput(tick int64, x, y int) guigui.PointerInput {
+	return guigui.PointerInput{Position: image.Pt(x, y), Hit: true, Tick: tick}
+}
+
+func TestClickRecognizer(t *testing.T) {
+	var c guigui.ClickRecognizer
+	const interval, slop = 30, 4
+
+	guigui.UpdateClickRecognizer(&c, pressInput(0, 10, 10), interval, slop)
+	if !c.JustPressed() || c.Count() != 1 {
+		t.Errorf("first press: JustPressed: %v, Count: %d", c.JustPressed(), c.Count())
+	}
+	guigui.UpdateClickRecognizer(&c, releaseInput(1, 10, 10, true), interval, slop)
+	if !c.JustClicked() || c.Count() != 1 {
+		t.Errorf("first click: JustClicked: %v, Count: %d", c.JustClicked(), c.Count())
+	}
+
+	// A press close in time and space is a double-click.
+	guigui.UpdateClickRecognizer(&c, pressInput(2, 12, 11), interval, slop)
+	if c.Count() != 2 {
+		t.Errorf("second press: Count: got: %d, want: 2", c.Count())
+	}
+	// Releasing outside of the widget is not a click.
+	guigui.UpdateClickRecognizer(&c, releaseInput(3, 100, 100, false), interval, slop)
+	if c.JustClicked() {
+		t.Errorf("releasing outside must not be a click")
+	}
+
+	// A press far from the last press starts a new count.
+	guigui.UpdateClickRecognizer(&c, pressInput(4, 50, 50), interval, slop)
+	if c.Count() != 1 {
+		t.Errorf("far press: Count: got: %d, want: 1", c.Count())
+	}
+	// A press after the interval starts a new count.
+	guigui.UpdateClickRecognizer(&c, releaseInput(5, 50, 50, true), interval, slop)
+	for tick := int64(6); tick < 100; tick++ {
+		guigui.UpdateClickRecognizer(&c, idleInput(tick, 50, 50), interval, slop)
+	}
+	guigui.UpdateClickRecognizer(&c, pressInput(100, 50, 50), interval, slop)
+	if c.Count() != 1 {
+		t.Errorf("late press: Count: got: %d, want: 1", c.Count())
+	}
+	guigui.UpdateClickRecognizer(&c, releaseInput(101, 50, 50, true), interval, slop)
+
+	// A press is discarded when the recognizer is not updated at a tick.
+	guigui.UpdateClickRecognizer(&c, pressInput(102, 50, 50), interval, slop)
+	guigui.UpdateClickRecognizer(&c, releaseInput(104, 50, 50, true), interval, slop)
+	if c.JustClicked() {
+		t.Errorf("a press discarded by a missed tick must not be a click")
+	}
+}
+
+func TestLongPressRecognizer(t *testing.T) {
+	var l guigui.LongPressRecognizer
+	const duration, slop = 30, 4
+
+	var fired []int64
+	guigui.UpdateLongPressRecognizer(&l, pressInput(0, 10, 10), duration, slop)
+	for tick := int64(1); tick < 60; tick++ {
+		guigui.UpdateLongPressRecognizer(&l, holdInput(tick, 11, 10), duration, slop)
+		if l.JustLongPressed() {
+			fired = append(fired, tick)
+		}
+	}
+	if len(fired) != 1 || fired[0] != duration {
+		t.Errorf("long press fired at: %v, want: [%d]", fired, duration)
+	}
+
+	// Moving the pointer cancels a long press.
+	guigui.UpdateLongPressRecognizer(&l, pressInput(60, 10, 10), duration, slop)
+	guigui.UpdateLongPressRecognizer(&l, holdInput(61, 30, 10), duration, slop)
+	for tick := int64(62); tick < 120; tick++ {
+		guigui.UpdateLongPressRecognizer(&l, holdInput(tick, 10, 10), duration, slop)
+		if l.JustLongPressed() {
+			t.Errorf("a moved press must not be a long press")
+			break
+		}
+	}
+
+	// A press is discarded when the recognizer is not updated at a tick.
+	guigui.UpdateLongPressRecognizer(&l, pressInput(120, 10, 10), duration, slop)
+	for tick := int64(122); tick < 180; tick++ {
+		guigui.UpdateLongPressRecognizer(&l, holdInput(tick, 10, 10), duration, slop)
+		if l.JustLongPressed() {
+			t.Errorf("a press discarded by a missed tick must not be a long press")
+			break
+		}
+	}
+}
+
+func TestDragRecognizer(t *testing.T) {
+	var d guigui.DragRecognizer
+	const threshold = 4
+
+	guigui.UpdateDragRecognizer(&d, pressInput(0, 10, 10), threshold)
+	if !d.JustPressed() || !d.IsPressed() {
+		t.Errorf("a press: JustPressed: %v, IsPressed: %v", d.JustPressed(), d.IsPressed())
+	}
+	guigui.UpdateDragRecognizer(&d, holdInput(1, 12, 10), threshold)
+	if d.IsDragging() {
+		t.Errorf("a move within the threshold must not start a drag")
+	}
+	guigui.UpdateDragRecognizer(&d, holdInput(2, 20, 10), threshold)
+	if !d.IsDragging() || !d.JustStarted() {
+		t.Errorf("a drag must start: IsDragging: %v, JustStarted: %v", d.IsDragging(), d.JustStarted())
+	}
+	// The drag continues outside of the widget.
+	guigui.UpdateDragRecognizer(&d, guigui.PointerInput{Position: image.Pt(200, 30), Pressed: true, Tick: 3}, threshold)
+	if got, want := d.Delta(), image.Pt(190, 20); got != want {
+		t.Errorf("Delta: got: %v, want: %v", got, want)
+	}
+	guigui.UpdateDragRecognizer(&d, releaseInput(4, 200, 30, false), threshold)
+	if d.IsDragging() || !d.JustEnded() {
+		t.Errorf("a drag must end: IsDragging: %v, JustEnded: %v", d.IsDragging(), d.JustEnded())
+	}
+
+	// A drag is discarded without ending when the recognizer is not updated at a tick.
+	guigui.UpdateDragRecognizer(&d, pressInput(5, 10, 10), threshold)
+	guigui.UpdateDragRecognizer(&d, holdInput(6, 20, 10), threshold)
+	guigui.UpdateDragRecognizer(&d, holdInput(8, 30, 10), threshold)
+	if d.IsPressed() || d.IsDragging() || d.JustEnded() {
+		t.Errorf("a drag must be discarded: IsPressed: %v, IsDragging: %v, JustEnded: %v", d.IsPressed(), d.IsDragging(), d.JustEnded())
+	}
+}
+
+func TestHoverRecognizer(t *testing.T) {
+	var h guigui.HoverRecognizer
+	var entered, left int
+	for _, hit := range []bool{false, true, true, false, true} {
+		guigui.UpdateHoverRecognizer(&h, hit)
+		if h.JustEntered() {
+			entered++
+		}
+		if h.JustLeft() {
+			left++
+		}
+	}
+	if entered != 2 || left != 1 || !h.IsHovered() {
+		t.Errorf("entered: %d, left: %d, hovered: %v", entered, left, h.IsHovered())
+	}
+}
@@ -135,33 +135,29 @@ func (l *LinearLayout) appendWidgetAlongPositionAndSizes(widgetAlongPositions []
 	return widgetAlongPositions
 }
 
-func linearLayoutItemDefaultAlongSize(context *Context, direction LayoutDirection, item *LinearLayoutItem, acrossSize int) int {
+func linearLayoutItemMeasure(context *Context, item *LinearLayoutItem, constraints Constraints) image.Point {
 	if item.Widget != nil {
-		switch direction {
-		case LayoutDirectionHorizontal:
-			if acrossSize <= 0 {
-				return item.Widget.Measure(context, Constraints{}).X
-			}
-			return item.Widget.Measure(context, FixedHeightConstraints(acrossSize)).X
-		case LayoutDirectionVertical:
-			if acrossSize <= 0 {
-				return item.Widget.Measure(context, Constraints{}).Y
-			}
-			return item.Widget.Measure(context, FixedWidthConstraints(acrossSize)).Y
+		return context.Measure(item.Widget, constraints)
+	}
+	if item.Layout != nil {
+		return item.Layout.Measure(context, constraints)
+	}
+	return image.Point{}
+}
+
+func linearLayoutItemDefaultAlongSize(context *Context, direction LayoutDirection, item *LinearLayoutItem, acrossSize int) int {
+	var constraints Constraints
+	switch direction {
+	case LayoutDirectionHorizontal:
+		if acrossSize > 0 {
+			constraints = FixedHeightConstraints(acrossSize)
 		}
-	} else if item.Layout != nil {
-		switch direction {
-		case LayoutDirectionHorizontal:
-			if acrossSize <= 0 {
-				return item.Layout.Measure(context, Constraints{}).X
-			}
-			return item.Layout.Measure(context, FixedHeightConstraints(acrossSize)).X
-		case LayoutDirectionVertical:
-			if acrossSize <= 0 {
-				return item.Layout.Measure(context, Constraints{}).Y
-			}
-			return item.Layout.Measure(context, FixedWidthConstraints(acrossSize)).Y
+		return linearLayoutItemMeasure(context, item, constraints).X
+	case LayoutDirectionVertical:
+		if acrossSize > 0 {
+			constraints = FixedWidthConstraints(acrossSize)
 		}
+		return linearLayoutItemMeasure(context, item, constraints).Y
 	}
 	return 0
 }
@@ -220,17 +216,22 @@ func (l *LinearLayout) appendSizesInPixels(sizesInPixels []int, context *Context
 }
 
 func (l LinearLayout) Measure(context *Context, constraints Constraints) image.Point {
-	var contentAcrossSize int
+	// itemConstraints is the constraints for the items in the across direction.
+	var itemConstraints Constraints
 	switch l.Direction {
 	case LayoutDirectionHorizontal:
-		if fixedH, ok := constraints.FixedHeight(); ok {
-			contentAcrossSize = fixedH - l.Padding.Top - l.Padding.Bottom
-			contentAcrossSize = max(contentAcrossSize, 0)
+		padding := l.Padding.Top + l.Padding.Bottom
+		if h, ok := constraints.FixedHeight(); ok && h-padding > 0 {
+			itemConstraints = FixedHeightConstraints(h - padding)
+		} else if h, ok := constraints.MaxHeight(); ok && h-padding > 0 {
+			itemConstraints = Constraints{}.WithMaxHeight(h - padding)
 		}
 	case LayoutDirectionVertical:
-		if fixedW, ok := constraints.FixedWidth(); ok {
-			contentAcrossSize = fixedW - l.Padding.Start - l.Padding.End
-			contentAcrossSize = max(contentAcrossSize, 0)
+		padding := l.Padding.Start + l.Padding.End
+		if w, ok := constraints.FixedWidth(); ok && w-padding > 0 {
+			itemConstraints = FixedWidthConstraints(w - padding)
+		} else if w, ok := constraints.MaxWidth(); ok && w-padding > 0 {
+			itemConstraints = Constraints{}.WithMaxWidth(w - padding)
 		}
 	}
 
@@ -240,43 +241,31 @@ func (l LinearLayout) Measure(context *Context, constraints Constraints) image.P
 		var s int
 		switch item.Size.typ {
 		case sizeTypeDefault:
-			s = linearLayoutItemDefaultAlongSize(context, l.Direction, &item, contentAcrossSize)
+			switch l.Direction {
+			case LayoutDirectionHorizontal:
+				s = linearLayoutItemMeasure(context, &item, itemConstraints).X
+			case LayoutDirectionVertical:
+				s = linearLayoutItemMeasure(context, &item, itemConstraints).Y
+			}
 		case sizeTypeFixed:
 			s = item.Size.value
 		case sizeTypeFlexible:
 			// Ignore this.
 		}
 		autoAlongSize += s
-		if item.Widget != nil {
-			switch l.Direction {
-			case LayoutDirectionHorizontal:
-				if s <= 0 {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, Constraints{}).Y)
-				} else {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, FixedWidthConstraints(s)).Y)
-				}
-			case LayoutDirectionVertical:
-				if s <= 0 {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, Constraints{}).X)
-				} else {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, FixedHeightConstraints(s)).X)
-				}
+
+		c := itemConstraints
+		switch l.Direction {
+		case LayoutDirectionHorizontal:
+			if s > 0 {
+				c = c.WithFixedWidth(s)
 			}
-		} else if item.Layout != nil {
-			switch l.Direction {
-			case LayoutDirectionHorizontal:
-				if s <= 0 {
-					autoAcrossSize = max(autoAcrossSize, item.Layout.Measure(context, Constraints{}).Y)
-				} else {
-					autoAcrossSize = max(autoAcrossSize, item.Layout.Measure(context, FixedWidthConstraints(s)).Y)
-				}
-			case LayoutDirectionVertical:
-				if s <= 0 {
-					autoAcrossSize = max(autoAcrossSize, item.Layout.Measure(context, Constraints{}).X)
-				} else {
-					autoAcrossSize = max(autoAcrossSize, item.Layout.Measure(context, FixedHeightConstraints(s)).X)
-				}
+			autoAcrossSize = max(autoAcrossSize, linearLayoutItemMeasure(context, &item, c).Y)
+		case LayoutDirectionVertical:
+			if s > 0 {
+				c = c.WithFixedHeight(s)
 			}
+			autoAcrossSize = max(autoAcrossSize, linearLayoutItemMeasure(context, &item, c).X)
 		}
 	}
 
@@ -284,29 +273,16 @@ func (l LinearLayout) Measure(context *Context, constraints Constraints) image.P
 		autoAlongSize += (len(l.Items) - 1) * l.Gap
 	}
 
+	var size image.Point
 	switch l.Direction {
 	case LayoutDirectionHorizontal:
-		alongSize := autoAlongSize + l.Padding.Start + l.Padding.End
-		acrossSize := autoAcrossSize + l.Padding.Top + l.Padding.Bottom
-		if fixedWidth, ok := constraints.FixedWidth(); ok {
-			alongSize = fixedWidth
-		}
-		if fixedHeight, ok := constraints.FixedHeight(); ok {
-			acrossSize = fixedHeight
-		}
-		return image.Pt(alongSize, acrossSize)
+		size.X = autoAlongSize + l.Padding.Start + l.Padding.End
+		size.Y = autoAcrossSize + l.Padding.Top + l.Padding.Bottom
 	case LayoutDirectionVertical:
-		alongSize := autoAlongSize + l.Padding.Top + l.Padding.Bottom
-		acrossSize := autoAcrossSize + l.Padding.Start + l.Padding.End
-		if fixedWidth, ok := constraints.FixedWidth(); ok {
-			acrossSize = fixedWidth
-		}
-		if fixedHeight, ok := constraints.FixedHeight(); ok {
-			alongSize = fixedHeight
-		}
-		return image.Pt(acrossSize, alongSize)
+		size.X = autoAcrossSize + l.Padding.Start + l.Padding.End
+		size.Y = autoAlongSize + l.Padding.Top + l.Padding.Bottom
 	}
-	return image.Point{}
+	return constraints.Constrain(size)
 }
 
 type LinearLayoutItem struct {
@@ -379,7 +355,7 @@ func (c *cachedLinearLayouts) itemBounds(context *Context, linearLayout *LinearL
 
 	v := c.get(context, linearLayout, bounds)
 	ps := v.itemAlongPositionAndSizes[index]
-	return positionAndSizeToBounds(linearLayout, bounds, ps)
+	return positionAndSizeToBounds(context, linearLayout, bounds, ps)
 }
 
 func (c *cachedLinearLayouts) widgetBounds(context *Context, linearLayout *LinearLayout, bounds image.Rectangle, widget Widget) (image.Rectangle, bool) {
@@ -392,27 +368,37 @@ func (c *cachedLinearLayouts) widgetBounds(context *Context, linearLayout *Linea
 		return image.Rectangle{}, false
 	}
 	ps := v.itemAlongPositionAndSizes[idx]
-	return positionAndSizeToBounds(linearLayout, bounds, ps), true
+	return positionAndSizeToBounds(context, linearLayout, bounds, ps), true
 }
 
-func positionAndSizeToBounds(linearLayout *LinearLayout, bounds image.Rectangle, ps positionAndSize) image.Rectangle {
+func positionAndSizeToBounds(context *Context, linearLayout *LinearLayout, bounds image.Rectangle, ps positionAndSize) image.Rectangle {
 	pt := bounds.Min.Add(image.Pt(linearLayout.Padding.Start, linearLayout.Padding.Top))
 	acrossSize := linearLayout.acrossSize(bounds)
+	var r image.Rectangle
 	switch linearLayout.Direction {
 	case LayoutDirectionHorizontal:
 		pt.X += ps.position
-		return image.Rectangle{
+		r = image.Rectangle{
 			Min: pt,
 			Max: pt.Add(image.Pt(ps.size, acrossSize)),
 		}
 	case LayoutDirectionVertical:
 		pt.Y += ps.position
-		return image.Rectangle{
+		r = image.Rectangle{
 			Min: pt,
 			Max: pt.Add(image.Pt(acrossSize, ps.size)),
 		}
 	}
-	return image.Rectangle{}
+	// Start and End are the right and the left in a right-to-left layout.
+	if context.IsRightToLeft() {
+		r = MirrorRectangle(r, bounds)
+	}
+	return r
+}
+
+// MirrorRectangle returns the rectangle mirrored horizontally in bounds.
+func MirrorRectangle(rect image.Rectangle, bounds image.Rectangle) image.Rectangle {
+	return image.Rect(bounds.Min.X+bounds.Max.X-rect.Max.X, rect.Min.Y, bounds.Min.X+bounds.Max.X-rect.Min.X, rect.Max.Y)
 }
 
 func (c *cachedLinearLayouts) get(context *Context, linearLayout *LinearLayout, bounds image.Rectangle) *cachedLinearLayoutValues {
@@ -50,3 +50,54 @@ func TestLinearLayoutMeasure(t *testing.T) {
 		}
 	}
 }
+
+// wrappingWidget is a widget like a wrapped text, whose area is kept when the width is limited.
+type wrappingWidget struct {
+	guigui.DefaultWidget
+
+	size image.Point
+}
+
+func (w *wrappingWidget) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	s := w.size
+	if maxW, ok := constraints.MaxWidth(); ok && s.X > maxW {
+		s = image.Pt(maxW, (w.size.X*w.size.Y+maxW-1)/maxW)
+	}
+	return s
+}
+
+func TestLinearLayoutMeasureMaxWidth(t *testing.T) {
+	l := &guigui.LinearLayout{
+		Direction: guigui.LayoutDirectionVertical,
+		Items: []guigui.LinearLayoutItem{
+			{
+				Widget: &wrappingWidget{
+					size: image.Pt(800, 100),
+				},
+			},
+		},
+		Padding: guigui.Padding{
+			Start:  10,
+			Top:    20,
+			End:    10,
+			Bottom: 20,
+		},
+	}
+	var context guigui.Context
+	testCases := []struct {
+		constraints guigui.Constraints
+		want        image.Point
+	}{
+		{guigui.Constraints{}, image.Pt(820, 140)},
+		{guigui.Constraints{}.WithMaxWidth(420), image.Pt(420, 240)},
+		{guigui.Constraints{}.WithMaxWidth(1000), image.Pt(820, 140)},
+		{guigui.FixedWidthConstraints(1000), image.Pt(1000, 140)},
+		{guigui.Constraints{}.WithMaxWidth(420).WithMaxHeight(200), image.Pt(420, 200)},
+		{guigui.Constraints{}.WithMinHeight(300), image.Pt(820, 300)},
+	}
+	for _, tc := range testCases {
+		if got := l.Measure(&context, tc.constraints); got != tc.want {
+			t.Errorf("constraints: %+v, got: %v, want: %v", tc.constraints, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+)
+
+// Measure returns the size of widget under constraints, i.e. widget.Measure(context, constraints).
+//
+// The result is cached per widget and constraints.
+// The cache is invalidated when the widget or its descendant requests a redraw or a rebuild,
+// when the widget's children are changed, or when all the widgets are rebuilt.
+// Layouts should call Measure instead of calling Widget's Measure directly to avoid measuring the same widget repeatedly.
+func (c *Context) Measure(widget Widget, constraints Constraints) image.Point {
+	// A Context without an app, e.g. in tests, doesn't cache anything.
+	if c.app == nil {
+		return widget.Measure(c, constraints)
+	}
+
+	widgetState := widget.widgetState()
+	if widgetState.measureCacheGeneration != c.app.measureCacheGeneration {
+		clear(widgetState.measureCache)
+		widgetState.measureCacheGeneration = c.app.measureCacheGeneration
+	}
+	if s, ok := widgetState.measureCache[constraints]; ok {
+		return s
+	}
+	s := widget.Measure(c, constraints)
+	if widgetState.measureCache == nil {
+		widgetState.measureCache = map[Constraints]image.Point{}
+	}
+	widgetState.measureCache[constraints] = s
+	return s
+}
+
+// invalidateMeasureCache invalidates the measure caches of widgetState and its ancestors,
+// as a widget's size might depend on its descendants' sizes.
+func invalidateMeasureCache(widgetState *widgetState) {
+	clear(widgetState.measureCache)
+	for p := widgetState.parent; p != nil; p = p.widgetState().parent {
+		clear(p.widgetState().measureCache)
+	}
+}
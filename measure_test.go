@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type measureTestWidget struct {
+	guigui.DefaultWidget
+
+	child        *measureTestWidget
+	size         image.Point
+	measureCount int
+}
+
+func (m *measureTestWidget) AddChildren(context *guigui.Context, adder *guigui.ChildAdder) {
+	if m.child != nil {
+		adder.AddChild(m.child)
+	}
+}
+
+func (m *measureTestWidget) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
+	m.measureCount++
+	if w, ok := constraints.FixedWidth(); ok {
+		return image.Pt(w, m.size.Y)
+	}
+	return m.size
+}
+
+func TestMeasureCache(t *testing.T) {
+	child := measureTestWidget{size: image.Pt(10, 20)}
+	parent := measureTestWidget{child: &child}
+	a := guigui.NewTestApp(&parent, 100, 100)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	context := a.Context()
+
+	for range 2 {
+		if got, want := context.Measure(&child, guigui.Constraints{}), image.Pt(10, 20); got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+		if got, want := context.Measure(&child, guigui.FixedWidthConstraints(30)), image.Pt(30, 20); got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}
+	if got, want := child.measureCount, 2; got != want {
+		t.Errorf("measure count: got: %d, want: %d", got, want)
+	}
+
+	// A redraw request invalidates the caches of the widget and its ancestors.
+	context.Measure(&parent, guigui.Constraints{})
+	child.size = image.Pt(40, 50)
+	guigui.RequestRedraw(&child)
+	if got, want := context.Measure(&child, guigui.Constraints{}), image.Pt(40, 50); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	context.Measure(&parent, guigui.Constraints{})
+	if got, want := parent.measureCount, 2; got != want {
+		t.Errorf("parent's measure count: got: %d, want: %d", got, want)
+	}
+
+	// A full build invalidates all the caches.
+	a.RequestFullBuild()
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	context.Measure(&child, guigui.Constraints{})
+	if got, want := child.measureCount, 4; got != want {
+		t.Errorf("measure count: got: %d, want: %d", got, want)
+	}
+}
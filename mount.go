@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"slices"
+)
+
+const (
+	mountedEvent   = "__mounted"
+	unmountedEvent = "__unmounted"
+)
+
+// RegisterMountedEventHandler registers a handler called when the widget enters the widget tree.
+//
+// The handler is called once at the first tick when the widget is in the tree, after Update of the widget is called.
+// This is useful to start subscriptions, goroutines or timers.
+func RegisterMountedEventHandler(widget Widget, f func()) {
+	RegisterEventHandler(widget, mountedEvent, f)
+}
+
+// RegisterUnmountedEventHandler registers a handler called when the widget leaves the widget tree.
+//
+// The handler is called once at the first tick when the widget is no longer in the tree.
+// The handler registered last is kept even after the widget is removed, so that the handler can be called.
+// This is useful to stop what is started by a mounted event handler.
+func RegisterUnmountedEventHandler(widget Widget, f func()) {
+	RegisterEventHandler(widget, unmountedEvent, f)
+}
+
+// IsMounted reports whether the widget is in the widget tree.
+func (c *Context) IsMounted(widget Widget) bool {
+	return widget.widgetState().mounted
+}
+
+// recordMountedWidget records widget visited at the current build to call its mounted event handler later.
+func (a *app) recordMountedWidget(widget Widget) {
+	widgetState := widget.widgetState()
+	if widgetState.mounted || widgetState.mountPending {
+		return
+	}
+	widgetState.mountPending = true
+	a.widgetsToMount = append(a.widgetsToMount, widget)
+}
+
+// recordRemovedChildren records the widgets in prevChildren but not in children, which might be unmounted.
+func (a *app) recordRemovedChildren(prevChildren, children []Widget) {
+	for _, child := range prevChildren {
+		if slices.Contains(children, child) {
+			continue
+		}
+		a.widgetsToUnmount = append(a.widgetsToUnmount, child)
+	}
+}
+
+// updateMountedWidgets updates the mounted states of the widgets recorded at the builds,
+// and calls the mounted and unmounted event handlers.
+// updateMountedWidgets reports whether any handler is called.
+//
+// Unmounted widgets are processed first, from descendants to ancestors.
+// Then, mounted widgets are processed from ancestors to descendants.
+func (a *app) updateMountedWidgets() bool {
+	var called bool
+
+	for _, widget := range a.widgetsToUnmount {
+		if a.unmountWidget(widget) {
+			called = true
+		}
+	}
+	a.widgetsToUnmount = slices.Delete(a.widgetsToUnmount, 0, len(a.widgetsToUnmount))
+
+	for _, widget := range a.widgetsToMount {
+		widgetState := widget.widgetState()
+		widgetState.mountPending = false
+		// The widget might be removed at the second build.
+		if !widgetState.isInTree(a.buildCount) {
+			continue
+		}
+		widgetState.mounted = true
+		if _, ok := dispatchEventHandler(widgetState, mountedEvent); ok {
+			called = true
+		}
+	}
+	a.widgetsToMount = slices.Delete(a.widgetsToMount, 0, len(a.widgetsToMount))
+
+	return called
+}
+
+// unmountWidget unmounts widget and its descendants that are no longer in the tree, and reports whether any handler is called.
+func (a *app) unmountWidget(widget Widget) bool {
+	widgetState := widget.widgetState()
+	// The widget might be moved to another parent.
+	if widgetState.isInTree(a.buildCount) {
+		return false
+	}
+
+	var called bool
+	for _, child := range widgetState.children {
+		if a.unmountWidget(child) {
+			called = true
+		}
+	}
+
+	if !widgetState.mounted {
+		return called
+	}
+	widgetState.mounted = false
+	if widgetState.offscreen != nil {
+		widgetState.offscreen.Deallocate()
+		widgetState.offscreen = nil
+	}
+	if a.pointerCapturingWidget == widget {
+		a.pointerCapturingWidget = nil
+	}
+	if _, ok := dispatchEventHandler(widgetState, unmountedEvent); ok {
+		called = true
+	}
+	// The widget is not traversed by updateEventDispatchStates, so reset the state here.
+	widgetState.eventDispatched = false
+	delete(widgetState.eventHandlers, unmountedEvent)
+	return called
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestMountedAndUnmountedEventHandlers(t *testing.T) {
+	var logs []string
+	var grandchild, child, sibling, root buildTestWidget
+	child.children = []*buildTestWidget{&grandchild}
+	root.children = []*buildTestWidget{&child, &sibling}
+	for _, w := range []struct {
+		widget *buildTestWidget
+		name   string
+	}{
+		{&root, "root"},
+		{&child, "child"},
+		{&grandchild, "grandchild"},
+		{&sibling, "sibling"},
+	} {
+		w.widget.onUpdate = func() {
+			guigui.RegisterMountedEventHandler(w.widget, func() {
+				logs = append(logs, fmt.Sprintf("mounted %s", w.name))
+			})
+			guigui.RegisterUnmountedEventHandler(w.widget, func() {
+				logs = append(logs, fmt.Sprintf("unmounted %s", w.name))
+			})
+		}
+	}
+
+	a := guigui.NewTestApp(&root, 100, 100)
+	context := a.Context()
+	build := func() {
+		t.Helper()
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	build()
+	if got, want := logs, []string{"mounted root", "mounted child", "mounted grandchild", "mounted sibling"}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if !context.IsMounted(&grandchild) {
+		t.Errorf("IsMounted(&grandchild): got: false, want: true")
+	}
+
+	// Nothing happens while the tree is not changed.
+	logs = logs[:0]
+	build()
+	if len(logs) != 0 {
+		t.Errorf("got: %v, want: empty", logs)
+	}
+
+	// Descendants are unmounted before their ancestors.
+	root.children = []*buildTestWidget{&sibling}
+	build()
+	if got, want := logs, []string{"unmounted grandchild", "unmounted child"}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if context.IsMounted(&child) {
+		t.Errorf("IsMounted(&child): got: true, want: false")
+	}
+
+	// A widget can be mounted again.
+	logs = logs[:0]
+	root.children = []*buildTestWidget{&child, &sibling}
+	build()
+	if got, want := logs, []string{"mounted child", "mounted grandchild"}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// A widget moved to another parent is not unmounted.
+	logs = logs[:0]
+	child.children = nil
+	sibling.children = []*buildTestWidget{&grandchild}
+	build()
+	if len(logs) != 0 {
+		t.Errorf("got: %v, want: empty", logs)
+	}
+	if !context.IsMounted(&grandchild) {
+		t.Errorf("IsMounted(&grandchild): got: false, want: true")
+	}
+}
+
+func TestMountedWidgetsInRebuildBoundary(t *testing.T) {
+	var mounted, unmounted int
+	var leaf buildTestWidget
+	leaf.onUpdate = func() {
+		guigui.RegisterMountedEventHandler(&leaf, func() { mounted++ })
+		guigui.RegisterUnmountedEventHandler(&leaf, func() { unmounted++ })
+	}
+	boundary := buildTestWidget{children: []*buildTestWidget{&leaf}}
+	root := buildTestWidget{children: []*buildTestWidget{&boundary}}
+
+	a := guigui.NewTestApp(&root, 100, 100)
+	a.Context().SetRebuildBoundary(&boundary, true)
+	for range 3 {
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if mounted != 1 || unmounted != 0 {
+		t.Errorf("mounted: %d, unmounted: %d, want: 1, 0", mounted, unmounted)
+	}
+	if leaf.updateCount != 1 {
+		t.Errorf("the leaf's update count: got: %d, want: 1", leaf.updateCount)
+	}
+
+	root.children = nil
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	if mounted != 1 || unmounted != 1 {
+		t.Errorf("mounted: %d, unmounted: %d, want: 1, 1", mounted, unmounted)
+	}
+}
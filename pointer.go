@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SetPointerCapture makes widget receive pointing inputs exclusively, even when the cursor is outside of widget.
+// While widget captures the pointer, HandlePointingInput of the other widgets is not called.
+//
+// This is useful to keep handling a drag started on widget.
+// The capture is released automatically when all the mouse buttons and touches are released,
+// or when widget is removed from the tree, hidden or disabled.
+func (c *Context) SetPointerCapture(widget Widget) {
+	c.app.pointerCapturingWidget = widget
+}
+
+// ReleasePointerCapture releases the pointer capture if widget captures the pointer.
+func (c *Context) ReleasePointerCapture(widget Widget) {
+	if c.app.pointerCapturingWidget != widget {
+		return
+	}
+	c.app.pointerCapturingWidget = nil
+}
+
+// HasPointerCapture reports whether widget captures the pointer.
+func (c *Context) HasPointerCapture(widget Widget) bool {
+	return c.app.pointerCapturingWidget == widget
+}
+
+// handlePointerCapturingWidget calls HandlePointingInput of the widget capturing the pointer.
+// handlePointerCapturingWidget reports false if no widget captures the pointer.
+func (a *app) handlePointerCapturingWidget() (HandleInputResult, bool) {
+	widget := a.pointerCapturingWidget
+	if widget == nil {
+		return HandleInputResult{}, false
+	}
+	ws := widget.widgetState()
+	if !ws.isInTree(a.buildCount) || !ws.isVisible() || !ws.isEnabled() {
+		a.pointerCapturingWidget = nil
+		return HandleInputResult{}, false
+	}
+	r := widget.HandlePointingInput(&a.context)
+	if !r.shouldRaise() {
+		// The other widgets must not handle the input.
+		r = AbortHandlingInputByWidget(widget)
+	}
+	return r, true
+}
+
+func (a *app) releasePointerCaptureIfNeeded() {
+	if a.pointerCapturingWidget == nil {
+		return
+	}
+	if len(a.pointer.touches) > 0 {
+		return
+	}
+	for _, b := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle} {
+		if ebiten.IsMouseButtonPressed(b) {
+			return
+		}
+	}
+	a.pointerCapturingWidget = nil
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"sync"
+)
+
+type postedFuncs struct {
+	m     sync.Mutex
+	funcs []func()
+	woken bool
+}
+
+var thePostedFuncs postedFuncs
+
+// Post enqueues f to be called on the UI goroutine at the start of the next update.
+// The widget tree is rebuilt after f is called, so f can update models that widgets read.
+//
+// Post is safe to call from any goroutine, e.g., to hand over a result of a background task.
+// The functions are called in the order they are posted.
+func Post(f func()) {
+	thePostedFuncs.post(f)
+}
+
+// Wake makes the app rebuild the widget tree at the next update even when nothing is changed on the UI goroutine.
+//
+// Wake is safe to call from any goroutine, e.g., after a model guarded by a mutex is updated.
+func Wake() {
+	thePostedFuncs.wake()
+}
+
+func (p *postedFuncs) post(f func()) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.funcs = append(p.funcs, f)
+}
+
+func (p *postedFuncs) wake() {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.woken = true
+}
+
+// run calls the posted functions and reports whether the widget tree needs to be rebuilt.
+// Functions posted during run are called at the next run.
+func (p *postedFuncs) run() bool {
+	p.m.Lock()
+	funcs := p.funcs
+	p.funcs = nil
+	woken := p.woken
+	p.woken = false
+	p.m.Unlock()
+
+	for _, f := range funcs {
+		f()
+	}
+	return woken || len(funcs) > 0
+}
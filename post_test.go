@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestPost(t *testing.T) {
+	if guigui.RunPostedFuncs() {
+		t.Errorf("run without posted functions: got: true, want: false")
+	}
+
+	var got []int
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			guigui.Post(func() {
+				got = append(got, i)
+			})
+		}()
+	}
+	wg.Wait()
+	if !guigui.RunPostedFuncs() {
+		t.Errorf("run with posted functions: got: false, want: true")
+	}
+	if len(got) != 10 {
+		t.Errorf("the number of called functions: got: %d, want: 10", len(got))
+	}
+
+	guigui.Wake()
+	if !guigui.RunPostedFuncs() {
+		t.Errorf("run after waking: got: false, want: true")
+	}
+	if guigui.RunPostedFuncs() {
+		t.Errorf("run again: got: true, want: false")
+	}
+}
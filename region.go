@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+	"slices"
+)
+
+// maxDirtyRegions is the maximum number of rectangles in dirtyRegions.
+// Too many rectangles make drawing slow as the widget tree is traversed for each rectangle.
+const maxDirtyRegions = 16
+
+// dirtyRegions is a set of rectangles to redraw.
+//
+// Overlapping or nearby rectangles are merged, so that distant small regions like a text cursor and a spinner
+// are redrawn separately without redrawing the region between them.
+type dirtyRegions struct {
+	rects []image.Rectangle
+}
+
+func area(r image.Rectangle) int {
+	return r.Dx() * r.Dy()
+}
+
+// mergeCost returns the area redrawn unnecessarily when r0 and r1 are merged.
+func mergeCost(r0, r1 image.Rectangle) int {
+	return area(r0.Union(r1)) - area(r0) - area(r1) + area(r0.Intersect(r1))
+}
+
+// shouldMerge reports whether r0 and r1 should be merged.
+// Overlapping rectangles are always merged not to draw the same region twice.
+// Nearby rectangles are merged when the merged rectangle is not much larger than them.
+func shouldMerge(r0, r1 image.Rectangle) bool {
+	if r0.Overlaps(r1) {
+		return true
+	}
+	return mergeCost(r0, r1) <= (area(r0)+area(r1))/2
+}
+
+func (d *dirtyRegions) add(region image.Rectangle) {
+	if region.Empty() {
+		return
+	}
+	for {
+		idx := slices.IndexFunc(d.rects, func(r image.Rectangle) bool {
+			return shouldMerge(r, region)
+		})
+		if idx < 0 {
+			break
+		}
+		region = region.Union(d.rects[idx])
+		d.rects = slices.Delete(d.rects, idx, idx+1)
+	}
+	d.rects = append(d.rects, region)
+
+	for len(d.rects) > maxDirtyRegions {
+		d.mergeCheapestPair()
+	}
+}
+
+// mergeCheapestPair merges the pair of rectangles whose mergeCost is the smallest.
+func (d *dirtyRegions) mergeCheapestPair() {
+	var i0, i1 int
+	minCost := -1
+	for i := range d.rects {
+		for j := i + 1; j < len(d.rects); j++ {
+			if c := mergeCost(d.rects[i], d.rects[j]); minCost < 0 || c < minCost {
+				i0, i1 = i, j
+				minCost = c
+			}
+		}
+	}
+	r := d.rects[i0].Union(d.rects[i1])
+	d.rects = slices.Delete(d.rects, i1, i1+1)
+	d.rects = slices.Delete(d.rects, i0, i0+1)
+	// Add the merged rectangle again as it might overlap with another rectangle.
+	d.add(r)
+}
+
+func (d *dirtyRegions) isEmpty() bool {
+	return len(d.rects) == 0
+}
+
+func (d *dirtyRegions) reset() {
+	d.rects = slices.Delete(d.rects, 0, len(d.rects))
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestDirtyRegions(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rects []image.Rectangle
+		want  []image.Rectangle
+	}{
+		{
+			name:  "distant",
+			rects: []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(1000, 1000, 1010, 1010)},
+			want:  []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(1000, 1000, 1010, 1010)},
+		},
+		{
+			name:  "overlapping",
+			rects: []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(5, 5, 15, 15)},
+			want:  []image.Rectangle{image.Rect(0, 0, 15, 15)},
+		},
+		{
+			name:  "adjacent",
+			rects: []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(10, 0, 20, 10)},
+			want:  []image.Rectangle{image.Rect(0, 0, 20, 10)},
+		},
+		{
+			name:  "chained",
+			rects: []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(20, 0, 30, 10), image.Rect(5, 0, 25, 10)},
+			want:  []image.Rectangle{image.Rect(0, 0, 30, 10)},
+		},
+		{
+			name:  "empty",
+			rects: []image.Rectangle{{}, image.Rect(0, 0, 10, 10)},
+			want:  []image.Rectangle{image.Rect(0, 0, 10, 10)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rects := guigui.AddDirtyRegions(tc.rects)
+			if len(rects) != len(tc.want) {
+				t.Fatalf("got: %v, want: %v", rects, tc.want)
+			}
+			for i := range rects {
+				if !rects[i].Eq(tc.want[i]) {
+					t.Errorf("got: %v, want: %v", rects, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDirtyRegionsMax(t *testing.T) {
+	var input []image.Rectangle
+	for i := range 100 {
+		x := (i % 10) * 100
+		y := (i / 10) * 100
+		input = append(input, image.Rect(x, y, x+10, y+10))
+	}
+	rects := guigui.AddDirtyRegions(input)
+	if len(rects) > guigui.MaxDirtyRegions {
+		t.Errorf("len(rects): got: %d, want: <= %d", len(rects), guigui.MaxDirtyRegions)
+	}
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			if rects[i].Overlaps(rects[j]) {
+				t.Errorf("%v and %v overlap", rects[i], rects[j])
+			}
+		}
+	}
+	// All the regions must be covered.
+	for i := range 100 {
+		x := (i % 10) * 100
+		y := (i / 10) * 100
+		r := image.Rect(x, y, x+10, y+10)
+		var covered bool
+		for _, dr := range rects {
+			if r.In(dr) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			t.Errorf("%v is not covered", r)
+		}
+	}
+}
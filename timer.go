@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TimerID identifies a timer scheduled by Context.AfterFunc or Context.EveryFunc.
+type TimerID int64
+
+type timer struct {
+	id          TimerID
+	widgetState *widgetState
+
+	// debounce reports whether the timer is added by Context.Debounce.
+	debounce bool
+
+	// debounceKey is the key given to Context.Debounce.
+	debounceKey any
+
+	f         func()
+	interval  time.Duration
+	remaining time.Duration
+	repeat    bool
+	stopped   bool
+}
+
+type timers struct {
+	timers []*timer
+	nextID TimerID
+
+	lastTickTime time.Time
+}
+
+// tps returns the current TPS.
+// If TPS is not fixed, i.e. ebiten.SyncWithFPS, tps returns ebiten.DefaultTPS as an approximation.
+func tps() int {
+	if tps := ebiten.TPS(); tps > 0 {
+		return tps
+	}
+	return ebiten.DefaultTPS
+}
+
+// durationToTicks returns the number of ticks for d. The result is at least 1.
+func durationToTicks(d time.Duration) int {
+	return max(int(math.Ceil(d.Seconds()*float64(tps()))), 1)
+}
+
+// elapsedDuration returns the duration to advance the timers at this tick.
+//
+// If TPS is fixed, the duration of one tick is returned, rounded up so that a duration of n ticks fires at the n-th tick.
+// Otherwise, i.e. ebiten.SyncWithFPS, the wall time since the previous tick is returned.
+func (t *timers) elapsedDuration() time.Duration {
+	now := time.Now()
+	last := t.lastTickTime
+	t.lastTickTime = now
+	if tps := ebiten.TPS(); tps > 0 {
+		return (time.Second + time.Duration(tps) - 1) / time.Duration(tps)
+	}
+	if last.IsZero() {
+		return 0
+	}
+	return now.Sub(last)
+}
+
+func (t *timers) add(widgetState *widgetState, d time.Duration, f func(), repeat bool) *timer {
+	t.nextID++
+	tm := &timer{
+		id:          t.nextID,
+		widgetState: widgetState,
+		f:           f,
+		interval:    d,
+		remaining:   d,
+		repeat:      repeat,
+	}
+	t.timers = append(t.timers, tm)
+	return tm
+}
+
+func (t *timers) stop(id TimerID) {
+	for _, tm := range t.timers {
+		if tm.id == id {
+			tm.stopped = true
+			return
+		}
+	}
+}
+
+func (t *timers) debounce(widgetState *widgetState, key any, d time.Duration, f func()) {
+	for _, tm := range t.timers {
+		if tm.stopped || !tm.debounce || tm.widgetState != widgetState || tm.debounceKey != key {
+			continue
+		}
+		tm.f = f
+		tm.interval = d
+		tm.remaining = d
+		return
+	}
+	tm := t.add(widgetState, d, f, false)
+	tm.debounce = true
+	tm.debounceKey = key
+}
+
+// tick advances the timers by one tick and calls the functions of the fired timers.
+// The timers of widgets not in the tree are cancelled.
+// tick reports whether any function is called.
+//
+// Timers added by the functions start at the next tick.
+func (t *timers) tick(buildCount int64) bool {
+	elapsed := t.elapsedDuration()
+	var fired bool
+	n := len(t.timers)
+	for i := 0; i < n; i++ {
+		tm := t.timers[i]
+		if tm.stopped {
+			continue
+		}
+		if !tm.widgetState.isInTree(buildCount) {
+			tm.stopped = true
+			continue
+		}
+		tm.remaining -= elapsed
+		if tm.remaining > 0 {
+			continue
+		}
+		if tm.repeat {
+			tm.remaining = tm.interval
+		} else {
+			tm.stopped = true
+		}
+		tm.f()
+		fired = true
+	}
+	t.timers = slices.DeleteFunc(t.timers, func(tm *timer) bool {
+		return tm.stopped
+	})
+	return fired
+}
+
+// AfterFunc calls f once after d on the UI goroutine, and returns the timer's ID.
+//
+// The timer is cancelled when widget is removed from the widget tree.
+// The widget tree is rebuilt after f is called.
+func (c *Context) AfterFunc(widget Widget, d time.Duration, f func()) TimerID {
+	return c.app.timers.add(widget.widgetState(), d, f, false).id
+}
+
+// EveryFunc calls f every d on the UI goroutine until the timer is stopped, and returns the timer's ID.
+//
+// The timer is cancelled when widget is removed from the widget tree.
+// The widget tree is rebuilt after f is called.
+func (c *Context) EveryFunc(widget Widget, d time.Duration, f func()) TimerID {
+	return c.app.timers.add(widget.widgetState(), d, f, true).id
+}
+
+// StopTimer stops the timer of id. StopTimer does nothing if the timer is already stopped or fired.
+func (c *Context) StopTimer(id TimerID) {
+	c.app.timers.stop(id)
+}
+
+// Debounce calls f after d has passed without another call of Debounce with the same widget and key.
+// Only the last f is called. key must be comparable.
+//
+// This is useful to handle a text input's value after a user stops typing:
+//
+//	textInput.SetOnValueChanged(func(text string, committed bool) {
+//		context.Debounce(&textInput, "search", 300*time.Millisecond, func() {
+//			search(text)
+//		})
+//	})
+//
+// The timer is cancelled when widget is removed from the widget tree.
+// The widget tree is rebuilt after f is called.
+func (c *Context) Debounce(widget Widget, key any, d time.Duration, f func()) {
+	c.app.timers.debounce(widget.widgetState(), key, d, f)
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestTimers(t *testing.T) {
+	var root buildTestWidget
+	a := guigui.NewTestApp(&root, 100, 100)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	context := a.Context()
+	tickDuration := time.Second / time.Duration(ebiten.TPS())
+
+	var once, every int
+	context.AfterFunc(&root, 2*tickDuration, func() { once++ })
+	id := context.EveryFunc(&root, 3*tickDuration, func() { every++ })
+
+	var fired []bool
+	for range 6 {
+		fired = append(fired, a.TickTimers())
+	}
+	if once != 1 {
+		t.Errorf("one-shot timer: got: %d calls, want: 1", once)
+	}
+	if every != 2 {
+		t.Errorf("repeating timer: got: %d calls, want: 2", every)
+	}
+	if want := []bool{false, true, true, false, false, true}; !slices.Equal(fired, want) {
+		t.Errorf("fired: got: %v, want: %v", fired, want)
+	}
+
+	context.StopTimer(id)
+	for range 6 {
+		if a.TickTimers() {
+			t.Errorf("tick after stop: got: true, want: false")
+		}
+	}
+	if every != 2 {
+		t.Errorf("stopped timer: got: %d calls, want: 2", every)
+	}
+}
+
+func TestTimersRemovedWidget(t *testing.T) {
+	var child buildTestWidget
+	root := buildTestWidget{children: []*buildTestWidget{&child}}
+	a := guigui.NewTestApp(&root, 100, 100)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	var called bool
+	a.Context().AfterFunc(&child, 0, func() { called = true })
+
+	root.children = nil
+	a.RequestFullBuild()
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	if a.TickTimers() {
+		t.Errorf("tick: got: true, want: false")
+	}
+	if called {
+		t.Errorf("the timer of a removed widget is fired")
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	var root buildTestWidget
+	a := guigui.NewTestApp(&root, 100, 100)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	context := a.Context()
+	tickDuration := time.Second / time.Duration(ebiten.TPS())
+
+	var got []int
+	for i := range 3 {
+		context.Debounce(&root, "key", 2*tickDuration, func() { got = append(got, i) })
+		a.TickTimers()
+	}
+	a.TickTimers()
+	a.TickTimers()
+	if len(got) != 1 || got[0] != 2 {
+		t.Errorf("debounced calls: got: %v, want: [2]", got)
+	}
+}
+
+func TestDebounceNilKey(t *testing.T) {
+	var root buildTestWidget
+	a := guigui.NewTestApp(&root, 100, 100)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	context := a.Context()
+	tickDuration := time.Second / time.Duration(ebiten.TPS())
+
+	var every, debounced int
+	context.EveryFunc(&root, tickDuration, func() { every++ })
+	context.Debounce(&root, nil, 2*tickDuration, func() { debounced++ })
+	for range 4 {
+		a.TickTimers()
+	}
+	if every != 4 {
+		t.Errorf("repeating timer: got: %d calls, want: 4", every)
+	}
+	if debounced != 1 {
+		t.Errorf("debounced timer: got: %d calls, want: 1", debounced)
+	}
+}
+
+func TestTimersSyncWithFPS(t *testing.T) {
+	tps := ebiten.TPS()
+	ebiten.SetTPS(ebiten.SyncWithFPS)
+	defer ebiten.SetTPS(tps)
+
+	var root buildTestWidget
+	a := guigui.NewTestApp(&root, 100, 100)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	var called bool
+	a.Context().AfterFunc(&root, time.Hour, func() { called = true })
+	for range 6 {
+		a.TickTimers()
+	}
+	if called {
+		t.Errorf("a timer is fired before the duration passes")
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Touch is a touch on the screen.
+type Touch struct {
+	ID ebiten.TouchID
+
+	// Position is the current position of the touch.
+	Position image.Point
+
+	// StartPosition is the position where the touch started.
+	StartPosition image.Point
+
+	// JustPressed reports whether the touch started at this tick.
+	JustPressed bool
+}
+
+// pointerState unifies the mouse cursor and touches into a pointer.
+//
+// The first touch started when no touch is active is the primary touch, which works as the pointer like the mouse cursor.
+// The other touches are available via Context.AppendTouches for multi-touch gestures.
+type pointerState struct {
+	touches []Touch
+
+	primaryTouchID           ebiten.TouchID
+	hasPrimaryTouch          bool
+	primaryTouchJustPressed  bool
+	primaryTouchJustReleased bool
+
+	position           image.Point
+	lastCursorPosition image.Point
+	touch              bool
+
+	tmpTouchIDs []ebiten.TouchID
+}
+
+func (p *pointerState) updateWithEbiten() {
+	p.tmpTouchIDs = ebiten.AppendTouchIDs(p.tmpTouchIDs[:0])
+	p.update(image.Pt(ebiten.CursorPosition()), p.tmpTouchIDs, func(id ebiten.TouchID) image.Point {
+		return image.Pt(ebiten.TouchPosition(id))
+	})
+}
+
+func (p *pointerState) update(cursorPosition image.Point, touchIDs []ebiten.TouchID, touchPosition func(id ebiten.TouchID) image.Point) {
+	p.primaryTouchJustPressed = false
+	p.primaryTouchJustReleased = false
+
+	if p.lastCursorPosition != cursorPosition {
+		p.lastCursorPosition = cursorPosition
+		p.position = cursorPosition
+		p.touch = false
+	}
+
+	p.touches = slices.DeleteFunc(p.touches, func(t Touch) bool {
+		return !slices.Contains(touchIDs, t.ID)
+	})
+	for i := range p.touches {
+		p.touches[i].Position = touchPosition(p.touches[i].ID)
+		p.touches[i].JustPressed = false
+	}
+	for _, id := range touchIDs {
+		if slices.ContainsFunc(p.touches, func(t Touch) bool { return t.ID == id }) {
+			continue
+		}
+		pos := touchPosition(id)
+		p.touches = append(p.touches, Touch{
+			ID:            id,
+			Position:      pos,
+			StartPosition: pos,
+			JustPressed:   true,
+		})
+	}
+
+	if p.hasPrimaryTouch && !slices.Contains(touchIDs, p.primaryTouchID) {
+		p.hasPrimaryTouch = false
+		p.primaryTouchJustReleased = true
+	}
+	if !p.hasPrimaryTouch {
+		for _, t := range p.touches {
+			if !t.JustPressed {
+				continue
+			}
+			p.primaryTouchID = t.ID
+			p.hasPrimaryTouch = true
+			p.primaryTouchJustPressed = true
+			break
+		}
+	}
+	if p.hasPrimaryTouch {
+		idx := slices.IndexFunc(p.touches, func(t Touch) bool { return t.ID == p.primaryTouchID })
+		p.position = p.touches[idx].Position
+		p.touch = true
+	}
+}
+
+// PointerPosition returns the position of the pointer, i.e. the mouse cursor or the primary touch.
+//
+// The primary touch is the first touch started when no touch is active.
+// Hit testing like IsWidgetHitAtCursor uses this position.
+func (c *Context) PointerPosition() image.Point {
+	return c.app.pointer.position
+}
+
+// IsPointerPressed reports whether the left mouse button or the primary touch is pressed.
+func (c *Context) IsPointerPressed() bool {
+	return ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) || c.app.pointer.hasPrimaryTouch
+}
+
+// IsPointerJustPressed reports whether the left mouse button or the primary touch is pressed at this tick.
+func (c *Context) IsPointerJustPressed() bool {
+	return inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || c.app.pointer.primaryTouchJustPressed
+}
+
+// IsPointerJustReleased reports whether the left mouse button or the primary touch is released at this tick.
+func (c *Context) IsPointerJustReleased() bool {
+	return inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) || c.app.pointer.primaryTouchJustReleased
+}
+
+// IsPointerTouch reports whether the pointer is the primary touch rather than the mouse cursor.
+//
+// This is useful to adjust the behavior for touches, e.g. scrolling by dragging the content.
+func (c *Context) IsPointerTouch() bool {
+	return c.app.pointer.touch
+}
+
+// AppendTouches appends the active touches to touches in the order they started, and returns the result.
+func (c *Context) AppendTouches(touches []Touch) []Touch {
+	return append(touches, c.app.pointer.touches...)
+}
+
+// IsWidgetHitAt reports whether widget is hit at point, e.g. at a touch's position.
+// See also IsWidgetHitAtCursor.
+func (c *Context) IsWidgetHitAt(widget Widget, point image.Point) bool {
+	return c.app.isWidgetHitAt(widget, point)
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestPointerStatePrimaryTouch(t *testing.T) {
+	positions := map[ebiten.TouchID]image.Point{}
+	touchPosition := func(id ebiten.TouchID) image.Point {
+		return positions[id]
+	}
+
+	var p guigui.PointerState
+	p.Update(image.Pt(1, 2), nil, touchPosition)
+	if got, want := p.Position(), image.Pt(1, 2); got != want {
+		t.Errorf("position: got: %v, want: %v", got, want)
+	}
+	if p.IsTouch() {
+		t.Errorf("touch: got: true, want: false")
+	}
+
+	// The first touch becomes the primary touch.
+	positions[1] = image.Pt(10, 10)
+	p.Update(image.Pt(1, 2), []ebiten.TouchID{1}, touchPosition)
+	if id, ok := p.PrimaryTouchID(); !p.IsPrimaryTouchJustPressed() || !ok || id != 1 {
+		t.Errorf("primary touch: got: %v, %v, %d, want: true, true, 1", p.IsPrimaryTouchJustPressed(), ok, id)
+	}
+	if got, want := p.Position(), image.Pt(10, 10); got != want {
+		t.Errorf("position: got: %v, want: %v", got, want)
+	}
+	if !p.IsTouch() {
+		t.Errorf("touch: got: false, want: true")
+	}
+
+	// The second touch doesn't move the pointer.
+	positions[1] = image.Pt(12, 10)
+	positions[2] = image.Pt(50, 50)
+	p.Update(image.Pt(1, 2), []ebiten.TouchID{1, 2}, touchPosition)
+	if p.IsPrimaryTouchJustPressed() {
+		t.Errorf("primaryTouchJustPressed: got: true, want: false")
+	}
+	if got, want := p.Position(), image.Pt(12, 10); got != want {
+		t.Errorf("position: got: %v, want: %v", got, want)
+	}
+	touches := p.AppendTouches(nil)
+	if got, want := len(touches), 2; got != want {
+		t.Fatalf("len(touches): got: %d, want: %d", got, want)
+	}
+	if got, want := touches[0].StartPosition, image.Pt(10, 10); got != want {
+		t.Errorf("touches[0].StartPosition: got: %v, want: %v", got, want)
+	}
+
+	// Releasing the primary touch doesn't promote the remaining touch.
+	p.Update(image.Pt(1, 2), []ebiten.TouchID{2}, touchPosition)
+	if _, ok := p.PrimaryTouchID(); !p.IsPrimaryTouchJustReleased() || ok {
+		t.Errorf("primary touch: got: %v, %v, want: true, false", p.IsPrimaryTouchJustReleased(), ok)
+	}
+	if got, want := p.Position(), image.Pt(12, 10); got != want {
+		t.Errorf("position: got: %v, want: %v", got, want)
+	}
+
+	// Moving the mouse cursor makes the cursor the pointer again.
+	p.Update(image.Pt(3, 4), nil, touchPosition)
+	if got, want := p.Position(), image.Pt(3, 4); got != want {
+		t.Errorf("position: got: %v, want: %v", got, want)
+	}
+	if p.IsTouch() {
+		t.Errorf("touch: got: true, want: false")
+	}
+}
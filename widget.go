@@ -64,26 +64,33 @@ type WidgetWithSize[T Widget] struct {
 func (w *WidgetWithSize[T]) SetMeasureFunc(f func(context *Context, constraints Constraints) image.Point) {
 	w.measure = f
 	w.fixedSizePlus1 = image.Point{}
+	// Functions are not comparable, so always invalidate the cache.
+	invalidateMeasureCache(w.widgetState())
 }
 
 func (w *WidgetWithSize[T]) SetFixedWidth(width int) {
-	w.measure = nil
-	w.fixedSizePlus1 = image.Point{X: width + 1, Y: 0}
+	w.setFixedSizePlus1(image.Point{X: width + 1, Y: 0})
 }
 
 func (w *WidgetWithSize[T]) SetFixedHeight(height int) {
-	w.measure = nil
-	w.fixedSizePlus1 = image.Point{X: 0, Y: height + 1}
+	w.setFixedSizePlus1(image.Point{X: 0, Y: height + 1})
 }
 
 func (w *WidgetWithSize[T]) SetFixedSize(size image.Point) {
-	w.measure = nil
-	w.fixedSizePlus1 = size.Add(image.Pt(1, 1))
+	w.setFixedSizePlus1(size.Add(image.Pt(1, 1)))
 }
 
 func (w *WidgetWithSize[T]) SetIntrinsicSize() {
+	w.setFixedSizePlus1(image.Point{})
+}
+
+func (w *WidgetWithSize[T]) setFixedSizePlus1(fixedSizePlus1 image.Point) {
+	if w.measure == nil && w.fixedSizePlus1 == fixedSizePlus1 {
+		return
+	}
 	w.measure = nil
-	w.fixedSizePlus1 = image.Point{}
+	w.fixedSizePlus1 = fixedSizePlus1
+	invalidateMeasureCache(w.widgetState())
 }
 
 func (w *WidgetWithSize[T]) Widget() T {
@@ -116,14 +123,12 @@ func (w *WidgetWithSize[T]) Measure(context *Context, constraints Constraints) i
 		return w.fixedSizePlus1.Sub(image.Pt(1, 1))
 	}
 	if w.fixedSizePlus1.X > 0 {
-		// TODO: Consider constraints.
-		s := w.Widget().Measure(context, FixedWidthConstraints(w.fixedSizePlus1.X-1))
+		s := context.Measure(w.Widget(), constraints.WithFixedWidth(w.fixedSizePlus1.X-1))
 		return image.Pt(w.fixedSizePlus1.X-1, s.Y)
 	}
 	if w.fixedSizePlus1.Y > 0 {
-		// TODO: Consider constraints.
-		s := w.Widget().Measure(context, FixedHeightConstraints(w.fixedSizePlus1.Y-1))
+		s := context.Measure(w.Widget(), constraints.WithFixedHeight(w.fixedSizePlus1.Y-1))
 		return image.Pt(s.X, w.fixedSizePlus1.Y-1)
 	}
-	return w.Widget().Measure(context, constraints)
+	return context.Measure(w.Widget(), constraints)
 }
@@ -85,6 +85,9 @@ type CustomDrawFunc func(dst, widgetImage *ebiten.Image, op *ebiten.DrawImageOpt
 type widgetState struct {
 	root    bool
 	builtAt int64
+	// mounted reports whether the mounted event handler is called without the unmounted one.
+	mounted      bool
+	mountPending bool
 
 	bounds image.Rectangle
 
@@ -96,7 +99,8 @@ type widgetState struct {
 	disabled        bool
 	transparency    float64
 	customDraw      CustomDrawFunc
-	eventHandlers   map[string]any
+	eventHandlers   map[string]eventHandler
+	eventListeners  map[any][]eventListener
 	tmpArgs         []reflect.Value
 	eventDispatched bool
 
@@ -111,6 +115,16 @@ type widgetState struct {
 	hasVisibleBoundsCache bool
 	visibleBoundsCache    image.Rectangle
 
+	rebuildBoundary            bool
+	rebuildRequested           bool
+	descendantRebuildRequested bool
+	lastBuildCount             int64
+	builtBounds                image.Rectangle
+	builtVisibleBounds         image.Rectangle
+
+	measureCache           map[Constraints]image.Point
+	measureCacheGeneration int64
+
 	_ noCopy
 }
 
@@ -118,6 +132,10 @@ func (w *widgetState) isInTree(now int64) bool {
 	return w.builtAt == now
 }
 
+func (w *widgetState) isRebuildRequested() bool {
+	return w.rebuildRequested || w.descendantRebuildRequested
+}
+
 func (w *widgetState) isVisible() bool {
 	if w.parent != nil {
 		if w.hidden {
@@ -169,12 +187,15 @@ func traverseWidget(widget Widget, f func(widget Widget) error) error {
 	return nil
 }
 
+// RequestRedraw requests to redraw widget at the next frame.
+// RequestRedraw also requests to rebuild widget.
 func RequestRedraw(widget Widget) {
 	requestRedraw(widget.widgetState())
 }
 
 func requestRedraw(widgetState *widgetState) {
 	widgetState.dirty = true
+	requestRebuild(widgetState)
 	if theDebugMode.showRenderingRegions {
 		_, file, line, ok := runtime.Caller(1)
 		if ok {
@@ -183,12 +204,68 @@ func requestRedraw(widgetState *widgetState) {
 	}
 }
 
+// RequestRebuild requests to call AddChildren, Update and Layout of widget and its descendants at the next build,
+// even when widget is in a clean rebuild boundary.
+//
+// Call RequestRebuild when a model that a widget in a rebuild boundary reads is changed outside the boundary.
+// See also Context.SetRebuildBoundary.
+func RequestRebuild(widget Widget) {
+	requestRebuild(widget.widgetState())
+}
+
+func requestRebuild(widgetState *widgetState) {
+	widgetState.rebuildRequested = true
+	for p := widgetState.parent; p != nil; p = p.widgetState().parent {
+		p.widgetState().descendantRebuildRequested = true
+	}
+	invalidateMeasureCache(widgetState)
+}
+
+// eventRegistration is the state to stamp event handlers and listeners when they are registered.
+//
+// A handler registered at a build is removed when the widget having the handler is rebuilt at a later build.
+// Handlers are registered not only by the widget itself but also by its ancestors,
+// so the handlers registered at the current build are kept.
+type eventRegistration struct {
+	// buildCount is the count of the current or the last build.
+	buildCount int64
+
+	// owner is the widget being built, or nil outside builds.
+	owner *widgetState
+}
+
+var theEventRegistration eventRegistration
+
+type eventHandler struct {
+	f            any
+	registeredAt int64
+}
+
+// removeStaleEventHandlers removes the handlers and the listeners registered before the build of buildCount.
+func (w *widgetState) removeStaleEventHandlers(buildCount int64) {
+	maps.DeleteFunc(w.eventHandlers, func(name string, h eventHandler) bool {
+		return h.registeredAt < buildCount
+	})
+	for e, ls := range w.eventListeners {
+		w.eventListeners[e] = slices.DeleteFunc(ls, func(l eventListener) bool {
+			return l.registeredAt < buildCount
+		})
+	}
+}
+
+// RegisterEventHandler registers handler as widget's only handler for eventName.
+// handler is called via reflection, so its signature is checked only at runtime. See also Event for typed events.
+//
+// The handler is removed when widget is rebuilt, so register handlers in Update.
 func RegisterEventHandler(widget Widget, eventName string, handler any) {
 	widgetState := widget.widgetState()
 	if widgetState.eventHandlers == nil {
-		widgetState.eventHandlers = map[string]any{}
+		widgetState.eventHandlers = map[string]eventHandler{}
+	}
+	widgetState.eventHandlers[eventName] = eventHandler{
+		f:            handler,
+		registeredAt: theEventRegistration.buildCount,
 	}
-	widgetState.eventHandlers[eventName] = handler
 }
 
 func IsEventHandlerRegistered(widget Widget, eventName string) bool {
@@ -209,7 +286,7 @@ func dispatchEventHandler(widgetState *widgetState, eventName string, args ...an
 	if !ok {
 		return nil, false
 	}
-	f := reflect.ValueOf(hanlder)
+	f := reflect.ValueOf(hanlder.f)
 	widgetState.tmpArgs = slices.Delete(widgetState.tmpArgs, 0, len(widgetState.tmpArgs))
 	for _, arg := range args {
 		widgetState.tmpArgs = append(widgetState.tmpArgs, reflect.ValueOf(arg))